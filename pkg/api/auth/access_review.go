@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	authzv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	typedauthzv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+
+	"github.com/oneblock-ai/oneblock/pkg/server/config"
+)
+
+// AccessReviewer checks the RBAC permission of the request user by the SubjectAccessReview,
+// the user is set to the request context by the AuthMiddleware
+type AccessReviewer struct {
+	sar typedauthzv1.SubjectAccessReviewInterface
+}
+
+func NewAccessReviewer(management *config.Management) *AccessReviewer {
+	return &AccessReviewer{
+		sar: management.ClientSet.AuthorizationV1().SubjectAccessReviews(),
+	}
+}
+
+func (a *AccessReviewer) CanAccess(ctx context.Context, attrs *authzv1.ResourceAttributes) (bool, error) {
+	userInfo, ok := request.UserFrom(ctx)
+	if !ok {
+		return false, errors.New("failed to get user from request")
+	}
+
+	extra := make(map[string]authzv1.ExtraValue, len(userInfo.GetExtra()))
+	for k, v := range userInfo.GetExtra() {
+		extra[k] = v
+	}
+
+	review, err := a.sar.Create(ctx, &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			ResourceAttributes: attrs,
+			User:               userInfo.GetName(),
+			Groups:             userInfo.GetGroups(),
+			UID:                userInfo.GetUID(),
+			Extra:              extra,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
+
+// RemoveCredentials removes the session token of the request before it is proxied to the user workloads
+func RemoveCredentials(req *http.Request) {
+	req.Header.Del("Authorization")
+
+	cookies := req.Cookies()
+	req.Header.Del("Cookie")
+	for _, cookie := range cookies {
+		if !strings.EqualFold(cookie.Name, cookieName) {
+			req.AddCookie(cookie)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package modeltemplate
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+)
+
+const (
+	bundleKind = "ModelTemplateBundle"
+
+	bundleFormatYAML = "yaml"
+	bundleFormatTar  = "tar"
+
+	// the tar bundle contains the template and a file of each version under the versions dir
+	bundleTemplateFile = "template.yaml"
+	bundleVersionsDir  = "versions"
+)
+
+// Bundle is a portable representation of a model template and all of its versions,
+// it can be exported from one namespace or cluster and imported into another one
+// as a single YAML document or a tar archive of YAML files.
+type Bundle struct {
+	APIVersion string                      `json:"apiVersion"`
+	Kind       string                      `json:"kind"`
+	Template   mlv1.ModelTemplate          `json:"template"`
+	Versions   []mlv1.ModelTemplateVersion `json:"versions,omitempty"`
+}
+
+// newBundle builds a bundle from the template and its versions, the versions are ordered by version number
+func newBundle(tp *mlv1.ModelTemplate, tpvs []*mlv1.ModelTemplateVersion) *Bundle {
+	bundle := &Bundle{
+		APIVersion: mlv1.SchemeGroupVersion.String(),
+		Kind:       bundleKind,
+		Template: mlv1.ModelTemplate{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: mlv1.SchemeGroupVersion.String(),
+				Kind:       "ModelTemplate",
+			},
+			ObjectMeta: portableObjectMeta(tp.ObjectMeta),
+			Spec:       tp.Spec,
+			Status: mlv1.ModelTemplateStatus{
+				DefaultVersion: tp.Status.DefaultVersion,
+				LatestVersion:  tp.Status.LatestVersion,
+			},
+		},
+	}
+
+	for _, tpv := range tpvs {
+		bundle.Versions = append(bundle.Versions, mlv1.ModelTemplateVersion{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: mlv1.SchemeGroupVersion.String(),
+				Kind:       "ModelTemplateVersion",
+			},
+			ObjectMeta: portableObjectMeta(tpv.ObjectMeta),
+			Spec:       tpv.Spec,
+			Status: mlv1.ModelTemplateVersionStatus{
+				GeneratedModelConfig: tpv.Status.GeneratedModelConfig,
+				Version:              tpv.Status.Version,
+			},
+		})
+	}
+	sortVersions(bundle.Versions)
+
+	return bundle
+}
+
+// sortVersions orders the versions by version number, so that the versions are imported in the same order
+func sortVersions(versions []mlv1.ModelTemplateVersion) {
+	sort.Slice(versions, func(i, j int) bool {
+		if versions[i].Status.Version == versions[j].Status.Version {
+			return versions[i].Name < versions[j].Name
+		}
+		return versions[i].Status.Version < versions[j].Status.Version
+	})
+}
+
+// marshal encodes the bundle in the format, the YAML format is used by default
+func (b *Bundle) marshal(format string) ([]byte, error) {
+	switch format {
+	case "", bundleFormatYAML:
+		return yaml.Marshal(b)
+	case bundleFormatTar:
+		return b.marshalTar()
+	default:
+		return nil, fmt.Errorf("unsupported bundle format %q, expected %s or %s", format, bundleFormatYAML, bundleFormatTar)
+	}
+}
+
+func (b *Bundle) marshalTar() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	writeFile := func(name string, obj interface{}) error {
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		if err = tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(data))}); err != nil {
+			return err
+		}
+		_, err = tw.Write(data)
+		return err
+	}
+
+	if err := writeFile(bundleTemplateFile, b.Template); err != nil {
+		return nil, err
+	}
+	for _, v := range b.Versions {
+		if err := writeFile(path.Join(bundleVersionsDir, v.Name+".yaml"), v); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// unmarshalBundle decodes the YAML or tar bundle, the tar archive is detected by the magic of its header
+func unmarshalBundle(data []byte) (*Bundle, error) {
+	if !isTarArchive(data) {
+		bundle := &Bundle{}
+		if err := yaml.Unmarshal(data, bundle); err != nil {
+			return nil, err
+		}
+		sortVersions(bundle.Versions)
+		return bundle, nil
+	}
+
+	bundle := &Bundle{
+		APIVersion: mlv1.SchemeGroupVersion.String(),
+		Kind:       bundleKind,
+	}
+	tr := tar.NewReader(bytes.NewReader(data))
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		name := path.Clean(header.Name)
+		switch {
+		case name == bundleTemplateFile:
+			if err = yaml.Unmarshal(content, &bundle.Template); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+			}
+		case path.Dir(name) == bundleVersionsDir:
+			version := mlv1.ModelTemplateVersion{}
+			if err = yaml.Unmarshal(content, &version); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+			}
+			bundle.Versions = append(bundle.Versions, version)
+		}
+	}
+	sortVersions(bundle.Versions)
+	return bundle, nil
+}
+
+// isTarArchive checks the ustar magic of the first tar header
+func isTarArchive(data []byte) bool {
+	const magicOffset = 257
+	return len(data) >= magicOffset+5 && string(data[magicOffset:magicOffset+5]) == "ustar"
+}
+
+// toObjects converts the bundle to the template and versions that will be created in the target namespace,
+// the template name of the versions and the default version ref of the template are remapped to the namespace.
+// The version numbers are kept by the annotation of the versions.
+func (b *Bundle) toObjects(namespace string) (*mlv1.ModelTemplate, []*mlv1.ModelTemplateVersion, error) {
+	if b.Kind != bundleKind {
+		return nil, nil, fmt.Errorf("invalid bundle kind %q, expected %q", b.Kind, bundleKind)
+	}
+	if b.Template.Name == "" {
+		return nil, nil, fmt.Errorf("the model template name of the bundle is empty")
+	}
+
+	tp := &mlv1.ModelTemplate{
+		ObjectMeta: portableObjectMeta(b.Template.ObjectMeta),
+		Spec:       b.Template.Spec,
+	}
+	tp.Namespace = namespace
+
+	versionNames := make(map[string]bool, len(b.Versions))
+	versionNumbers := make(map[int]bool, len(b.Versions))
+	tpvs := make([]*mlv1.ModelTemplateVersion, 0, len(b.Versions))
+	for _, v := range b.Versions {
+		if v.Name == "" {
+			return nil, nil, fmt.Errorf("the model template version name of the bundle is empty")
+		}
+		tpv := &mlv1.ModelTemplateVersion{
+			ObjectMeta: portableObjectMeta(v.ObjectMeta),
+			Spec:       v.Spec,
+		}
+		tpv.Namespace = namespace
+		tpv.Spec.TemplateName = tp.Name
+		if tpv.Labels == nil {
+			tpv.Labels = make(map[string]string, 1)
+		}
+		tpv.Labels[constant.LabelModelTemplateName] = tp.Name
+		if v.Status.Version > 0 {
+			if versionNumbers[v.Status.Version] {
+				return nil, nil, fmt.Errorf("duplicated model template version number %d in the bundle", v.Status.Version)
+			}
+			versionNumbers[v.Status.Version] = true
+			if tpv.Annotations == nil {
+				tpv.Annotations = make(map[string]string, 1)
+			}
+			tpv.Annotations[constant.AnnotationModelTemplateVersion] = strconv.Itoa(v.Status.Version)
+		}
+
+		versionNames[tpv.Name] = true
+		tpvs = append(tpvs, tpv)
+	}
+
+	if tp.Spec.DefaultVersionID != "" {
+		_, name := utils.Ref(tp.Spec.DefaultVersionID).Parse()
+		if !versionNames[name] {
+			return nil, nil, fmt.Errorf("the default version %s is not included in the bundle", tp.Spec.DefaultVersionID)
+		}
+		tp.Spec.DefaultVersionID = utils.NewRef(namespace, name)
+	}
+
+	return tp, tpvs, nil
+}
+
+// portableObjectMeta only keeps the metadata that can be applied to another namespace or cluster
+func portableObjectMeta(meta metav1.ObjectMeta) metav1.ObjectMeta {
+	portable := metav1.ObjectMeta{
+		Name:      meta.Name,
+		Namespace: meta.Namespace,
+	}
+	if len(meta.Labels) > 0 {
+		portable.Labels = make(map[string]string, len(meta.Labels))
+		for k, v := range meta.Labels {
+			portable.Labels[k] = v
+		}
+	}
+	if len(meta.Annotations) > 0 {
+		portable.Annotations = make(map[string]string, len(meta.Annotations))
+		for k, v := range meta.Annotations {
+			if k == corev1.LastAppliedConfigAnnotation {
+				continue
+			}
+			portable.Annotations[k] = v
+		}
+	}
+	return portable
+}
@@ -0,0 +1,96 @@
+package modeltemplate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+)
+
+func Test_bundleRoundTrip(t *testing.T) {
+	tp := &mlv1.ModelTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "llama2",
+			Namespace:       "staging",
+			UID:             "uid-1",
+			ResourceVersion: "100",
+			Labels:          map[string]string{"team": "nlp"},
+		},
+		Spec: mlv1.ModelTemplateSpec{
+			DefaultVersionID: "staging/llama2-v2",
+		},
+	}
+	tpvs := []*mlv1.ModelTemplateVersion{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "llama2-v2",
+				Namespace: "staging",
+				Labels:    map[string]string{constant.LabelModelTemplateName: "llama2"},
+			},
+			Spec:   mlv1.ModelTemplateVersionSpec{TemplateName: "llama2", ModelID: "meta-llama/Llama-2-7b-chat-hf"},
+			Status: mlv1.ModelTemplateVersionStatus{Version: 2, GeneratedModelConfig: "v2-config"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "llama2-v1",
+				Namespace: "staging",
+				Labels:    map[string]string{constant.LabelModelTemplateName: "llama2"},
+			},
+			Spec:   mlv1.ModelTemplateVersionSpec{TemplateName: "llama2", ModelID: "meta-llama/Llama-2-7b-hf"},
+			Status: mlv1.ModelTemplateVersionStatus{Version: 1, GeneratedModelConfig: "v1-config"},
+		},
+	}
+
+	for _, format := range []string{bundleFormatYAML, bundleFormatTar} {
+		data, err := newBundle(tp, tpvs).marshal(format)
+		require.NoError(t, err, format)
+		assert.Equal(t, format == bundleFormatTar, isTarArchive(data), format)
+
+		bundle, err := unmarshalBundle(data)
+		require.NoError(t, err, format)
+		assert.Equal(t, bundleKind, bundle.Kind, format)
+		assert.Equal(t, "llama2", bundle.Template.Name, format)
+		require.Len(t, bundle.Versions, 2, format)
+		assert.Equal(t, "llama2-v1", bundle.Versions[0].Name, format)
+		assert.Equal(t, "v1-config", bundle.Versions[0].Status.GeneratedModelConfig, format)
+	}
+
+	_, err := newBundle(tp, tpvs).marshal("zip")
+	assert.Error(t, err)
+
+	data, err := yaml.Marshal(newBundle(tp, tpvs))
+	require.NoError(t, err)
+	bundle, err := unmarshalBundle(data)
+	require.NoError(t, err)
+
+	newTp, newTpvs, err := bundle.toObjects("production")
+	require.NoError(t, err)
+	assert.Equal(t, "production", newTp.Namespace)
+	assert.Empty(t, newTp.UID)
+	assert.Empty(t, newTp.ResourceVersion)
+	assert.Equal(t, "nlp", newTp.Labels["team"])
+	assert.Equal(t, "production/llama2-v2", newTp.Spec.DefaultVersionID)
+	// the versions are created in order and keep their version numbers
+	require.Len(t, newTpvs, 2)
+	assert.Equal(t, "1", newTpvs[0].Annotations[constant.AnnotationModelTemplateVersion])
+	assert.Equal(t, "2", newTpvs[1].Annotations[constant.AnnotationModelTemplateVersion])
+	for _, tpv := range newTpvs {
+		assert.Equal(t, "production", tpv.Namespace)
+		assert.Equal(t, "llama2", tpv.Spec.TemplateName)
+		assert.Equal(t, "llama2", tpv.Labels[constant.LabelModelTemplateName])
+		assert.Empty(t, tpv.Status.GeneratedModelConfig)
+	}
+
+	bundle.Versions[1].Status.Version = 1
+	_, _, err = bundle.toObjects("production")
+	assert.Error(t, err, "duplicated version number")
+
+	bundle.Versions = bundle.Versions[:1]
+	_, _, err = bundle.toObjects("production")
+	assert.Error(t, err)
+}
@@ -0,0 +1,143 @@
+package modeltemplate
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"sigs.k8s.io/yaml"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+)
+
+// CompareInput specifies the model template version to compare with, either by the version ref
+// (<namespace>/<name> or <name>) or by the version number of the same model template.
+type CompareInput struct {
+	VersionID string `json:"versionId,omitempty"`
+	Version   int    `json:"version,omitempty"`
+}
+
+type VersionSummary struct {
+	Name         string `json:"name"`
+	Namespace    string `json:"namespace"`
+	TemplateName string `json:"templateName"`
+	Version      int    `json:"version"`
+}
+
+// FieldDiff describes a changed field, a missing From or To means the field is added or removed
+type FieldDiff struct {
+	Path string      `json:"path"`
+	From interface{} `json:"from,omitempty"`
+	To   interface{} `json:"to,omitempty"`
+}
+
+type CompareOutput struct {
+	From                 VersionSummary `json:"from"`
+	To                   VersionSummary `json:"to"`
+	Spec                 []FieldDiff    `json:"spec"`
+	GeneratedModelConfig []FieldDiff    `json:"generatedModelConfig"`
+}
+
+func versionSummary(tpv *mlv1.ModelTemplateVersion) VersionSummary {
+	return VersionSummary{
+		Name:         tpv.Name,
+		Namespace:    tpv.Namespace,
+		TemplateName: tpv.Spec.TemplateName,
+		Version:      tpv.Status.Version,
+	}
+}
+
+// compareVersions returns the structured diff of the spec and generated model config from one version to another
+func compareVersions(from, to *mlv1.ModelTemplateVersion) (*CompareOutput, error) {
+	fromSpec, err := toGeneric(from.Spec)
+	if err != nil {
+		return nil, err
+	}
+	toSpec, err := toGeneric(to.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &CompareOutput{
+		From:                 versionSummary(from),
+		To:                   versionSummary(to),
+		Spec:                 []FieldDiff{},
+		GeneratedModelConfig: []FieldDiff{},
+	}
+	output.Spec = diffValues("", fromSpec, toSpec, output.Spec)
+	output.GeneratedModelConfig = diffValues("", parseModelConfig(from.Status.GeneratedModelConfig),
+		parseModelConfig(to.Status.GeneratedModelConfig), output.GeneratedModelConfig)
+
+	return output, nil
+}
+
+func toGeneric(obj interface{}) (interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %T: %w", obj, err)
+	}
+	var generic interface{}
+	if err = json.Unmarshal(data, &generic); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %T: %w", obj, err)
+	}
+	return generic, nil
+}
+
+// parseModelConfig parses the generated model config YAML, the raw string is returned if it is not a valid YAML
+func parseModelConfig(config string) interface{} {
+	if config == "" {
+		return nil
+	}
+	var generic interface{}
+	if err := yaml.Unmarshal([]byte(config), &generic); err != nil {
+		return config
+	}
+	return generic
+}
+
+// diffValues recursively compares two generic JSON values and appends the changed leaf fields to diffs
+func diffValues(path string, from, to interface{}, diffs []FieldDiff) []FieldDiff {
+	fromMap, fromIsMap := from.(map[string]interface{})
+	toMap, toIsMap := to.(map[string]interface{})
+	if fromIsMap && toIsMap {
+		keys := make(map[string]struct{}, len(fromMap)+len(toMap))
+		for k := range fromMap {
+			keys[k] = struct{}{}
+		}
+		for k := range toMap {
+			keys[k] = struct{}{}
+		}
+		sortedKeys := make([]string, 0, len(keys))
+		for k := range keys {
+			sortedKeys = append(sortedKeys, k)
+		}
+		sort.Strings(sortedKeys)
+		for _, k := range sortedKeys {
+			diffs = diffValues(joinPath(path, k), fromMap[k], toMap[k], diffs)
+		}
+		return diffs
+	}
+
+	fromSlice, fromIsSlice := from.([]interface{})
+	toSlice, toIsSlice := to.([]interface{})
+	if fromIsSlice && toIsSlice && len(fromSlice) == len(toSlice) {
+		for i := range fromSlice {
+			diffs = diffValues(path+"["+strconv.Itoa(i)+"]", fromSlice[i], toSlice[i], diffs)
+		}
+		return diffs
+	}
+
+	if !reflect.DeepEqual(from, to) {
+		diffs = append(diffs, FieldDiff{Path: path, From: from, To: to})
+	}
+	return diffs
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,51 @@
+package modeltemplate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+)
+
+func Test_compareVersions(t *testing.T) {
+	from := &mlv1.ModelTemplateVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama2-v3", Namespace: "default"},
+		Spec: mlv1.ModelTemplateVersionSpec{
+			TemplateName: "llama2",
+			ModelID:      "meta-llama/Llama-2-7b-chat-hf",
+			DeploymentConfig: mlv1.DeploymentConfig{
+				Replicas:             1,
+				MaxReplicas:          2,
+				MaxConcurrentQueries: 64,
+			},
+			EngineConfig: mlv1.EngineConfig{
+				Generation: mlv1.GenerationConfig{StoppingSequences: []string{"</s>"}},
+			},
+		},
+		Status: mlv1.ModelTemplateVersionStatus{
+			Version:              3,
+			GeneratedModelConfig: "deployment_config:\n  max_concurrent_queries: 64\n",
+		},
+	}
+	to := from.DeepCopy()
+	to.Name = "llama2-v4"
+	to.Status.Version = 4
+	to.Spec.DeploymentConfig.MaxReplicas = 4
+	to.Spec.Description = "more replicas"
+	to.Status.GeneratedModelConfig = "deployment_config:\n  max_concurrent_queries: 128\n"
+
+	output, err := compareVersions(from, to)
+	require.NoError(t, err)
+	assert.Equal(t, 3, output.From.Version)
+	assert.Equal(t, 4, output.To.Version)
+	assert.Equal(t, []FieldDiff{
+		{Path: "deploymentConfig.maxReplicas", From: float64(2), To: float64(4)},
+		{Path: "description", To: "more replicas"},
+	}, output.Spec)
+	assert.Equal(t, []FieldDiff{
+		{Path: "deployment_config.max_concurrent_queries", From: float64(64), To: float64(128)},
+	}, output.GeneratedModelConfig)
+}
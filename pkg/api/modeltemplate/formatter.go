@@ -0,0 +1,273 @@
+package modeltemplate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/oneblock-ai/apiserver/v2/pkg/apierror"
+	"github.com/oneblock-ai/apiserver/v2/pkg/types"
+	"github.com/rancher/wrangler/v2/pkg/schemas/validation"
+	"github.com/sirupsen/logrus"
+	authzv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/retry"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+)
+
+func formatter(request *types.APIRequest, resource *types.RawResource) {
+	resource.Actions = make(map[string]string, 1)
+	resource.AddAction(request, ActionExport)
+}
+
+func versionFormatter(request *types.APIRequest, resource *types.RawResource) {
+	resource.Actions = make(map[string]string, 1)
+	resource.AddAction(request, ActionCompare)
+}
+
+func (h Handler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if err := h.do(rw, req); err != nil {
+		status := http.StatusInternalServerError
+		var e *apierror.APIError
+		if errors.As(err, &e) {
+			status = e.Code.Status
+		}
+		rw.WriteHeader(status)
+		_, _ = rw.Write([]byte(err.Error()))
+		return
+	}
+}
+
+func (h Handler) do(rw http.ResponseWriter, req *http.Request) error {
+	vars := utils.EncodeVars(mux.Vars(req))
+	if req.Method == http.MethodPost {
+		return h.doPost(vars["action"], rw, req)
+	}
+
+	return apierror.NewAPIError(validation.InvalidAction, fmt.Sprintf("Unsupported method %s", req.Method))
+}
+
+func (h Handler) doPost(action string, rw http.ResponseWriter, req *http.Request) error {
+	vars := utils.EncodeVars(mux.Vars(req))
+	switch action {
+	case ActionExport:
+		return h.exportTemplate(rw, vars["namespace"], vars["name"], req.URL.Query().Get("format"))
+	case ActionCompare:
+		return h.compareVersion(rw, req, vars["namespace"], vars["name"])
+	case ActionImport:
+		// the collection action is served by the /v1/{type}/{namespace} route
+		return h.importTemplate(rw, req, vars["nameorns"])
+	default:
+		return apierror.NewAPIError(validation.InvalidAction, fmt.Sprintf("Unsupported POST action %s", action))
+	}
+}
+
+// exportTemplate writes the template and all its versions as a single YAML bundle, or a tar archive if the format
+// is tar
+func (h Handler) exportTemplate(rw http.ResponseWriter, namespace, name, format string) error {
+	logrus.Debugf("Export model template %s/%s", namespace, name)
+	tp, err := h.templateCache.Get(namespace, name)
+	if err != nil {
+		return err
+	}
+
+	selector := labels.Set(map[string]string{constant.LabelModelTemplateName: tp.Name}).AsSelector()
+	tpvs, err := h.templateVersionCache.List(namespace, selector)
+	if err != nil {
+		return err
+	}
+
+	data, err := newBundle(tp, tpvs).marshal(format)
+	if err != nil {
+		return apierror.NewAPIError(validation.InvalidOption, fmt.Sprintf("Failed to marshal model template bundle: %v", err))
+	}
+
+	contentType, ext := "application/yaml", bundleFormatYAML
+	if format == bundleFormatTar {
+		contentType, ext = "application/x-tar", bundleFormatTar
+	}
+	rw.Header().Set("Content-Type", contentType)
+	rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s-%s.%s", namespace, name, ext))
+	rw.WriteHeader(http.StatusOK)
+	if _, err = rw.Write(data); err != nil {
+		logrus.Errorf("failed to write model template bundle: %v", err)
+	}
+	return nil
+}
+
+// importTemplate recreates the template and its versions of the YAML or tar bundle in the target namespace. The
+// versions are created in order and keep their version numbers, the default version is set after all the versions
+// are created, and the created objects are deleted if the import fails.
+func (h Handler) importTemplate(rw http.ResponseWriter, req *http.Request, namespace string) error {
+	if namespace == "" {
+		return apierror.NewAPIError(validation.MissingRequired, "target namespace is required to import a model template bundle")
+	}
+
+	// the template and versions are created by the API server, check the user is allowed to create them
+	for _, resource := range []string{"modeltemplates", "modeltemplateversions"} {
+		allowed, err := h.reviewer.CanAccess(req.Context(), &authzv1.ResourceAttributes{
+			Namespace: namespace,
+			Verb:      "create",
+			Group:     mlv1.SchemeGroupVersion.Group,
+			Resource:  resource,
+		})
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return apierror.NewAPIError(validation.PermissionDenied,
+				fmt.Sprintf("creating %s in namespace %s is forbidden", resource, namespace))
+		}
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(rw, req.Body, maxBundleSize))
+	if err != nil {
+		return apierror.NewAPIError(validation.InvalidBodyContent, fmt.Sprintf("Failed to read request body: %v", err))
+	}
+
+	bundle, err := unmarshalBundle(data)
+	if err != nil {
+		return apierror.NewAPIError(validation.InvalidBodyContent, fmt.Sprintf("Failed to parse model template bundle: %v", err))
+	}
+
+	tp, tpvs, err := bundle.toObjects(namespace)
+	if err != nil {
+		return apierror.NewAPIError(validation.InvalidBodyContent, err.Error())
+	}
+
+	logrus.Debugf("Import model template %s/%s with %d versions", tp.Namespace, tp.Name, len(tpvs))
+	defaultVersionID := tp.Spec.DefaultVersionID
+	tp.Spec.DefaultVersionID = ""
+	created, err := h.templates.Create(tp)
+	if err != nil {
+		return fmt.Errorf("failed to create model template %s/%s: %w", tp.Namespace, tp.Name, err)
+	}
+
+	createdVersions := make([]*mlv1.ModelTemplateVersion, 0, len(tpvs))
+	for _, tpv := range tpvs {
+		createdVersion, err := h.templateVersions.Create(tpv)
+		if err != nil {
+			h.deleteImportedTemplate(created, createdVersions)
+			return fmt.Errorf("failed to create model template version %s/%s: %w", tpv.Namespace, tpv.Name, err)
+		}
+		createdVersions = append(createdVersions, createdVersion)
+	}
+
+	if defaultVersionID != "" {
+		if created, err = h.setDefaultVersion(created, defaultVersionID); err != nil {
+			h.deleteImportedTemplate(created, createdVersions)
+			return fmt.Errorf("failed to set the default version of model template %s/%s: %w", tp.Namespace, tp.Name, err)
+		}
+	}
+
+	utils.ResponseOKWithBody(rw, created)
+	return nil
+}
+
+// setDefaultVersion sets the default version of the imported template, the template may be updated by the
+// controllers at the same time
+func (h Handler) setDefaultVersion(tp *mlv1.ModelTemplate, defaultVersionID string) (*mlv1.ModelTemplate, error) {
+	var updated *mlv1.ModelTemplate
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest, err := h.templates.Get(tp.Namespace, tp.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		latestCpy := latest.DeepCopy()
+		latestCpy.Spec.DefaultVersionID = defaultVersionID
+		updated, err = h.templates.Update(latestCpy)
+		return err
+	})
+	if err != nil {
+		return tp, err
+	}
+	return updated, nil
+}
+
+// deleteImportedTemplate rolls back the failed import by deleting the created versions and template
+func (h Handler) deleteImportedTemplate(tp *mlv1.ModelTemplate, tpvs []*mlv1.ModelTemplateVersion) {
+	for _, tpv := range tpvs {
+		if err := h.templateVersions.Delete(tpv.Namespace, tpv.Name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			logrus.Errorf("failed to delete imported model template version %s/%s: %v", tpv.Namespace, tpv.Name, err)
+		}
+	}
+	if err := h.templates.Delete(tp.Namespace, tp.Name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		logrus.Errorf("failed to delete imported model template %s/%s: %v", tp.Namespace, tp.Name, err)
+	}
+}
+
+// compareVersion returns the diff from the requested model template version to the given one
+func (h Handler) compareVersion(rw http.ResponseWriter, req *http.Request, namespace, name string) error {
+	input := &CompareInput{}
+	if err := json.NewDecoder(req.Body).Decode(input); err != nil {
+		return apierror.NewAPIError(validation.InvalidBodyContent, fmt.Sprintf("Failed to decode request body: %v", err))
+	}
+
+	from, err := h.templateVersionCache.Get(namespace, name)
+	if err != nil {
+		return err
+	}
+
+	to, err := h.getCompareTarget(from, input)
+	if err != nil {
+		return err
+	}
+
+	output, err := compareVersions(from, to)
+	if err != nil {
+		return err
+	}
+
+	utils.ResponseOKWithBody(rw, output)
+	return nil
+}
+
+func (h Handler) getCompareTarget(from *mlv1.ModelTemplateVersion, input *CompareInput) (*mlv1.ModelTemplateVersion, error) {
+	// the versions are read from the cache, so only the versions of the same template are allowed to be compared
+	// with, the caller is authorized to get the template version of the request
+	if input.VersionID != "" {
+		namespace, name := utils.Ref(input.VersionID).Parse()
+		if namespace == "" {
+			namespace = from.Namespace
+		}
+		if namespace != from.Namespace {
+			return nil, apierror.NewAPIError(validation.InvalidOption,
+				fmt.Sprintf("version %s isn't in namespace %s of the compared version", input.VersionID, from.Namespace))
+		}
+		to, err := h.templateVersionCache.Get(namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		if to.Spec.TemplateName != from.Spec.TemplateName {
+			return nil, apierror.NewAPIError(validation.InvalidOption,
+				fmt.Sprintf("version %s doesn't belong to model template %s", input.VersionID, from.Spec.TemplateName))
+		}
+		return to, nil
+	}
+
+	if input.Version <= 0 {
+		return nil, apierror.NewAPIError(validation.MissingRequired, "either versionId or version is required to compare")
+	}
+
+	selector := labels.Set(map[string]string{constant.LabelModelTemplateName: from.Spec.TemplateName}).AsSelector()
+	tpvs, err := h.templateVersionCache.List(from.Namespace, selector)
+	if err != nil {
+		return nil, err
+	}
+	for _, tpv := range tpvs {
+		if tpv.Status.Version == input.Version {
+			return tpv, nil
+		}
+	}
+
+	return nil, apierror.NewAPIError(validation.NotFound, fmt.Sprintf("version %d of model template %s/%s is not found",
+		input.Version, from.Namespace, from.Spec.TemplateName))
+}
@@ -0,0 +1,80 @@
+package modeltemplate
+
+import (
+	"net/http"
+
+	"github.com/oneblock-ai/apiserver/v2/pkg/types"
+	"github.com/oneblock-ai/steve/v2/pkg/schema"
+	"github.com/oneblock-ai/steve/v2/pkg/server"
+	"github.com/rancher/wrangler/v2/pkg/schemas"
+
+	"github.com/oneblock-ai/oneblock/pkg/api/auth"
+	ctlmlv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ml.oneblock.ai/v1"
+	"github.com/oneblock-ai/oneblock/pkg/server/config"
+)
+
+const (
+	modelTemplateSchemaID        = "ml.oneblock.ai.modeltemplate"
+	modelTemplateVersionSchemaID = "ml.oneblock.ai.modeltemplateversion"
+
+	ActionExport  = "export"
+	ActionImport  = "import"
+	ActionCompare = "compare"
+
+	// maxBundleSize is the max size of the imported model template bundle
+	maxBundleSize = 4 * 1024 * 1024
+)
+
+type Handler struct {
+	templates            ctlmlv1.ModelTemplateClient
+	templateCache        ctlmlv1.ModelTemplateCache
+	templateVersions     ctlmlv1.ModelTemplateVersionClient
+	templateVersionCache ctlmlv1.ModelTemplateVersionCache
+	reviewer             *auth.AccessReviewer
+}
+
+func RegisterSchema(mgmt *config.Management, server *server.Server) error {
+	templates := mgmt.OneBlockMLFactory.Ml().V1().ModelTemplate()
+	templateVersions := mgmt.OneBlockMLFactory.Ml().V1().ModelTemplateVersion()
+	h := Handler{
+		templates:            templates,
+		templateCache:        templates.Cache(),
+		templateVersions:     templateVersions,
+		templateVersionCache: templateVersions.Cache(),
+		reviewer:             auth.NewAccessReviewer(mgmt),
+	}
+
+	t := []schema.Template{
+		{
+			ID:        modelTemplateSchemaID,
+			Formatter: formatter,
+			Customize: func(apiSchema *types.APISchema) {
+				apiSchema.ResourceActions = map[string]schemas.Action{
+					ActionExport: {},
+				}
+				apiSchema.CollectionActions = map[string]schemas.Action{
+					ActionImport: {},
+				}
+				apiSchema.ActionHandlers = map[string]http.Handler{
+					ActionExport: h,
+					ActionImport: h,
+				}
+			},
+		},
+		{
+			ID:        modelTemplateVersionSchemaID,
+			Formatter: versionFormatter,
+			Customize: func(apiSchema *types.APISchema) {
+				apiSchema.ResourceActions = map[string]schemas.Action{
+					ActionCompare: {},
+				}
+				apiSchema.ActionHandlers = map[string]http.Handler{
+					ActionCompare: h,
+				}
+			},
+		},
+	}
+
+	server.SchemaFactory.AddTemplate(t...)
+	return nil
+}
@@ -0,0 +1,183 @@
+package notebook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/oneblock-ai/apiserver/v2/pkg/apierror"
+	"github.com/oneblock-ai/apiserver/v2/pkg/types"
+	"github.com/rancher/wrangler/v2/pkg/schemas/validation"
+	"github.com/sirupsen/logrus"
+	authzv1 "k8s.io/api/authorization/v1"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+)
+
+func formatter(request *types.APIRequest, resource *types.RawResource) {
+	resource.Actions = make(map[string]string, 1)
+	if resource.APIObject.Data().String("metadata", "annotations", constant.AnnotationResourceStopped) != "" {
+		resource.AddAction(request, ActionStart)
+	} else {
+		resource.AddAction(request, ActionStop)
+	}
+	resource.AddAction(request, ActionSnapshot)
+	resource.AddAction(request, ActionClone)
+}
+
+func (h Handler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if err := h.do(rw, req); err != nil {
+		status := http.StatusInternalServerError
+		var e *apierror.APIError
+		if errors.As(err, &e) {
+			status = e.Code.Status
+		}
+		rw.WriteHeader(status)
+		_, _ = rw.Write([]byte(err.Error()))
+		return
+	}
+}
+
+func (h Handler) do(rw http.ResponseWriter, req *http.Request) error {
+	vars := utils.EncodeVars(mux.Vars(req))
+	if req.Method == http.MethodPost {
+		return h.doPost(vars["action"], rw, req)
+	}
+
+	return apierror.NewAPIError(validation.InvalidAction, fmt.Sprintf("Unsupported method %s", req.Method))
+}
+
+func (h Handler) doPost(action string, rw http.ResponseWriter, req *http.Request) error {
+	vars := utils.EncodeVars(mux.Vars(req))
+	switch action {
+	case ActionStop:
+		if err := h.authorizeUpdate(req, vars["namespace"], vars["name"]); err != nil {
+			return err
+		}
+		if err := h.stopNotebook(vars["namespace"], vars["name"]); err != nil {
+			return err
+		}
+	case ActionStart:
+		if err := h.authorizeUpdate(req, vars["namespace"], vars["name"]); err != nil {
+			return err
+		}
+		if err := h.startNotebook(vars["namespace"], vars["name"]); err != nil {
+			return err
+		}
+	case ActionSnapshot:
+		return h.doSnapshot(rw, req, vars["namespace"], vars["name"])
+	case ActionClone:
+		return h.doClone(rw, req, vars["namespace"], vars["name"])
+	default:
+		return apierror.NewAPIError(validation.InvalidAction, fmt.Sprintf("Unsupported POST action %s", action))
+	}
+	rw.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (h Handler) doSnapshot(rw http.ResponseWriter, req *http.Request, namespace, name string) error {
+	input := &SnapshotInput{}
+	if err := decodeInput(req, input); err != nil {
+		return err
+	}
+
+	notebook, err := h.notebookCache.Get(namespace, name)
+	if err != nil {
+		return err
+	}
+	if err = h.authorizeSnapshot(req.Context(), notebook); err != nil {
+		return err
+	}
+	output, err := h.snapshotNotebook(req.Context(), notebook, input)
+	if err != nil {
+		return err
+	}
+
+	utils.ResponseOKWithBody(rw, output)
+	return nil
+}
+
+func (h Handler) doClone(rw http.ResponseWriter, req *http.Request, namespace, name string) error {
+	input := &CloneInput{}
+	if err := decodeInput(req, input); err != nil {
+		return err
+	}
+
+	notebook, err := h.notebookCache.Get(namespace, name)
+	if err != nil {
+		return err
+	}
+	clone, err := h.cloneNotebook(req.Context(), notebook, input)
+	if err != nil {
+		return err
+	}
+
+	utils.ResponseOKWithBody(rw, clone)
+	return nil
+}
+
+// decodeInput decodes the optional action input of the request body
+func decodeInput(req *http.Request, input interface{}) error {
+	if req.Body == nil || req.ContentLength == 0 {
+		return nil
+	}
+	if err := json.NewDecoder(req.Body).Decode(input); err != nil && !errors.Is(err, io.EOF) {
+		return apierror.NewAPIError(validation.InvalidBodyContent, fmt.Sprintf("Failed to decode request body: %v", err))
+	}
+	return nil
+}
+
+// authorizeUpdate checks the user is allowed to update the notebook, the replicas are patched by the API server
+func (h Handler) authorizeUpdate(req *http.Request, namespace, name string) error {
+	return h.authorize(req.Context(), &authzv1.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      "update",
+		Group:     mlv1.SchemeGroupVersion.Group,
+		Resource:  "notebooks",
+		Name:      name,
+	})
+}
+
+// stopNotebook scales the notebook down to zero by the stopped annotation, the volumes are kept
+func (h Handler) stopNotebook(namespace, name string) error {
+	logrus.Debugf("Stop notebook %s/%s", namespace, name)
+	notebook, err := h.notebookCache.Get(namespace, name)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := notebook.Annotations[constant.AnnotationResourceStopped]; ok {
+		return nil
+	}
+
+	nbCpy := notebook.DeepCopy()
+	if nbCpy.Annotations == nil {
+		nbCpy.Annotations = make(map[string]string, 1)
+	}
+	nbCpy.Annotations[constant.AnnotationResourceStopped] = time.Now().UTC().Format(time.RFC3339)
+	_, err = h.notebooks.Update(nbCpy)
+	return err
+}
+
+func (h Handler) startNotebook(namespace, name string) error {
+	logrus.Debugf("Start notebook %s/%s", namespace, name)
+	notebook, err := h.notebookCache.Get(namespace, name)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := notebook.Annotations[constant.AnnotationResourceStopped]; !ok {
+		return nil
+	}
+
+	nbCpy := notebook.DeepCopy()
+	delete(nbCpy.Annotations, constant.AnnotationResourceStopped)
+	_, err = h.notebooks.Update(nbCpy)
+	return err
+}
@@ -0,0 +1,99 @@
+package notebook
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	authzv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/oneblock-ai/oneblock/pkg/api/auth"
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+	ctlnotebook "github.com/oneblock-ai/oneblock/pkg/controller/notebook"
+	ctlmlv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ml.oneblock.ai/v1"
+	"github.com/oneblock-ai/oneblock/pkg/server/config"
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+)
+
+// ProxyHandler proxies the HTTP and WebSocket requests of /notebooks/{namespace}/{name}/ to the notebook service,
+// the notebook server runs the code of the users as the notebook service account, so the user must have the
+// permission to update the notebook rather than only to get it
+type ProxyHandler struct {
+	notebookCache ctlmlv1.NotebookCache
+	reviewer      *auth.AccessReviewer
+}
+
+func NewProxyHandler(mgmt *config.Management) *ProxyHandler {
+	return &ProxyHandler{
+		notebookCache: mgmt.OneBlockMLFactory.Ml().V1().Notebook().Cache(),
+		reviewer:      auth.NewAccessReviewer(mgmt),
+	}
+}
+
+func (h *ProxyHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	vars := utils.EncodeVars(mux.Vars(req))
+	namespace, name := vars["namespace"], vars["name"]
+
+	allowed, err := h.reviewer.CanAccess(req.Context(), &authzv1.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      "update",
+		Group:     mlv1.SchemeGroupVersion.Group,
+		Resource:  "notebooks",
+		Name:      name,
+	})
+	if err != nil {
+		utils.ResponseError(rw, http.StatusInternalServerError, err)
+		return
+	}
+	if !allowed {
+		utils.ResponseErrorMsg(rw, http.StatusForbidden, fmt.Sprintf("access to notebook %s/%s is forbidden", namespace, name))
+		return
+	}
+
+	notebook, err := h.notebookCache.Get(namespace, name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			utils.ResponseError(rw, http.StatusNotFound, err)
+			return
+		}
+		utils.ResponseError(rw, http.StatusInternalServerError, err)
+		return
+	}
+	if _, ok := notebook.Annotations[constant.AnnotationResourceStopped]; ok {
+		utils.ResponseErrorMsg(rw, http.StatusServiceUnavailable, fmt.Sprintf("notebook %s/%s is stopped", namespace, name))
+		return
+	}
+
+	target, err := url.Parse(ctlnotebook.GetNotebookServerURL(notebook))
+	if err != nil {
+		utils.ResponseError(rw, http.StatusInternalServerError, err)
+		return
+	}
+
+	// the notebook server without the base path is served at the root path, e.g., code-server
+	prefix := ctlnotebook.GetNotebookProxyPrefix(namespace, name)
+	stripPrefix := ctlnotebook.GetNotebookBasePath(notebook) != prefix
+	proxy := &httputil.ReverseProxy{
+		// the original host is kept for the origin check of the notebook websockets
+		Director: func(r *http.Request) {
+			r.URL.Scheme = target.Scheme
+			r.URL.Host = target.Host
+			if stripPrefix {
+				r.URL.Path = "/" + strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, prefix), "/")
+				r.URL.RawPath = ""
+			}
+			auth.RemoveCredentials(r)
+		},
+		ErrorHandler: func(rw http.ResponseWriter, r *http.Request, err error) {
+			logrus.Debugf("Failed to proxy %s to notebook %s/%s: %v", r.URL.Path, namespace, name, err)
+			utils.ResponseError(rw, http.StatusBadGateway, err)
+		},
+	}
+	proxy.ServeHTTP(rw, req)
+}
@@ -0,0 +1,72 @@
+package notebook
+
+import (
+	"net/http"
+
+	"github.com/oneblock-ai/apiserver/v2/pkg/types"
+	"github.com/oneblock-ai/steve/v2/pkg/schema"
+	"github.com/oneblock-ai/steve/v2/pkg/server"
+	ctlcorev1 "github.com/rancher/wrangler/v2/pkg/generated/controllers/core/v1"
+	"github.com/rancher/wrangler/v2/pkg/schemas"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/oneblock-ai/oneblock/pkg/api/auth"
+	ctlmlv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ml.oneblock.ai/v1"
+	"github.com/oneblock-ai/oneblock/pkg/server/config"
+)
+
+const (
+	notebookSchemaID = "ml.oneblock.ai.notebook"
+
+	ActionStop     = "stop"
+	ActionStart    = "start"
+	ActionSnapshot = "snapshot"
+	ActionClone    = "clone"
+)
+
+type Handler struct {
+	notebooks     ctlmlv1.NotebookClient
+	notebookCache ctlmlv1.NotebookCache
+	pvcs          ctlcorev1.PersistentVolumeClaimClient
+	snapshots     dynamic.Interface
+	reviewer      *auth.AccessReviewer
+}
+
+func RegisterSchema(mgmt *config.Management, server *server.Server) error {
+	notebooks := mgmt.OneBlockMLFactory.Ml().V1().Notebook()
+	snapshots, err := dynamic.NewForConfig(mgmt.RestConfig)
+	if err != nil {
+		return err
+	}
+	h := Handler{
+		notebooks:     notebooks,
+		notebookCache: notebooks.Cache(),
+		pvcs:          mgmt.CoreFactory.Core().V1().PersistentVolumeClaim(),
+		snapshots:     snapshots,
+		reviewer:      auth.NewAccessReviewer(mgmt),
+	}
+
+	t := []schema.Template{
+		{
+			ID:        notebookSchemaID,
+			Formatter: formatter,
+			Customize: func(apiSchema *types.APISchema) {
+				apiSchema.ResourceActions = map[string]schemas.Action{
+					ActionStop:     {},
+					ActionStart:    {},
+					ActionSnapshot: {},
+					ActionClone:    {},
+				}
+				apiSchema.ActionHandlers = map[string]http.Handler{
+					ActionStop:     h,
+					ActionStart:    h,
+					ActionSnapshot: h,
+					ActionClone:    h,
+				}
+			},
+		},
+	}
+
+	server.SchemaFactory.AddTemplate(t...)
+	return nil
+}
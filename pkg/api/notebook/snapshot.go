@@ -0,0 +1,465 @@
+package notebook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/oneblock-ai/apiserver/v2/pkg/apierror"
+	"github.com/rancher/wrangler/v2/pkg/schemas/validation"
+	"github.com/sirupsen/logrus"
+	authzv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/pointer"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+	ctlnotebook "github.com/oneblock-ai/oneblock/pkg/controller/notebook"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+)
+
+const (
+	snapshotAPIGroup   = "snapshot.storage.k8s.io"
+	snapshotAPIVersion = snapshotAPIGroup + "/v1"
+
+	snapshotCopyTimeout   = 30 * time.Minute
+	snapshotReadyInterval = 5 * time.Second
+)
+
+var (
+	volumeSnapshotGVR        = schema.GroupVersionResource{Group: snapshotAPIGroup, Version: "v1", Resource: "volumesnapshots"}
+	volumeSnapshotContentGVR = schema.GroupVersionResource{Group: snapshotAPIGroup, Version: "v1", Resource: "volumesnapshotcontents"}
+)
+
+type SnapshotInput struct {
+	// Name is the name of the snapshot, default to <notebook>-<timestamp>
+	Name                    string `json:"name"`
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName"`
+}
+
+type SnapshotOutput struct {
+	Name            string   `json:"name"`
+	VolumeSnapshots []string `json:"volumeSnapshots"`
+}
+
+type CloneInput struct {
+	// Name is the name of the new notebook
+	Name string `json:"name"`
+	// Namespace is the namespace of the new notebook, default to the namespace of the source notebook
+	Namespace string `json:"namespace"`
+	// SnapshotName is the snapshot to restore the volumes from, a new snapshot is taken if it is empty
+	SnapshotName            string `json:"snapshotName"`
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName"`
+}
+
+// authorize checks the user is allowed to access all the resources, since the actions are done by the API server
+func (h Handler) authorize(ctx context.Context, attrs ...*authzv1.ResourceAttributes) error {
+	for _, attr := range attrs {
+		allowed, err := h.reviewer.CanAccess(ctx, attr)
+		if err != nil {
+			return err
+		}
+		if allowed {
+			continue
+		}
+		target := fmt.Sprintf("%s in namespace %s", attr.Resource, attr.Namespace)
+		if attr.Name != "" {
+			target = fmt.Sprintf("%s %s/%s", attr.Resource, attr.Namespace, attr.Name)
+		}
+		return apierror.NewAPIError(validation.PermissionDenied, fmt.Sprintf("%s %s is forbidden", attr.Verb, target))
+	}
+	return nil
+}
+
+// authorizeSnapshot checks the user is allowed to snapshot the volumes of the notebook, the VolumeSnapshots are
+// created by the API server and expose the data of the PVCs
+func (h Handler) authorizeSnapshot(ctx context.Context, notebook *mlv1.Notebook) error {
+	return h.authorize(ctx,
+		&authzv1.ResourceAttributes{
+			Namespace: notebook.Namespace,
+			Verb:      "update",
+			Group:     mlv1.SchemeGroupVersion.Group,
+			Resource:  "notebooks",
+			Name:      notebook.Name,
+		},
+		&authzv1.ResourceAttributes{
+			Namespace: notebook.Namespace,
+			Verb:      "get",
+			Resource:  "persistentvolumeclaims",
+		},
+		&authzv1.ResourceAttributes{
+			Namespace: notebook.Namespace,
+			Verb:      "create",
+			Group:     snapshotAPIGroup,
+			Resource:  "volumesnapshots",
+		},
+	)
+}
+
+// snapshotNotebook takes a VolumeSnapshot of each notebook volume, the VolumeSnapshots are named
+// <snapshot>-<volume> and labeled with the snapshot name
+func (h Handler) snapshotNotebook(ctx context.Context, notebook *mlv1.Notebook, input *SnapshotInput) (*SnapshotOutput, error) {
+	if len(notebook.Spec.Volumes) == 0 {
+		return nil, apierror.NewAPIError(validation.InvalidAction,
+			fmt.Sprintf("notebook %s/%s doesn't have any volumes to snapshot", notebook.Namespace, notebook.Name))
+	}
+
+	name := input.Name
+	if name == "" {
+		name = fmt.Sprintf("%s-%s", notebook.Name, time.Now().UTC().Format("20060102150405"))
+	}
+	logrus.Debugf("Snapshot notebook %s/%s as %s", notebook.Namespace, notebook.Name, name)
+
+	output := &SnapshotOutput{Name: name}
+	for _, volume := range notebook.Spec.Volumes {
+		spec := map[string]interface{}{
+			"source": map[string]interface{}{
+				"persistentVolumeClaimName": volume.Name,
+			},
+		}
+		if input.VolumeSnapshotClassName != "" {
+			spec["volumeSnapshotClassName"] = input.VolumeSnapshotClassName
+		}
+		snapshot := newVolumeSnapshot(notebook.Namespace, getVolumeSnapshotName(name, volume.Name), name, notebook.Name, spec)
+		if _, err := h.snapshots.Resource(volumeSnapshotGVR).Namespace(notebook.Namespace).
+			Create(ctx, snapshot, metav1.CreateOptions{}); err != nil {
+			return nil, fmt.Errorf("failed to snapshot volume %s: %w", volume.Name, err)
+		}
+		output.VolumeSnapshots = append(output.VolumeSnapshots, snapshot.GetName())
+	}
+	return output, nil
+}
+
+// cloneNotebook creates a new notebook of the source notebook whose volumes are restored from the snapshot,
+// the VolumeSnapshots are copied to the target namespace in the background if it is different from the source
+// namespace, since the PVCs are not provisioned until their data source exists
+func (h Handler) cloneNotebook(ctx context.Context, notebook *mlv1.Notebook, input *CloneInput) (*mlv1.Notebook, error) {
+	if input.Name == "" {
+		return nil, apierror.NewAPIError(validation.MissingRequired, "name of the new notebook is required")
+	}
+	namespace := input.Namespace
+	if namespace == "" {
+		namespace = notebook.Namespace
+	}
+
+	// the volumes of the source notebook are copied by the API server, the user must be allowed to snapshot them and
+	// to create the notebook in the target namespace, otherwise the data of any readable notebook could be copied
+	// to the namespace of the user
+	if err := h.authorizeSnapshot(ctx, notebook); err != nil {
+		return nil, err
+	}
+	if err := h.authorize(ctx, &authzv1.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      "create",
+		Group:     mlv1.SchemeGroupVersion.Group,
+		Resource:  "notebooks",
+	}); err != nil {
+		return nil, err
+	}
+
+	snapshotName := input.SnapshotName
+	snapshotTaken := false
+	if snapshotName == "" {
+		output, err := h.snapshotNotebook(ctx, notebook, &SnapshotInput{VolumeSnapshotClassName: input.VolumeSnapshotClassName})
+		if err != nil {
+			return nil, err
+		}
+		snapshotName = output.Name
+		snapshotTaken = true
+	}
+
+	clone := newClonedNotebook(notebook, input.Name, namespace, snapshotName)
+	logrus.Debugf("Clone notebook %s/%s to %s/%s from snapshot %s", notebook.Namespace, notebook.Name,
+		clone.Namespace, clone.Name, snapshotName)
+	created, err := h.notebooks.Create(clone)
+	if err != nil {
+		if snapshotTaken {
+			h.deleteVolumeSnapshots(context.Background(), notebook.Namespace, notebook.Name, snapshotName)
+		}
+		return nil, err
+	}
+
+	if namespace != notebook.Namespace {
+		go h.restoreClonedNotebook(notebook, created, snapshotName, snapshotTaken)
+	}
+	return created, nil
+}
+
+// restoreClonedNotebook copies the VolumeSnapshots to the namespace of the cloned notebook, and deletes the copies
+// and their retained VolumeSnapshotContents once the PVCs are restored from them. The clone and the snapshot taken
+// for it are deleted if the copy or the restore fails.
+func (h Handler) restoreClonedNotebook(notebook, clone *mlv1.Notebook, snapshotName string, snapshotTaken bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), snapshotCopyTimeout)
+	defer cancel()
+
+	for _, volume := range notebook.Spec.Volumes {
+		name := getVolumeSnapshotName(snapshotName, volume.Name)
+		if err := h.copyVolumeSnapshot(ctx, notebook, name, snapshotName, clone.Namespace); err != nil {
+			logrus.Errorf("failed to clone notebook %s/%s to %s/%s, rolling back: %v", notebook.Namespace, notebook.Name,
+				clone.Namespace, clone.Name, err)
+			h.rollbackClonedNotebook(notebook, clone, snapshotName, snapshotTaken)
+			return
+		}
+	}
+
+	if err := h.waitForClonedVolumes(ctx, clone); err != nil {
+		logrus.Errorf("volumes of the cloned notebook %s/%s are not restored from %s/%s, rolling back: %v",
+			clone.Namespace, clone.Name, notebook.Namespace, snapshotName, err)
+		h.rollbackClonedNotebook(notebook, clone, snapshotName, snapshotTaken)
+		return
+	}
+	h.deleteVolumeSnapshots(context.Background(), clone.Namespace, notebook.Name, snapshotName)
+}
+
+// waitForClonedVolumes waits until the PVCs of the cloned notebook are bound, the restored PVCs no longer depend on
+// their data source
+func (h Handler) waitForClonedVolumes(ctx context.Context, clone *mlv1.Notebook) error {
+	return wait.PollUntilContextCancel(ctx, snapshotReadyInterval, true, func(ctx context.Context) (bool, error) {
+		for _, volume := range clone.Spec.Volumes {
+			pvc, err := h.pvcs.Get(clone.Namespace, volume.Name, metav1.GetOptions{})
+			if errors.IsNotFound(err) {
+				return false, nil
+			} else if err != nil {
+				return false, err
+			}
+			if pvc.Status.Phase != corev1.ClaimBound {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+}
+
+func (h Handler) rollbackClonedNotebook(notebook, clone *mlv1.Notebook, snapshotName string, snapshotTaken bool) {
+	ctx := context.Background()
+	if err := h.notebooks.Delete(clone.Namespace, clone.Name, &metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+		logrus.Errorf("failed to delete cloned notebook %s/%s: %v", clone.Namespace, clone.Name, err)
+	}
+	h.deleteVolumeSnapshots(ctx, clone.Namespace, notebook.Name, snapshotName)
+	if snapshotTaken {
+		h.deleteVolumeSnapshots(ctx, notebook.Namespace, notebook.Name, snapshotName)
+	}
+}
+
+// deleteVolumeSnapshots deletes the VolumeSnapshots of the notebook snapshot in the namespace, and the
+// VolumeSnapshotContents copied for them
+func (h Handler) deleteVolumeSnapshots(ctx context.Context, namespace, notebookName, snapshotName string) {
+	selector := labels.SelectorFromSet(map[string]string{
+		constant.LabelNotebookSnapshot: snapshotName,
+		constant.LabelNotebookName:     notebookName,
+	}).String()
+
+	snapshots, err := h.snapshots.Resource(volumeSnapshotGVR).Namespace(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		logrus.Errorf("failed to list VolumeSnapshots of snapshot %s/%s: %v", namespace, snapshotName, err)
+		return
+	}
+	for _, snapshot := range snapshots.Items {
+		err := h.snapshots.Resource(volumeSnapshotGVR).Namespace(namespace).Delete(ctx, snapshot.GetName(), metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			logrus.Errorf("failed to delete VolumeSnapshot %s/%s: %v", namespace, snapshot.GetName(), err)
+		}
+	}
+
+	contents, err := h.snapshots.Resource(volumeSnapshotContentGVR).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		logrus.Errorf("failed to list VolumeSnapshotContents of snapshot %s/%s: %v", namespace, snapshotName, err)
+		return
+	}
+	for _, content := range contents.Items {
+		refNamespace, _, _ := unstructured.NestedString(content.Object, "spec", "volumeSnapshotRef", "namespace")
+		if refNamespace != namespace {
+			continue
+		}
+		err := h.snapshots.Resource(volumeSnapshotContentGVR).Delete(ctx, content.GetName(), metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			logrus.Errorf("failed to delete VolumeSnapshotContent %s: %v", content.GetName(), err)
+		}
+	}
+}
+
+// copyVolumeSnapshot copies the ready VolumeSnapshot to the target namespace by a pre-provisioned VolumeSnapshotContent
+// of the same snapshot handle, since the PVC can only be restored from the VolumeSnapshot of its namespace
+func (h Handler) copyVolumeSnapshot(ctx context.Context, notebook *mlv1.Notebook, name, snapshotName, namespace string) error {
+	var source *unstructured.Unstructured
+	err := wait.PollUntilContextCancel(ctx, snapshotReadyInterval, true, func(ctx context.Context) (bool, error) {
+		snapshot, err := h.snapshots.Resource(volumeSnapshotGVR).Namespace(notebook.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		ready, _, _ := unstructured.NestedBool(snapshot.Object, "status", "readyToUse")
+		source = snapshot
+		return ready, nil
+	})
+	if err != nil {
+		return fmt.Errorf("VolumeSnapshot %s/%s is not ready to be copied: %w", notebook.Namespace, name, err)
+	}
+
+	contentName, _, _ := unstructured.NestedString(source.Object, "status", "boundVolumeSnapshotContentName")
+	content, err := h.snapshots.Resource(volumeSnapshotContentGVR).Get(ctx, contentName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	driver, _, _ := unstructured.NestedString(content.Object, "spec", "driver")
+	snapshotHandle, _, _ := unstructured.NestedString(content.Object, "status", "snapshotHandle")
+	snapshotClassName, _, _ := unstructured.NestedString(content.Object, "spec", "volumeSnapshotClassName")
+
+	// the copied content must be retained, deleting it with the Delete policy removes the snapshot data still owned
+	// by the source VolumeSnapshot. It is labeled with the snapshot and deleted with the copied VolumeSnapshot.
+	copiedContentName := fmt.Sprintf("%s-%s", namespace, name)
+	copiedContent := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": snapshotAPIVersion,
+		"kind":       "VolumeSnapshotContent",
+		"metadata": map[string]interface{}{
+			"name": copiedContentName,
+			"labels": map[string]interface{}{
+				constant.LabelNotebookSnapshot: snapshotName,
+				constant.LabelNotebookName:     notebook.Name,
+			},
+		},
+		"spec": map[string]interface{}{
+			"deletionPolicy":          "Retain",
+			"driver":                  driver,
+			"volumeSnapshotClassName": snapshotClassName,
+			"source": map[string]interface{}{
+				"snapshotHandle": snapshotHandle,
+			},
+			"volumeSnapshotRef": map[string]interface{}{
+				"name":      name,
+				"namespace": namespace,
+			},
+		},
+	}}
+	if _, err = h.snapshots.Resource(volumeSnapshotContentGVR).Create(ctx, copiedContent, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to copy VolumeSnapshotContent %s: %w", contentName, err)
+	}
+
+	snapshot := newVolumeSnapshot(namespace, name, snapshotName, notebook.Name, map[string]interface{}{
+		"source": map[string]interface{}{
+			"volumeSnapshotContentName": copiedContentName,
+		},
+	})
+	if _, err = h.snapshots.Resource(volumeSnapshotGVR).Namespace(namespace).Create(ctx, snapshot, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to copy VolumeSnapshot %s to namespace %s: %w", name, namespace, err)
+	}
+	return nil
+}
+
+func newVolumeSnapshot(namespace, name, snapshotName, notebookName string, spec map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": snapshotAPIVersion,
+		"kind":       "VolumeSnapshot",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+			"labels": map[string]interface{}{
+				constant.LabelNotebookSnapshot: snapshotName,
+				constant.LabelNotebookName:     notebookName,
+			},
+		},
+		"spec": spec,
+	}}
+}
+
+func getVolumeSnapshotName(snapshotName, volumeName string) string {
+	return fmt.Sprintf("%s-%s", snapshotName, volumeName)
+}
+
+// newClonedNotebook returns the new notebook of the source notebook, the volumes are renamed after the new notebook
+// and restored from the VolumeSnapshots of the snapshot
+func newClonedNotebook(notebook *mlv1.Notebook, name, namespace, snapshotName string) *mlv1.Notebook {
+	clone := &mlv1.Notebook{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Labels:      make(map[string]string, len(notebook.Labels)),
+			Annotations: make(map[string]string),
+		},
+		Spec: *notebook.Spec.DeepCopy(),
+	}
+	for k, v := range notebook.Labels {
+		clone.Labels[k] = v
+	}
+	for k, v := range notebook.Annotations {
+		if k != constant.AnnotationResourceStopped && !strings.HasPrefix(k, "kubectl.kubernetes.io/") {
+			clone.Annotations[k] = v
+		}
+	}
+	// only the RayClusters of the notebook namespace or the public namespace can be connected, the RayCluster of the
+	// source namespace is dropped from the notebook cloned to another namespace
+	if ref := clone.Spec.RayClusterRef; ref != nil && namespace != notebook.Namespace {
+		refNamespace := ref.Namespace
+		if refNamespace == "" {
+			refNamespace = notebook.Namespace
+		}
+		if refNamespace != namespace && refNamespace != constant.PublicNamespaceName {
+			clone.Spec.RayClusterRef = nil
+		}
+	}
+
+	claimNames := make(map[string]string, len(notebook.Spec.Volumes))
+	for i := range clone.Spec.Volumes {
+		volume := &clone.Spec.Volumes[i]
+		newName := getClonedVolumeName(volume.Name, notebook.Name, name)
+		claimNames[volume.Name] = newName
+		volume.Spec.DataSource = &corev1.TypedLocalObjectReference{
+			APIGroup: pointer.String(snapshotAPIGroup),
+			Kind:     "VolumeSnapshot",
+			Name:     getVolumeSnapshotName(snapshotName, volume.Name),
+		}
+		volume.Spec.DataSourceRef = nil
+		volume.Spec.VolumeName = ""
+		volume.Name = newName
+	}
+
+	podSpec := &clone.Spec.Template.Spec
+	for _, v := range podSpec.Volumes {
+		if v.PersistentVolumeClaim == nil {
+			continue
+		}
+		if newName, ok := claimNames[v.PersistentVolumeClaim.ClaimName]; ok {
+			v.PersistentVolumeClaim.ClaimName = newName
+		}
+	}
+
+	// the proxy prefix and the git init container are injected again by the controller
+	podSpec.InitContainers = removeContainer(podSpec.InitContainers, ctlnotebook.GitInitContainerName)
+	if len(podSpec.Containers) > 0 {
+		container := &podSpec.Containers[0]
+		container.Name = name
+		env := make([]corev1.EnvVar, 0, len(container.Env))
+		for _, e := range container.Env {
+			if e.Name != ctlnotebook.PrefixEnvVar && e.Name != ctlnotebook.RayAddressEnvVar {
+				env = append(env, e)
+			}
+		}
+		container.Env = env
+	}
+	return clone
+}
+
+// getClonedVolumeName replaces the source notebook name of the volume name with the new one
+func getClonedVolumeName(volumeName, notebookName, newNotebookName string) string {
+	if volumeName == notebookName {
+		return newNotebookName
+	}
+	if strings.HasPrefix(volumeName, notebookName+"-") {
+		return newNotebookName + strings.TrimPrefix(volumeName, notebookName)
+	}
+	return fmt.Sprintf("%s-%s", newNotebookName, volumeName)
+}
+
+func removeContainer(containers []corev1.Container, name string) []corev1.Container {
+	for i, c := range containers {
+		if c.Name == name {
+			return append(containers[:i:i], containers[i+1:]...)
+		}
+	}
+	return containers
+}
@@ -0,0 +1,119 @@
+package notebook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+	ctlnotebook "github.com/oneblock-ai/oneblock/pkg/controller/notebook"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+)
+
+func newTestNotebook() *mlv1.Notebook {
+	return &mlv1.Notebook{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "nb",
+			Annotations: map[string]string{
+				constant.AnnotationResourceStopped: "2024-01-01T00:00:00Z",
+			},
+		},
+		Spec: mlv1.NotebookSpec{
+			Template: mlv1.NotebookTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "nb",
+							Env: []corev1.EnvVar{
+								{Name: ctlnotebook.PrefixEnvVar, Value: "/notebooks/default/nb"},
+								{Name: "FOO", Value: "bar"},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "workspace",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "nb"},
+							},
+						},
+					},
+				},
+			},
+			Volumes: []mlv1.Volume{{Name: "nb"}},
+		},
+	}
+}
+
+func TestHandler_snapshotNotebook(t *testing.T) {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		volumeSnapshotGVR: "VolumeSnapshotList",
+	})
+	h := Handler{snapshots: client}
+
+	output, err := h.snapshotNotebook(context.TODO(), newTestNotebook(), &SnapshotInput{Name: "before-upgrade"})
+	require.NoError(t, err)
+	assert.Equal(t, &SnapshotOutput{Name: "before-upgrade", VolumeSnapshots: []string{"before-upgrade-nb"}}, output)
+
+	snapshot, err := client.Resource(volumeSnapshotGVR).Namespace("default").Get(context.TODO(), "before-upgrade-nb", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "before-upgrade", snapshot.GetLabels()[constant.LabelNotebookSnapshot])
+}
+
+func Test_newClonedNotebook(t *testing.T) {
+	clone := newClonedNotebook(newTestNotebook(), "nb-copy", "team-b", "before-upgrade")
+
+	assert.Equal(t, "team-b", clone.Namespace)
+	assert.NotContains(t, clone.Annotations, constant.AnnotationResourceStopped)
+	require.Len(t, clone.Spec.Volumes, 1)
+	assert.Equal(t, "nb-copy", clone.Spec.Volumes[0].Name)
+	assert.Equal(t, "before-upgrade-nb", clone.Spec.Volumes[0].Spec.DataSource.Name)
+	assert.Equal(t, "nb-copy", clone.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+	assert.Equal(t, "nb-copy", clone.Spec.Template.Spec.Containers[0].Name)
+	assert.Equal(t, []corev1.EnvVar{{Name: "FOO", Value: "bar"}}, clone.Spec.Template.Spec.Containers[0].Env)
+}
+
+func Test_newClonedNotebookRayClusterRef(t *testing.T) {
+	notebook := newTestNotebook()
+	notebook.Spec.RayClusterRef = &mlv1.RayClusterReference{Name: "ray"}
+
+	clone := newClonedNotebook(notebook, "nb-copy", "default", "before-upgrade")
+	assert.Equal(t, &mlv1.RayClusterReference{Name: "ray"}, clone.Spec.RayClusterRef)
+
+	// the RayCluster of the source namespace can't be connected from another namespace
+	clone = newClonedNotebook(notebook, "nb-copy", "team-b", "before-upgrade")
+	assert.Nil(t, clone.Spec.RayClusterRef)
+
+	notebook.Spec.RayClusterRef = &mlv1.RayClusterReference{Name: "ray", Namespace: constant.PublicNamespaceName}
+	clone = newClonedNotebook(notebook, "nb-copy", "team-b", "before-upgrade")
+	assert.Equal(t, notebook.Spec.RayClusterRef, clone.Spec.RayClusterRef)
+}
+
+func TestHandler_deleteVolumeSnapshots(t *testing.T) {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		volumeSnapshotGVR:        "VolumeSnapshotList",
+		volumeSnapshotContentGVR: "VolumeSnapshotContentList",
+	})
+	h := Handler{snapshots: client}
+
+	_, err := h.snapshotNotebook(context.TODO(), newTestNotebook(), &SnapshotInput{Name: "before-upgrade"})
+	require.NoError(t, err)
+	_, err = h.snapshotNotebook(context.TODO(), newTestNotebook(), &SnapshotInput{Name: "other"})
+	require.NoError(t, err)
+
+	h.deleteVolumeSnapshots(context.TODO(), "default", "nb", "before-upgrade")
+
+	snapshots, err := client.Resource(volumeSnapshotGVR).Namespace("default").List(context.TODO(), metav1.ListOptions{})
+	require.NoError(t, err)
+	if assert.Len(t, snapshots.Items, 1) {
+		assert.Equal(t, "other-nb", snapshots.Items[0].GetName())
+	}
+}
@@ -1,27 +1,94 @@
 package publicui
 
 import (
+	"encoding/json"
 	"net/http"
+	"sort"
 
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+	ctlmlv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ml.oneblock.ai/v1"
+	"github.com/oneblock-ai/oneblock/pkg/server/config"
 	"github.com/oneblock-ai/oneblock/pkg/settings"
 	"github.com/oneblock-ai/oneblock/pkg/utils"
 )
 
+const notebookImagesKey = "default-notebook-images"
+
 type Handler struct {
+	notebookImageCache ctlmlv1.NotebookImageCache
 }
 
-func NewPublicHandler() *Handler {
-	return &Handler{}
+// notebookImage is the public view of the notebook image, the allowed namespaces of the image are only served by the
+// authenticated notebookimages API to not expose the namespace names to anonymous users
+type notebookImage struct {
+	ContainerImage string `json:"containerImage,omitempty"`
+	Description    string `json:"description,omitempty"`
+	Default        bool   `json:"default,omitempty"`
+	GPUSupported   bool   `json:"gpuSupported,omitempty"`
+}
+
+func NewPublicHandler(mgmt *config.Management) *Handler {
+	return &Handler{
+		notebookImageCache: mgmt.OneBlockMLFactory.Ml().V1().NotebookImage().Cache(),
+	}
 }
 
 func (h *Handler) ServeHTTP(rw http.ResponseWriter, _ *http.Request) {
 	utils.ResponseOKWithBody(rw, map[string]string{
-		settings.UIPlSettingName:                  settings.UIPl.Get(),
-		settings.UISourceSettingName:              getUISource(),
-		settings.DefaultNotebookImagesSettingName: settings.NotebookDefaultImages.Get(),
-		settings.DefaultRayClusterImage:           settings.RayClusterImage.Get(),
-		settings.DefaultRayLLMImage:               settings.RayLLMImage.Get(),
+		settings.UIPlSettingName:             settings.UIPl.Get(),
+		settings.UISourceSettingName:         getUISource(),
+		notebookImagesKey:                    h.getNotebookImages(),
+		settings.DefaultRayClusterImage:      settings.RayClusterImage.Get(),
+		settings.DefaultRayLLMImage:          settings.RayLLMImage.Get(),
+		settings.DefaultVLLMImage:            settings.VLLMImage.Get(),
+		settings.AcceleratorTypesSettingName: getAcceleratorTypes(),
+	})
+}
+
+// getAcceleratorTypes returns the loaded accelerator type registry, the UI lists the types for the worker groups
+func getAcceleratorTypes() string {
+	result, err := json.Marshal(utils.GetAcceleratorRegistry())
+	if err != nil {
+		logrus.Errorf("failed to marshal accelerator types: %v", err)
+		return "[]"
+	}
+	return string(result)
+}
+
+// getNotebookImages returns the notebook images grouped by the notebook type, the default images are listed first
+func (h *Handler) getNotebookImages() string {
+	images, err := h.notebookImageCache.List(labels.Everything())
+	if err != nil {
+		logrus.Errorf("failed to list notebook images: %v", err)
+		return "{}"
+	}
+
+	sort.Slice(images, func(i, j int) bool {
+		if images[i].Spec.Default != images[j].Spec.Default {
+			return images[i].Spec.Default
+		}
+		return images[i].Name < images[j].Name
 	})
+
+	notebookImages := make(map[mlv1.NotebookType][]notebookImage)
+	for _, image := range images {
+		notebookImages[image.Spec.Type] = append(notebookImages[image.Spec.Type], notebookImage{
+			ContainerImage: image.Spec.Image,
+			Description:    image.Spec.Description,
+			Default:        image.Spec.Default,
+			GPUSupported:   image.Spec.GPUSupported,
+		})
+	}
+
+	result, err := json.Marshal(notebookImages)
+	if err != nil {
+		logrus.Errorf("failed to marshal notebook images: %v", err)
+		return "{}"
+	}
+	return string(result)
 }
 
 func getUISource() string {
@@ -1,6 +1,7 @@
 package queue
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -10,15 +11,98 @@ import (
 	"github.com/oneblock-ai/apiserver/v2/pkg/types"
 	"github.com/rancher/wrangler/v2/pkg/schemas/validation"
 	"github.com/sirupsen/logrus"
+	authzv1 "k8s.io/api/authorization/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	scheduling "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 
+	"github.com/oneblock-ai/oneblock/pkg/api/auth"
 	"github.com/oneblock-ai/oneblock/pkg/utils"
 	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
 )
 
-func formatter(request *types.APIRequest, resource *types.RawResource) {
-	resource.Actions = make(map[string]string, 1)
+func (h Handler) formatter(request *types.APIRequest, resource *types.RawResource) {
+	resource.Actions = make(map[string]string, 2)
 	resource.AddAction(request, ActionSetDefault)
+	resource.AddAction(request, ActionUpdate)
+
+	// the usages of the listed queues are aggregated once by the collection formatter
+	if request.Type == queueSchemaID && request.Name == "" {
+		return
+	}
+	h.setUsages(request, []*types.RawResource{resource})
+}
+
+func (h Handler) collectionFormatter(request *types.APIRequest, collection *types.GenericCollection) {
+	h.setUsages(request, collection.Data)
+}
+
+// setUsages sets the usages of the queues to the resources, the usage explains why the workloads of the queue are pending
+func (h Handler) setUsages(request *types.APIRequest, resources []*types.RawResource) {
+	queues := make([]*scheduling.Queue, 0, len(resources))
+	for _, resource := range resources {
+		queue, err := h.queueCache.Get(resource.ID)
+		if err != nil {
+			logrus.Debugf("failed to get queue %s: %v", resource.ID, err)
+			continue
+		}
+		queues = append(queues, queue)
+	}
+	if len(queues) == 0 {
+		return
+	}
+
+	filter := &namespaceFilter{
+		ctx:      request.Context(),
+		reviewer: h.reviewer,
+		allowed:  make(map[string]bool),
+	}
+	usages, err := h.usage.aggregate(queues, filter.canList)
+	if err != nil {
+		logrus.Errorf("failed to aggregate the usages of queues: %v", err)
+		return
+	}
+	for _, resource := range resources {
+		usage, ok := usages[resource.ID]
+		if !ok {
+			continue
+		}
+		data := resource.APIObject.Data()
+		data.SetNested(usage.Resources, "status", "resources")
+		data.SetNested(usage.PendingPodGroups, "status", "pendingPodGroups")
+		data.SetNested(usage.TopConsumers, "status", "topConsumers")
+	}
+}
+
+// namespaceFilter checks whether the user is allowed to list the PodGroups of a namespace, the results are kept to
+// review each namespace once for all the queues of a list
+type namespaceFilter struct {
+	ctx      context.Context
+	reviewer *auth.AccessReviewer
+	allowed  map[string]bool
+}
+
+func (f *namespaceFilter) canList(namespace string) bool {
+	// the user allowed to list the PodGroups of all namespaces is reviewed once
+	for _, ns := range []string{"", namespace} {
+		allowed, ok := f.allowed[ns]
+		if !ok {
+			var err error
+			allowed, err = f.reviewer.CanAccess(f.ctx, &authzv1.ResourceAttributes{
+				Namespace: ns,
+				Verb:      "list",
+				Group:     scheduling.SchemeGroupVersion.Group,
+				Resource:  "podgroups",
+			})
+			if err != nil {
+				logrus.Errorf("failed to review the access to the podgroups of namespace %q: %v", ns, err)
+			}
+			f.allowed[ns] = allowed
+		}
+		if allowed {
+			return true
+		}
+	}
+	return false
 }
 
 func (h Handler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
@@ -32,7 +116,6 @@ func (h Handler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 		_, _ = rw.Write([]byte(err.Error()))
 		return
 	}
-	rw.WriteHeader(http.StatusNoContent)
 }
 
 func (h Handler) do(rw http.ResponseWriter, req *http.Request) error {
@@ -50,18 +133,58 @@ func (h Handler) doPost(action string, rw http.ResponseWriter, req *http.Request
 	switch action {
 	case ActionSetDefault:
 		return h.setDefaultQueue(rw, name)
+	case ActionCreate:
+		input, err := decodeQueueInput(req)
+		if err != nil {
+			return err
+		}
+		if err = h.authorize(req, "create", input.Name); err != nil {
+			return err
+		}
+		return h.createQueue(rw, input)
+	case ActionUpdate:
+		input, err := decodeQueueInput(req)
+		if err != nil {
+			return err
+		}
+		if err = h.authorize(req, "update", name); err != nil {
+			return err
+		}
+		return h.updateQueue(rw, name, input)
 	default:
 		return apierror.NewAPIError(validation.InvalidAction, fmt.Sprintf("Unsupported POST action %s", action))
 	}
 }
 
-func (h Handler) setDefaultQueue(_ http.ResponseWriter, name string) error {
+// authorize checks the user is allowed to create or update the queue, since the queue is changed by the API server
+func (h Handler) authorize(req *http.Request, verb, name string) error {
+	allowed, err := h.reviewer.CanAccess(req.Context(), &authzv1.ResourceAttributes{
+		Verb:     verb,
+		Group:    scheduling.SchemeGroupVersion.Group,
+		Resource: "queues",
+		Name:     name,
+	})
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return apierror.NewAPIError(validation.PermissionDenied, fmt.Sprintf("%s queue %s is forbidden", verb, name))
+	}
+	return nil
+}
+
+func (h Handler) setDefaultQueue(rw http.ResponseWriter, name string) error {
 	logrus.Debugf("Set default queue %s", name)
 	// check if queue exists
 	queue, err := h.queueCache.Get(name)
 	if err != nil {
 		return err
 	}
+	// the workloads of the namespaces without bound queues fall back to the default queue
+	if utils.GetQueueNamespaces(queue) != nil {
+		return apierror.NewAPIError(validation.InvalidOption,
+			fmt.Sprintf("queue %s doesn't support all the namespaces to be the default queue", name))
+	}
 
 	// unset all default queues
 	if err = h.unsetAllDefaultQueues(); err != nil {
@@ -77,6 +200,7 @@ func (h Handler) setDefaultQueue(_ http.ResponseWriter, name string) error {
 		return err
 	}
 
+	rw.WriteHeader(http.StatusNoContent)
 	return nil
 }
 
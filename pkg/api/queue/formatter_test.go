@@ -5,9 +5,12 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
 	scheduling "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 
 	"github.com/oneblock-ai/oneblock/pkg/generated/clientset/versioned/fake"
@@ -99,3 +102,152 @@ func Test_setDefaultQueueAction(t *testing.T) {
 		}
 	}
 }
+
+func Test_createQueueAction(t *testing.T) {
+	boundQueue := &scheduling.Queue{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "team-a",
+			Annotations: map[string]string{
+				constant.AnnotationSchedulingSupportedNamespacesKey: "ns-a,ns-b",
+			},
+		},
+	}
+	var testCases = []struct {
+		name      string
+		input     *QueueInput
+		expectErr bool
+	}{
+		{
+			name: "create queue with namespaces",
+			input: &QueueInput{
+				Name:       "team-c",
+				Capability: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")},
+				Guarantee:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				Namespaces: []string{"ns-d", "ns-c"},
+			},
+		},
+		{
+			name:      "name is required",
+			input:     &QueueInput{},
+			expectErr: true,
+		},
+		{
+			name:      "queue already exists",
+			input:     &QueueInput{Name: boundQueue.Name},
+			expectErr: true,
+		},
+		{
+			name: "guarantee exceeds capability",
+			input: &QueueInput{
+				Name:       "team-c",
+				Capability: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				Guarantee:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "namespace is bound to another queue",
+			input:     &QueueInput{Name: "team-c", Namespaces: []string{"ns-b"}},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		assert := require.New(t)
+		client := fake.NewSimpleClientset(boundQueue)
+		h := Handler{
+			queue:      fakeclients.QueueClient(client.SchedulingV1beta1().Queues),
+			queueCache: fakeclients.QueueCache(client.SchedulingV1beta1().Queues),
+		}
+
+		err := h.createQueue(httptest.NewRecorder(), tc.input)
+		if tc.expectErr {
+			assert.Error(err, tc.name)
+			continue
+		}
+		assert.NoError(err, tc.name)
+
+		queue, err := h.queueCache.Get(tc.input.Name)
+		assert.NoError(err, tc.name)
+		assert.Equal(int32(1), queue.Spec.Weight, tc.name)
+		assert.True(*queue.Spec.Reclaimable, tc.name)
+		assert.Equal("ns-c,ns-d", queue.Annotations[constant.AnnotationSchedulingSupportedNamespacesKey], tc.name)
+	}
+}
+
+func Test_updateQueueAction(t *testing.T) {
+	assert := require.New(t)
+	queue := &scheduling.Queue{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: constant.DefaultQueueName,
+			Annotations: map[string]string{
+				constant.AnnotationDefaultSchedulingKey:             "true",
+				constant.AnnotationSchedulingSupportedNamespacesKey: constant.AllNamespaces,
+			},
+		},
+	}
+	client := fake.NewSimpleClientset(queue)
+	h := Handler{
+		queue:      fakeclients.QueueClient(client.SchedulingV1beta1().Queues),
+		queueCache: fakeclients.QueueCache(client.SchedulingV1beta1().Queues),
+	}
+
+	err := h.updateQueue(httptest.NewRecorder(), queue.Name, &QueueInput{Namespaces: []string{"ns-a"}})
+	assert.Error(err, "expected error while restricting the namespaces of the default queue")
+
+	err = h.updateQueue(httptest.NewRecorder(), queue.Name, &QueueInput{Weight: pointer.Int32(3), Reclaimable: pointer.Bool(false)})
+	assert.NoError(err, "expected no error while updating the queue")
+
+	updated, err := h.queueCache.Get(queue.Name)
+	assert.NoError(err)
+	assert.Equal(int32(3), updated.Spec.Weight)
+	assert.False(*updated.Spec.Reclaimable)
+	assert.Equal("true", updated.Annotations[constant.AnnotationDefaultSchedulingKey])
+}
+
+func Test_updateQueueActionKeepsOmittedSettings(t *testing.T) {
+	assert := require.New(t)
+	queue := &scheduling.Queue{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "team-a",
+			Annotations: map[string]string{
+				constant.AnnotationSchedulingSupportedNamespacesKey: "ns-a,ns-b",
+			},
+		},
+		Spec: scheduling.QueueSpec{
+			Weight:      1,
+			Reclaimable: pointer.Bool(false),
+			Capability:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")},
+			Guarantee:   scheduling.Guarantee{Resource: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")}},
+		},
+	}
+	client := fake.NewSimpleClientset(queue)
+	h := Handler{
+		queue:      fakeclients.QueueClient(client.SchedulingV1beta1().Queues),
+		queueCache: fakeclients.QueueCache(client.SchedulingV1beta1().Queues),
+	}
+
+	err := h.updateQueue(httptest.NewRecorder(), queue.Name, &QueueInput{Weight: pointer.Int32(3)})
+	assert.NoError(err, "expected no error while updating the weight")
+
+	updated, err := h.queueCache.Get(queue.Name)
+	assert.NoError(err)
+	assert.Equal(int32(3), updated.Spec.Weight)
+	assert.False(*updated.Spec.Reclaimable)
+	assert.Equal("8", updated.Spec.Capability.Cpu().String())
+	assert.Equal("2", updated.Spec.Guarantee.Resource.Cpu().String())
+	assert.Equal("ns-a,ns-b", updated.Annotations[constant.AnnotationSchedulingSupportedNamespacesKey])
+
+	// the guarantee is validated against the kept capability
+	err = h.updateQueue(httptest.NewRecorder(), queue.Name, &QueueInput{
+		Guarantee: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("16")},
+	})
+	assert.Error(err, "expected error while the guarantee exceeds the kept capability")
+
+	// the empty namespaces allow all the namespaces
+	err = h.updateQueue(httptest.NewRecorder(), queue.Name, &QueueInput{Namespaces: []string{}})
+	assert.NoError(err)
+	updated, err = h.queueCache.Get(queue.Name)
+	assert.NoError(err)
+	assert.Equal(constant.AllNamespaces, updated.Annotations[constant.AnnotationSchedulingSupportedNamespacesKey])
+}
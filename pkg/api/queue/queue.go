@@ -0,0 +1,185 @@
+package queue
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/oneblock-ai/apiserver/v2/pkg/apierror"
+	"github.com/rancher/wrangler/v2/pkg/schemas/validation"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/pointer"
+	"k8s.io/utils/strings/slices"
+	scheduling "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+)
+
+// QueueInput is the input of the create and update actions, the settings omitted by the update action are kept,
+// e.g., an empty capability removes the limits while an omitted one keeps them
+type QueueInput struct {
+	// Name is the name of the queue to create, it's ignored by the update action
+	Name string `json:"name"`
+	// Capability is the upper limit of the resources allocated to the queue
+	Capability corev1.ResourceList `json:"capability,omitempty"`
+	// Guarantee is the resources reserved for the queue, it can't exceed the capability
+	Guarantee corev1.ResourceList `json:"guarantee,omitempty"`
+	// Weight is the share of the cluster resources of the queue, default to 1
+	Weight *int32 `json:"weight,omitempty"`
+	// Reclaimable indicates whether the resources over the deserved share of the queue can be reclaimed, default to true
+	Reclaimable *bool `json:"reclaimable,omitempty"`
+	// Namespaces are the namespaces allowed to submit workloads to the queue, all the namespaces are allowed if empty
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+func decodeQueueInput(req *http.Request) (*QueueInput, error) {
+	input := &QueueInput{}
+	if err := json.NewDecoder(req.Body).Decode(input); err != nil {
+		return nil, apierror.NewAPIError(validation.InvalidBodyContent, fmt.Sprintf("Failed to decode request body: %v", err))
+	}
+	return input, nil
+}
+
+func (h Handler) createQueue(rw http.ResponseWriter, input *QueueInput) error {
+	if input.Name == "" {
+		return apierror.NewAPIError(validation.MissingRequired, "name of the queue is required")
+	}
+	if _, err := h.queueCache.Get(input.Name); err == nil {
+		return apierror.NewAPIError(validation.Conflict, fmt.Sprintf("queue %s already exists", input.Name))
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	queue := &scheduling.Queue{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: input.Name,
+		},
+	}
+	if err := h.applyQueueInput(queue, input); err != nil {
+		return err
+	}
+
+	logrus.Debugf("Create queue %s", queue.Name)
+	created, err := h.queue.Create(queue)
+	if err != nil {
+		return err
+	}
+
+	utils.ResponseOKWithBody(rw, created)
+	return nil
+}
+
+func (h Handler) updateQueue(rw http.ResponseWriter, name string, input *QueueInput) error {
+	queue, err := h.queueCache.Get(name)
+	if err != nil {
+		return err
+	}
+
+	queueCpy := queue.DeepCopy()
+	if err = h.applyQueueInput(queueCpy, input); err != nil {
+		return err
+	}
+
+	logrus.Debugf("Update queue %s", queueCpy.Name)
+	updated, err := h.queue.Update(queueCpy)
+	if err != nil {
+		return err
+	}
+
+	utils.ResponseOKWithBody(rw, updated)
+	return nil
+}
+
+// applyQueueInput validates the input and applies the given settings to the queue, the defaults are set to the
+// settings missing from both
+func (h Handler) applyQueueInput(queue *scheduling.Queue, input *QueueInput) error {
+	if input.Weight != nil && *input.Weight < 0 {
+		return apierror.NewAPIError(validation.InvalidFormat, "weight of the queue can't be negative")
+	}
+	capability, guarantee := queue.Spec.Capability, queue.Spec.Guarantee.Resource
+	if input.Capability != nil {
+		capability = input.Capability
+	}
+	if input.Guarantee != nil {
+		guarantee = input.Guarantee
+	}
+	if err := validateResources(capability, guarantee); err != nil {
+		return apierror.NewAPIError(validation.InvalidFormat, err.Error())
+	}
+	_, namespacesSet := queue.Annotations[constant.AnnotationSchedulingSupportedNamespacesKey]
+	if input.Namespaces != nil || !namespacesSet {
+		if err := h.validateNamespaces(queue, input.Namespaces); err != nil {
+			return err
+		}
+		if queue.Annotations == nil {
+			queue.Annotations = make(map[string]string, 1)
+		}
+		queue.Annotations[constant.AnnotationSchedulingSupportedNamespacesKey] = utils.FormatQueueNamespaces(input.Namespaces)
+	}
+
+	if input.Weight != nil {
+		queue.Spec.Weight = *input.Weight
+	}
+	if queue.Spec.Weight == 0 {
+		queue.Spec.Weight = 1
+	}
+	if input.Reclaimable != nil {
+		queue.Spec.Reclaimable = pointer.Bool(*input.Reclaimable)
+	} else if queue.Spec.Reclaimable == nil {
+		queue.Spec.Reclaimable = pointer.Bool(true)
+	}
+	queue.Spec.Capability = capability
+	queue.Spec.Guarantee = scheduling.Guarantee{Resource: guarantee}
+	return nil
+}
+
+// validateResources checks the quantities are not negative and the guarantee doesn't exceed the capability
+func validateResources(capability, guarantee corev1.ResourceList) error {
+	for name, quantity := range capability {
+		if quantity.Sign() < 0 {
+			return fmt.Errorf("capability of %s can't be negative", name)
+		}
+	}
+	for name, quantity := range guarantee {
+		if quantity.Sign() < 0 {
+			return fmt.Errorf("guarantee of %s can't be negative", name)
+		}
+		if limit, ok := capability[name]; ok && quantity.Cmp(limit) > 0 {
+			return fmt.Errorf("guarantee of %s %s exceeds the capability %s", name, quantity.String(), limit.String())
+		}
+	}
+	return nil
+}
+
+// validateNamespaces checks the default queue supports all the namespaces and a namespace is bound to one queue at most
+func (h Handler) validateNamespaces(queue *scheduling.Queue, namespaces []string) error {
+	if utils.FormatQueueNamespaces(namespaces) == constant.AllNamespaces {
+		return nil
+	}
+	if utils.IsDefaultQueue(queue) {
+		return apierror.NewAPIError(validation.InvalidOption,
+			fmt.Sprintf("default queue %s must support all the namespaces", queue.Name))
+	}
+
+	queues, err := h.queueCache.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, q := range queues {
+		if q.Name == queue.Name {
+			continue
+		}
+		for _, ns := range utils.GetQueueNamespaces(q) {
+			if slices.Contains(namespaces, ns) {
+				return apierror.NewAPIError(validation.Conflict,
+					fmt.Sprintf("namespace %s is already bound to queue %s", ns, q.Name))
+			}
+		}
+	}
+	return nil
+}
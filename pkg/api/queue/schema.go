@@ -8,7 +8,9 @@ import (
 	"github.com/oneblock-ai/steve/v2/pkg/server"
 	"github.com/rancher/wrangler/v2/pkg/schemas"
 
+	"github.com/oneblock-ai/oneblock/pkg/api/auth"
 	ctlschedulv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/scheduling.volcano.sh/v1beta1"
+	"github.com/oneblock-ai/oneblock/pkg/indexeres"
 	"github.com/oneblock-ai/oneblock/pkg/server/config"
 )
 
@@ -16,32 +18,55 @@ const (
 	queueSchemaID = "scheduling.volcano.sh.queue"
 
 	ActionSetDefault = "setDefault"
+	ActionCreate     = "create"
+	ActionUpdate     = "update"
 )
 
 type Handler struct {
 	httpClient http.Client
 	queue      ctlschedulv1.QueueClient
 	queueCache ctlschedulv1.QueueCache
+	usage      *usageAggregator
+	reviewer   *auth.AccessReviewer
 }
 
 func RegisterSchema(mgmt *config.Management, server *server.Server) error {
+	// the usages are aggregated by the indexers on every replica, not only the leader running the controllers
+	indexeres.RegisterQueueIndexers(mgmt)
+
 	queues := mgmt.SchedulingFactory.Scheduling().V1beta1().Queue()
 	h := Handler{
 		httpClient: http.Client{},
 		queue:      queues,
 		queueCache: queues.Cache(),
+		reviewer:   auth.NewAccessReviewer(mgmt),
+		usage: &usageAggregator{
+			podCache:        mgmt.CoreFactory.Core().V1().Pod().Cache(),
+			podGroupCache:   mgmt.SchedulingFactory.Scheduling().V1beta1().PodGroup().Cache(),
+			rayClusterCache: mgmt.KubeRayFactory.Ray().V1().RayCluster().Cache(),
+			rayJobCache:     mgmt.KubeRayFactory.Ray().V1().RayJob().Cache(),
+			rayServiceCache: mgmt.KubeRayFactory.Ray().V1().RayService().Cache(),
+			notebookCache:   mgmt.OneBlockMLFactory.Ml().V1().Notebook().Cache(),
+		},
 	}
 
 	t := []schema.Template{
 		{
 			ID:        queueSchemaID,
-			Formatter: formatter,
+			Formatter: h.formatter,
 			Customize: func(apiSchema *types.APISchema) {
+				apiSchema.CollectionFormatter = h.collectionFormatter
 				apiSchema.ResourceActions = map[string]schemas.Action{
 					ActionSetDefault: {},
+					ActionUpdate:     {},
+				}
+				apiSchema.CollectionActions = map[string]schemas.Action{
+					ActionCreate: {},
 				}
 				apiSchema.ActionHandlers = map[string]http.Handler{
 					ActionSetDefault: h,
+					ActionUpdate:     h,
+					ActionCreate:     h,
 				}
 			},
 		},
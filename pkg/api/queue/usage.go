@@ -0,0 +1,333 @@
+package queue
+
+import (
+	"sort"
+
+	ctlcorev1 "github.com/rancher/wrangler/v2/pkg/generated/controllers/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	scheduling "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	ctlmlv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ml.oneblock.ai/v1"
+	ctlkuberayv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ray.io/v1"
+	ctlschedulv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/scheduling.volcano.sh/v1beta1"
+	"github.com/oneblock-ai/oneblock/pkg/indexeres"
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+)
+
+const (
+	resourceGPU corev1.ResourceName = "gpu"
+
+	topConsumersLimit = 5
+)
+
+// ResourceUsage is the allocated resource of the queue compared to its capability, the capability is empty if the
+// resource of the queue is unlimited. The allocated resource is the one reported by volcano in the queue status, or
+// the sum of the requests of the pods bound to the nodes, not the gang minimum of the PodGroups
+type ResourceUsage struct {
+	Resource   string             `json:"resource"`
+	Allocated  resource.Quantity  `json:"allocated"`
+	Capability *resource.Quantity `json:"capability,omitempty"`
+}
+
+// PendingPodGroup is a PodGroup of the queue waiting to be scheduled and the reason reported by volcano
+type PendingPodGroup struct {
+	Namespace    string              `json:"namespace"`
+	Name         string              `json:"name"`
+	Owner        string              `json:"owner,omitempty"`
+	Phase        string              `json:"phase"`
+	Reason       string              `json:"reason,omitempty"`
+	Message      string              `json:"message,omitempty"`
+	MinResources corev1.ResourceList `json:"minResources,omitempty"`
+	Since        metav1.Time         `json:"since"`
+}
+
+// NamespaceUsage is the resources requested by the bound pods of the running PodGroups of a namespace in the queue
+type NamespaceUsage struct {
+	Namespace string            `json:"namespace"`
+	Workloads int               `json:"workloads"`
+	CPU       resource.Quantity `json:"cpu"`
+	Memory    resource.Quantity `json:"memory"`
+	GPU       resource.Quantity `json:"gpu"`
+}
+
+// QueueUsage is the usage of a queue aggregated from the queue status, its PodGroups and workloads
+type QueueUsage struct {
+	Resources        []ResourceUsage   `json:"resources"`
+	PendingPodGroups []PendingPodGroup `json:"pendingPodGroups"`
+	TopConsumers     []NamespaceUsage  `json:"topConsumers"`
+}
+
+type usageAggregator struct {
+	podCache        ctlcorev1.PodCache
+	podGroupCache   ctlschedulv1.PodGroupCache
+	rayClusterCache ctlkuberayv1.RayClusterCache
+	rayJobCache     ctlkuberayv1.RayJobCache
+	rayServiceCache ctlkuberayv1.RayServiceCache
+	notebookCache   ctlmlv1.NotebookCache
+}
+
+// aggregate returns the usages of the queues keyed by the queue name, the PodGroups and workloads of the namespaces
+// that can't be listed by canList are only counted in the allocated resources of the queues
+func (a *usageAggregator) aggregate(queues []*scheduling.Queue, canList func(namespace string) bool) (map[string]*QueueUsage, error) {
+	queuePodGroups := make(map[string][]*scheduling.PodGroup, len(queues))
+	podGroups := make([]*scheduling.PodGroup, 0)
+	for _, queue := range queues {
+		pgs, err := a.podGroupCache.GetByIndex(indexeres.QueueNameIndex, queue.Name)
+		if err != nil {
+			return nil, err
+		}
+		queuePodGroups[queue.Name] = pgs
+		podGroups = append(podGroups, pgs...)
+	}
+	podGroupRequests, err := a.getPodGroupRequests(podGroups)
+	if err != nil {
+		return nil, err
+	}
+
+	usages := make(map[string]*QueueUsage, len(queues))
+	for _, queue := range queues {
+		workloadNamespaces, err := a.listWorkloadNamespaces(queue.Name)
+		if err != nil {
+			return nil, err
+		}
+		usages[queue.Name] = getQueueUsage(queue, queuePodGroups[queue.Name], podGroupRequests, workloadNamespaces, canList)
+	}
+	return usages, nil
+}
+
+// getPodGroupRequests returns the resources requested by the bound pods of each running PodGroup, keyed by the
+// namespaced name of the PodGroup
+func (a *usageAggregator) getPodGroupRequests(podGroups []*scheduling.PodGroup) (map[string]corev1.ResourceList, error) {
+	requests := make(map[string]corev1.ResourceList)
+	for _, pg := range podGroups {
+		if pg.Status.Phase != scheduling.PodGroupRunning {
+			continue
+		}
+		key := utils.NewRef(pg.Namespace, pg.Name)
+		pods, err := a.podCache.GetByIndex(indexeres.PodGroupPodIndex, key)
+		if err != nil {
+			return nil, err
+		}
+		total := corev1.ResourceList{}
+		for _, pod := range pods {
+			if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+				continue
+			}
+			addResources(total, getPodRequests(pod))
+		}
+		requests[key] = total
+	}
+	return requests, nil
+}
+
+// listWorkloadNamespaces returns the namespace of each RayCluster, RayJob, RayService and Notebook assigned to the
+// queue, the RayClusters created by the RayJobs and RayServices are counted by their owners
+func (a *usageAggregator) listWorkloadNamespaces(queueName string) ([]string, error) {
+	namespaces := make([]string, 0)
+
+	clusters, err := a.rayClusterCache.GetByIndex(indexeres.QueueNameIndex, queueName)
+	if err != nil {
+		return nil, err
+	}
+	for _, cluster := range clusters {
+		if metav1.GetControllerOf(cluster) == nil {
+			namespaces = append(namespaces, cluster.Namespace)
+		}
+	}
+	jobs, err := a.rayJobCache.GetByIndex(indexeres.QueueNameIndex, queueName)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		namespaces = append(namespaces, job.Namespace)
+	}
+	services, err := a.rayServiceCache.GetByIndex(indexeres.QueueNameIndex, queueName)
+	if err != nil {
+		return nil, err
+	}
+	for _, service := range services {
+		namespaces = append(namespaces, service.Namespace)
+	}
+	notebooks, err := a.notebookCache.GetByIndex(indexeres.QueueNameIndex, queueName)
+	if err != nil {
+		return nil, err
+	}
+	for _, notebook := range notebooks {
+		namespaces = append(namespaces, notebook.Namespace)
+	}
+	return namespaces, nil
+}
+
+func getQueueUsage(queue *scheduling.Queue, podGroups []*scheduling.PodGroup, podGroupRequests map[string]corev1.ResourceList,
+	workloadNamespaces []string, canList func(namespace string) bool) *QueueUsage {
+	usage := &QueueUsage{
+		PendingPodGroups: make([]PendingPodGroup, 0),
+	}
+
+	consumers := make(map[string]*NamespaceUsage)
+	getConsumer := func(namespace string) *NamespaceUsage {
+		if consumers[namespace] == nil {
+			consumers[namespace] = &NamespaceUsage{Namespace: namespace}
+		}
+		return consumers[namespace]
+	}
+	for _, namespace := range workloadNamespaces {
+		getConsumer(namespace).Workloads++
+	}
+
+	allocated := corev1.ResourceList{}
+	for _, pg := range podGroups {
+		if pg.Spec.Queue != queue.Name {
+			continue
+		}
+		switch pg.Status.Phase {
+		case scheduling.PodGroupPending, scheduling.PodGroupInqueue:
+			if canList(pg.Namespace) {
+				usage.PendingPodGroups = append(usage.PendingPodGroups, getPendingPodGroup(pg))
+			}
+		case scheduling.PodGroupRunning:
+			requests := podGroupRequests[utils.NewRef(pg.Namespace, pg.Name)]
+			addResources(allocated, requests)
+			consumer := getConsumer(pg.Namespace)
+			consumer.CPU.Add(getResource(requests, corev1.ResourceCPU))
+			consumer.Memory.Add(getResource(requests, corev1.ResourceMemory))
+			consumer.GPU.Add(getResource(requests, resourceGPU))
+		}
+	}
+	sort.Slice(usage.PendingPodGroups, func(i, j int) bool {
+		return usage.PendingPodGroups[i].Since.Before(&usage.PendingPodGroups[j].Since)
+	})
+
+	// the allocated resources are reported by volcano in the queue status, the requests of the bound pods of the
+	// running PodGroups are summed up if the scheduler doesn't report them
+	if queue.Status.Allocated != nil {
+		allocated = queue.Status.Allocated
+	}
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory, resourceGPU} {
+		resourceUsage := ResourceUsage{
+			Resource:  string(name),
+			Allocated: getResource(allocated, name),
+		}
+		if hasResource(queue.Spec.Capability, name) {
+			capability := getResource(queue.Spec.Capability, name)
+			resourceUsage.Capability = &capability
+		}
+		usage.Resources = append(usage.Resources, resourceUsage)
+	}
+
+	// the namespaces of the other users are hidden from the consumers
+	for namespace := range consumers {
+		if !canList(namespace) {
+			delete(consumers, namespace)
+		}
+	}
+	usage.TopConsumers = getTopConsumers(consumers)
+	return usage
+}
+
+func getPendingPodGroup(pg *scheduling.PodGroup) PendingPodGroup {
+	pending := PendingPodGroup{
+		Namespace: pg.Namespace,
+		Name:      pg.Name,
+		Phase:     string(pg.Status.Phase),
+		Since:     pg.CreationTimestamp,
+	}
+	if owner := metav1.GetControllerOf(pg); owner != nil {
+		pending.Owner = owner.Kind + "/" + owner.Name
+	}
+	if pg.Spec.MinResources != nil {
+		pending.MinResources = *pg.Spec.MinResources
+	}
+
+	// the latest unschedulable condition explains why the PodGroup is not scheduled
+	for i := len(pg.Status.Conditions) - 1; i >= 0; i-- {
+		condition := pg.Status.Conditions[i]
+		if condition.Type == scheduling.PodGroupUnschedulableType && condition.Status == corev1.ConditionTrue {
+			pending.Reason = condition.Reason
+			pending.Message = condition.Message
+			break
+		}
+	}
+	return pending
+}
+
+// getTopConsumers returns the namespaces consuming the most resources, ordered by GPU, CPU and memory
+func getTopConsumers(consumers map[string]*NamespaceUsage) []NamespaceUsage {
+	result := make([]NamespaceUsage, 0, len(consumers))
+	for _, consumer := range consumers {
+		result = append(result, *consumer)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if c := result[i].GPU.Cmp(result[j].GPU); c != 0 {
+			return c > 0
+		}
+		if c := result[i].CPU.Cmp(result[j].CPU); c != 0 {
+			return c > 0
+		}
+		if c := result[i].Memory.Cmp(result[j].Memory); c != 0 {
+			return c > 0
+		}
+		return result[i].Namespace < result[j].Namespace
+	})
+	if len(result) > topConsumersLimit {
+		result = result[:topConsumersLimit]
+	}
+	return result
+}
+
+// getPodRequests returns the effective requests of the pod, an init container runs alone so its requests only count
+// when they are larger than the sum of the app containers
+func getPodRequests(pod *corev1.Pod) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		addResources(requests, container.Resources.Requests)
+	}
+	for _, container := range pod.Spec.InitContainers {
+		for name, quantity := range container.Resources.Requests {
+			if current, ok := requests[name]; !ok || quantity.Cmp(current) > 0 {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	if pod.Spec.Overhead != nil {
+		addResources(requests, pod.Spec.Overhead)
+	}
+	return requests
+}
+
+func addResources(total, resources corev1.ResourceList) {
+	for name, quantity := range resources {
+		sum := total[name]
+		sum.Add(quantity)
+		total[name] = sum
+	}
+}
+
+// getResource returns the quantity of the resource, the gpu resource is the sum of all the accelerator resources
+func getResource(resources corev1.ResourceList, name corev1.ResourceName) resource.Quantity {
+	if name != resourceGPU {
+		return resources[name].DeepCopy()
+	}
+	sum := resource.Quantity{}
+	for resourceName, quantity := range resources {
+		if utils.IsAcceleratorResource(resourceName) {
+			sum.Add(quantity)
+		}
+	}
+	return sum
+}
+
+func hasResource(resources corev1.ResourceList, name corev1.ResourceName) bool {
+	if name != resourceGPU {
+		_, ok := resources[name]
+		return ok
+	}
+	for resourceName := range resources {
+		if utils.IsAcceleratorResource(resourceName) {
+			return true
+		}
+	}
+	return false
+}
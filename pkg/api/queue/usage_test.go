@@ -0,0 +1,154 @@
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	scheduling "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+)
+
+func newResources(cpu, memory string, gpu int64) corev1.ResourceList {
+	resources := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}
+	if gpu > 0 {
+		resources[utils.ResourceNvidiaGPU] = *resource.NewQuantity(gpu, resource.DecimalSI)
+	}
+	return resources
+}
+
+func newPodGroup(namespace, name, queue string, phase scheduling.PodGroupPhase, cpu, memory string, gpu int64) *scheduling.PodGroup {
+	minResources := newResources(cpu, memory, gpu)
+	return &scheduling.PodGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         namespace,
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+		},
+		Spec: scheduling.PodGroupSpec{
+			Queue:        queue,
+			MinResources: &minResources,
+		},
+		Status: scheduling.PodGroupStatus{
+			Phase: phase,
+		},
+	}
+}
+
+func Test_getQueueUsage(t *testing.T) {
+	queue := &scheduling.Queue{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a"},
+		Spec: scheduling.QueueSpec{
+			Capability: corev1.ResourceList{
+				corev1.ResourceCPU:      resource.MustParse("16"),
+				utils.ResourceNvidiaGPU: resource.MustParse("4"),
+			},
+		},
+	}
+
+	pending := newPodGroup("ns-b", "ray-cluster-b-pg", queue.Name, scheduling.PodGroupPending, "8", "16Gi", 2)
+	pending.OwnerReferences = []metav1.OwnerReference{
+		{Kind: "RayCluster", Name: "cluster-b", Controller: pointer.Bool(true)},
+	}
+	pending.Status.Conditions = []scheduling.PodGroupCondition{
+		{
+			Type:    scheduling.PodGroupUnschedulableType,
+			Status:  corev1.ConditionTrue,
+			Reason:  scheduling.NotEnoughResourcesReason,
+			Message: "queue resource quota insufficient",
+		},
+	}
+	podGroups := []*scheduling.PodGroup{
+		newPodGroup("ns-a", "ray-cluster-a-pg", queue.Name, scheduling.PodGroupRunning, "4", "8Gi", 2),
+		newPodGroup("ns-b", "ray-job-b-pg", queue.Name, scheduling.PodGroupRunning, "8", "8Gi", 0),
+		newPodGroup("ns-c", "podgroup-c", queue.Name, scheduling.PodGroupRunning, "2", "4Gi", 0),
+		newPodGroup("ns-a", "podgroup-other", "other", scheduling.PodGroupRunning, "4", "8Gi", 1),
+		pending,
+	}
+
+	// the bound pods of a running PodGroup may request more than its gang minimum
+	podGroupRequests := map[string]corev1.ResourceList{
+		"ns-a/ray-cluster-a-pg": newResources("6", "10Gi", 2),
+		"ns-b/ray-job-b-pg":     newResources("8", "8Gi", 0),
+		"ns-c/podgroup-c":       newResources("2", "4Gi", 0),
+	}
+
+	listAll := func(string) bool { return true }
+	usage := getQueueUsage(queue, podGroups, podGroupRequests, []string{"ns-a", "ns-b", "ns-b"}, listAll)
+
+	// the allocated resources are summed up from the bound pods of the running PodGroups without the queue status
+	if assert.Len(t, usage.Resources, 3) {
+		assert.Equal(t, "cpu", usage.Resources[0].Resource)
+		assert.Equal(t, "16", usage.Resources[0].Allocated.String())
+		assert.Equal(t, "16", usage.Resources[0].Capability.String())
+		assert.Equal(t, "memory", usage.Resources[1].Resource)
+		assert.Equal(t, "22Gi", usage.Resources[1].Allocated.String())
+		assert.Nil(t, usage.Resources[1].Capability)
+		assert.Equal(t, "gpu", usage.Resources[2].Resource)
+		assert.Equal(t, "2", usage.Resources[2].Allocated.String())
+		assert.Equal(t, "4", usage.Resources[2].Capability.String())
+	}
+
+	if assert.Len(t, usage.PendingPodGroups, 1) {
+		assert.Equal(t, "ray-cluster-b-pg", usage.PendingPodGroups[0].Name)
+		assert.Equal(t, "RayCluster/cluster-b", usage.PendingPodGroups[0].Owner)
+		assert.Equal(t, scheduling.NotEnoughResourcesReason, usage.PendingPodGroups[0].Reason)
+		assert.Equal(t, "queue resource quota insufficient", usage.PendingPodGroups[0].Message)
+	}
+
+	// the namespaces are ordered by GPU, CPU and memory
+	if assert.Len(t, usage.TopConsumers, 3) {
+		assert.Equal(t, "ns-a", usage.TopConsumers[0].Namespace)
+		assert.Equal(t, 1, usage.TopConsumers[0].Workloads)
+		assert.Equal(t, "ns-b", usage.TopConsumers[1].Namespace)
+		assert.Equal(t, 2, usage.TopConsumers[1].Workloads)
+		assert.Equal(t, "8", usage.TopConsumers[1].CPU.String())
+		assert.Equal(t, "ns-c", usage.TopConsumers[2].Namespace)
+		assert.Equal(t, 0, usage.TopConsumers[2].Workloads)
+	}
+
+	// the namespaces which can't be listed by the user are only counted in the allocated resources
+	usage = getQueueUsage(queue, podGroups, podGroupRequests, []string{"ns-a", "ns-b", "ns-b"},
+		func(namespace string) bool { return namespace == "ns-a" })
+	assert.Equal(t, "16", usage.Resources[0].Allocated.String())
+	assert.Empty(t, usage.PendingPodGroups)
+	if assert.Len(t, usage.TopConsumers, 1) {
+		assert.Equal(t, "ns-a", usage.TopConsumers[0].Namespace)
+	}
+
+	// the allocated resources reported by volcano win
+	queue.Status.Allocated = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10")}
+	usage = getQueueUsage(queue, podGroups, podGroupRequests, nil, listAll)
+	assert.Equal(t, "10", usage.Resources[0].Allocated.String())
+	assert.Equal(t, "0", usage.Resources[1].Allocated.String())
+}
+
+func Test_getPodRequests(t *testing.T) {
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{Resources: corev1.ResourceRequirements{Requests: newResources("4", "1Gi", 0)}},
+			},
+			Containers: []corev1.Container{
+				{Resources: corev1.ResourceRequirements{Requests: newResources("1", "4Gi", 1)}},
+				{Resources: corev1.ResourceRequirements{Requests: newResources("1", "2Gi", 0)}},
+			},
+		},
+	}
+
+	// the init container only counts when it requests more than the app containers
+	requests := getPodRequests(pod)
+	cpu, memory, gpu := getResource(requests, corev1.ResourceCPU), getResource(requests, corev1.ResourceMemory),
+		getResource(requests, resourceGPU)
+	assert.Equal(t, "4", cpu.String())
+	assert.Equal(t, "6Gi", memory.String())
+	assert.Equal(t, "1", gpu.String())
+}
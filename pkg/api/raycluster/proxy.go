@@ -0,0 +1,99 @@
+package raycluster
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/mux"
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/sirupsen/logrus"
+	authzv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/oneblock-ai/oneblock/pkg/api/auth"
+	ctlraycluster "github.com/oneblock-ai/oneblock/pkg/controller/raycluster"
+	ctlrayv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ray.io/v1"
+	"github.com/oneblock-ai/oneblock/pkg/server/config"
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+)
+
+const proxyVerb = "update"
+
+// ProxyHandler proxies the HTTP and WebSocket requests of /ray/{namespace}/{name}/ to the Ray dashboard of the
+// cluster, including the dashboard UI, the jobs API and the log streaming, all the requests require the permission
+// to update the RayCluster since the dashboard runs the submitted jobs and changes the cluster even by the GET
+// requests, e.g., killing the actors or profiling the workers
+type ProxyHandler struct {
+	rayClusterCache ctlrayv1.RayClusterCache
+	reviewer        *auth.AccessReviewer
+}
+
+func NewProxyHandler(mgmt *config.Management) *ProxyHandler {
+	return &ProxyHandler{
+		rayClusterCache: mgmt.KubeRayFactory.Ray().V1().RayCluster().Cache(),
+		reviewer:        auth.NewAccessReviewer(mgmt),
+	}
+}
+
+func (h *ProxyHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	vars := utils.EncodeVars(mux.Vars(req))
+	namespace, name := vars["namespace"], vars["name"]
+
+	allowed, err := h.reviewer.CanAccess(req.Context(), &authzv1.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      proxyVerb,
+		Group:     rayv1.SchemeGroupVersion.Group,
+		Resource:  "rayclusters",
+		Name:      name,
+	})
+	if err != nil {
+		utils.ResponseError(rw, http.StatusInternalServerError, err)
+		return
+	}
+	if !allowed {
+		utils.ResponseErrorMsg(rw, http.StatusForbidden, fmt.Sprintf("%s access to RayCluster %s/%s is forbidden", proxyVerb, namespace, name))
+		return
+	}
+
+	cluster, err := h.rayClusterCache.Get(namespace, name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			utils.ResponseError(rw, http.StatusNotFound, err)
+			return
+		}
+		utils.ResponseError(rw, http.StatusInternalServerError, err)
+		return
+	}
+
+	target, err := url.Parse(ctlraycluster.GetDashboardURL(cluster))
+	if err != nil {
+		utils.ResponseError(rw, http.StatusInternalServerError, err)
+		return
+	}
+
+	// the dashboard is served at the root path, its UI requests the assets and APIs by the relative paths
+	prefix := GetDashboardProxyPrefix(namespace, name)
+	proxy := &httputil.ReverseProxy{
+		Director: func(r *http.Request) {
+			r.URL.Scheme = target.Scheme
+			r.URL.Host = target.Host
+			r.URL.Path = getDashboardPath(prefix, r.URL.Path)
+			r.URL.RawPath = ""
+			r.Host = target.Host
+			auth.RemoveCredentials(r)
+		},
+		ErrorHandler: func(rw http.ResponseWriter, r *http.Request, err error) {
+			logrus.Debugf("Failed to proxy %s to the dashboard of RayCluster %s/%s: %v", r.URL.Path, namespace, name, err)
+			utils.ResponseError(rw, http.StatusBadGateway, err)
+		},
+	}
+	proxy.ServeHTTP(rw, req)
+}
+
+// getDashboardPath returns the dashboard path of the proxied request path by stripping the proxy prefix
+func getDashboardPath(prefix, path string) string {
+	return "/" + strings.TrimPrefix(strings.TrimPrefix(path, prefix), "/")
+}
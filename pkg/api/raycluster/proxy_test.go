@@ -0,0 +1,41 @@
+package raycluster
+
+import (
+	"testing"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ctlraycluster "github.com/oneblock-ai/oneblock/pkg/controller/raycluster"
+)
+
+func Test_getDashboardPath(t *testing.T) {
+	prefix := GetDashboardProxyPrefix("default", "cluster")
+	assert.Equal(t, "/", getDashboardPath(prefix, "/ray/default/cluster/"))
+	assert.Equal(t, "/api/jobs/", getDashboardPath(prefix, "/ray/default/cluster/api/jobs/"))
+	assert.Equal(t, "/api/v0/logs/stream", getDashboardPath(prefix, "/ray/default/cluster/api/v0/logs/stream"))
+}
+
+func TestGetDashboardURL(t *testing.T) {
+	cluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cluster"},
+		Spec: rayv1.RayClusterSpec{
+			HeadGroupSpec: rayv1.HeadGroupSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "ray-head"}},
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, "http://cluster-head-svc.default.svc:8265", ctlraycluster.GetDashboardURL(cluster))
+
+	cluster.Spec.HeadGroupSpec.HeadService = &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "head"}}
+	cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Ports = []corev1.ContainerPort{
+		{Name: "dashboard", ContainerPort: 8266},
+	}
+	assert.Equal(t, "http://head.default.svc:8266", ctlraycluster.GetDashboardURL(cluster))
+}
@@ -0,0 +1,98 @@
+package raycluster
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/oneblock-ai/apiserver/v2/pkg/apierror"
+	"github.com/rancher/wrangler/v2/pkg/schemas/validation"
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/sirupsen/logrus"
+	authzv1 "k8s.io/api/authorization/v1"
+	"k8s.io/utils/pointer"
+
+	ctlraycluster "github.com/oneblock-ai/oneblock/pkg/controller/raycluster"
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+)
+
+func (h Handler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if err := h.do(rw, req); err != nil {
+		status := http.StatusInternalServerError
+		var e *apierror.APIError
+		if errors.As(err, &e) {
+			status = e.Code.Status
+		}
+		rw.WriteHeader(status)
+		_, _ = rw.Write([]byte(err.Error()))
+		return
+	}
+}
+
+func (h Handler) do(rw http.ResponseWriter, req *http.Request) error {
+	vars := utils.EncodeVars(mux.Vars(req))
+	if req.Method == http.MethodPost {
+		return h.doPost(vars["action"], rw, req)
+	}
+
+	return apierror.NewAPIError(validation.InvalidAction, fmt.Sprintf("Unsupported method %s", req.Method))
+}
+
+func (h Handler) doPost(action string, rw http.ResponseWriter, req *http.Request) error {
+	vars := utils.EncodeVars(mux.Vars(req))
+	switch action {
+	case ActionResume:
+		if err := h.authorize(req, vars["namespace"], vars["name"]); err != nil {
+			return err
+		}
+		if err := h.resumeCluster(vars["namespace"], vars["name"]); err != nil {
+			return err
+		}
+	default:
+		return apierror.NewAPIError(validation.InvalidAction, fmt.Sprintf("Unsupported POST action %s", action))
+	}
+	rw.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// authorize checks the user is allowed to update the cluster, the action only requires the get permission while the
+// suspension is cleared by the API server
+func (h Handler) authorize(req *http.Request, namespace, name string) error {
+	allowed, err := h.reviewer.CanAccess(req.Context(), &authzv1.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      "update",
+		Group:     rayv1.SchemeGroupVersion.Group,
+		Resource:  "rayclusters",
+		Name:      name,
+	})
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return apierror.NewAPIError(validation.PermissionDenied,
+			fmt.Sprintf("update access to RayCluster %s/%s is forbidden", namespace, name))
+	}
+	return nil
+}
+
+// resumeCluster clears the suspension of the cluster, KubeRay recreates the head and workers, and the GCS state
+// is restored from Redis if the GCS fault tolerance is enabled
+func (h Handler) resumeCluster(namespace, name string) error {
+	logrus.Debugf("Resume RayCluster %s/%s", namespace, name)
+	cluster, err := h.rayClusterCache.Get(namespace, name)
+	if err != nil {
+		return err
+	}
+
+	if !ctlraycluster.IsSuspended(cluster) {
+		return nil
+	}
+
+	clusterCpy := cluster.DeepCopy()
+	clusterCpy.Spec.Suspend = pointer.Bool(false)
+	delete(clusterCpy.Annotations, constant.AnnotationRayClusterSuspendedAt)
+	_, err = h.rayClusters.Update(clusterCpy)
+	return err
+}
@@ -0,0 +1,102 @@
+package raycluster
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/oneblock-ai/apiserver/v2/pkg/types"
+	"github.com/oneblock-ai/steve/v2/pkg/schema"
+	"github.com/oneblock-ai/steve/v2/pkg/server"
+	"github.com/rancher/wrangler/v2/pkg/schemas"
+
+	"github.com/oneblock-ai/oneblock/pkg/api/auth"
+	ctlkuberayv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ray.io/v1"
+	"github.com/oneblock-ai/oneblock/pkg/server/config"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+)
+
+const (
+	rayClusterSchemaID = "ray.io.raycluster"
+	mlServiceSchemaID  = "ml.oneblock.ai.mlservice"
+
+	linkDashboard = "dashboard"
+
+	ActionResume = "resume"
+)
+
+type Handler struct {
+	rayClusters     ctlkuberayv1.RayClusterClient
+	rayClusterCache ctlkuberayv1.RayClusterCache
+	reviewer        *auth.AccessReviewer
+}
+
+func RegisterSchema(mgmt *config.Management, server *server.Server) error {
+	rayClusters := mgmt.KubeRayFactory.Ray().V1().RayCluster()
+	h := Handler{
+		rayClusters:     rayClusters,
+		rayClusterCache: rayClusters.Cache(),
+		reviewer:        auth.NewAccessReviewer(mgmt),
+	}
+
+	t := []schema.Template{
+		{
+			ID:        rayClusterSchemaID,
+			Formatter: formatter,
+			Customize: func(apiSchema *types.APISchema) {
+				apiSchema.ResourceActions = map[string]schemas.Action{
+					ActionResume: {},
+				}
+				apiSchema.ActionHandlers = map[string]http.Handler{
+					ActionResume: h,
+				}
+			},
+		},
+		{
+			ID:        mlServiceSchemaID,
+			Formatter: mlServiceFormatter,
+		},
+	}
+
+	server.SchemaFactory.AddTemplate(t...)
+	return nil
+}
+
+func formatter(request *types.APIRequest, resource *types.RawResource) {
+	data := resource.APIObject.Data()
+	addDashboardLink(request, resource, data.String("metadata", "namespace"), data.String("metadata", "name"))
+
+	if !data.Bool("spec", "suspend") {
+		return
+	}
+	// the suspended cluster is resumed by the action, the suspended time is recorded by the idle suspender.
+	// The action is added to the actions of the other formatters.
+	if resource.Actions == nil {
+		resource.Actions = make(map[string]string, 1)
+	}
+	resource.AddAction(request, ActionResume)
+	if since := data.String("metadata", "annotations", constant.AnnotationRayClusterSuspendedAt); since != "" {
+		data.SetNested(since, "status", "suspendedSince")
+	}
+}
+
+// mlServiceFormatter links the dashboard of the active RayCluster of the RayService backend
+func mlServiceFormatter(request *types.APIRequest, resource *types.RawResource) {
+	data := resource.APIObject.Data()
+	clusterName := data.String("status", "rayServiceStatuses", "activeServiceStatus", "rayClusterName")
+	if clusterName == "" {
+		return
+	}
+	addDashboardLink(request, resource, data.String("metadata", "namespace"), clusterName)
+}
+
+func addDashboardLink(request *types.APIRequest, resource *types.RawResource, namespace, name string) {
+	if resource.Links == nil {
+		resource.Links = make(map[string]string, 1)
+	}
+	resource.Links[linkDashboard] = request.URLBuilder.RelativeToRoot(GetDashboardProxyPrefix(namespace, name) + "/")
+}
+
+// GetDashboardProxyPrefix returns the path prefix of the Ray dashboard served by the API server proxy
+func GetDashboardProxyPrefix(namespace, name string) string {
+	return fmt.Sprintf("/ray/%s/%s", namespace, name)
+}
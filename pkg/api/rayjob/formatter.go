@@ -0,0 +1,46 @@
+package rayjob
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/oneblock-ai/apiserver/v2/pkg/apierror"
+	"github.com/rancher/wrangler/v2/pkg/schemas/validation"
+
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+)
+
+func (h Handler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if err := h.do(rw, req); err != nil {
+		status := http.StatusInternalServerError
+		var e *apierror.APIError
+		if errors.As(err, &e) {
+			status = e.Code.Status
+		}
+		rw.WriteHeader(status)
+		_, _ = rw.Write([]byte(err.Error()))
+		return
+	}
+}
+
+func (h Handler) do(rw http.ResponseWriter, req *http.Request) error {
+	vars := utils.EncodeVars(mux.Vars(req))
+	if req.Method == http.MethodPost {
+		return h.doPost(vars["action"], rw, req)
+	}
+
+	return apierror.NewAPIError(validation.InvalidAction, fmt.Sprintf("Unsupported method %s", req.Method))
+}
+
+func (h Handler) doPost(action string, rw http.ResponseWriter, req *http.Request) error {
+	vars := utils.EncodeVars(mux.Vars(req))
+	switch action {
+	case ActionSubmit:
+		// the collection action is served by the /v1/{type}/{namespace} route
+		return h.submitJob(rw, req, vars["nameorns"])
+	default:
+		return apierror.NewAPIError(validation.InvalidAction, fmt.Sprintf("Unsupported POST action %s", action))
+	}
+}
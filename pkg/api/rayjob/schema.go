@@ -0,0 +1,52 @@
+package rayjob
+
+import (
+	"net/http"
+
+	"github.com/oneblock-ai/apiserver/v2/pkg/types"
+	"github.com/oneblock-ai/steve/v2/pkg/schema"
+	"github.com/oneblock-ai/steve/v2/pkg/server"
+	ctlcorev1 "github.com/rancher/wrangler/v2/pkg/generated/controllers/core/v1"
+	"github.com/rancher/wrangler/v2/pkg/schemas"
+
+	"github.com/oneblock-ai/oneblock/pkg/api/auth"
+	ctlrayv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ray.io/v1"
+	"github.com/oneblock-ai/oneblock/pkg/server/config"
+)
+
+const (
+	rayJobSchemaID = "ray.io.rayjob"
+
+	ActionSubmit = "submit"
+)
+
+type Handler struct {
+	rayJobs    ctlrayv1.RayJobClient
+	configMaps ctlcorev1.ConfigMapClient
+	reviewer   *auth.AccessReviewer
+}
+
+func RegisterSchema(mgmt *config.Management, server *server.Server) error {
+	h := Handler{
+		rayJobs:    mgmt.KubeRayFactory.Ray().V1().RayJob(),
+		configMaps: mgmt.CoreFactory.Core().V1().ConfigMap(),
+		reviewer:   auth.NewAccessReviewer(mgmt),
+	}
+
+	t := []schema.Template{
+		{
+			ID: rayJobSchemaID,
+			Customize: func(apiSchema *types.APISchema) {
+				apiSchema.CollectionActions = map[string]schemas.Action{
+					ActionSubmit: {},
+				}
+				apiSchema.ActionHandlers = map[string]http.Handler{
+					ActionSubmit: h,
+				}
+			},
+		},
+	}
+
+	server.SchemaFactory.AddTemplate(t...)
+	return nil
+}
@@ -0,0 +1,231 @@
+package rayjob
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+
+	"github.com/oneblock-ai/apiserver/v2/pkg/apierror"
+	"github.com/rancher/wrangler/v2/pkg/schemas/validation"
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/sirupsen/logrus"
+	authzv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/oneblock-ai/oneblock/pkg/settings"
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+)
+
+const (
+	// maxWorkingDirSize is the max size of the zipped working dir, since it is stored in a ConfigMap limited to 1MiB
+	maxWorkingDirSize = 1000 * 1024
+	// maxFormSize is the max size of the multipart form including the RayJob manifest
+	maxFormSize = maxWorkingDirSize + 256*1024
+
+	formFieldRayJob     = "rayJob"
+	formFieldWorkingDir = "workingDir"
+
+	workingDirKey           = "working_dir.zip"
+	workingDirVolumeName    = "working-dir"
+	workingDirMountPath     = "/home/ray/working-dir"
+	runtimeEnvWorkingDirKey = "working_dir"
+	submitterContainerName  = "ray-job-submitter"
+)
+
+// submitJob creates a RayJob from the multipart form of the RayJob manifest and the zipped working dir, the working
+// dir is stored in a ConfigMap owned by the job and mounted to the submitter pod, which uploads it to the cluster
+// by the working_dir of the runtime env
+func (h Handler) submitJob(rw http.ResponseWriter, req *http.Request, namespace string) error {
+	if namespace == "" {
+		return apierror.NewAPIError(validation.MissingRequired, "namespace is required to submit a RayJob")
+	}
+
+	req.Body = http.MaxBytesReader(rw, req.Body, maxFormSize)
+	if err := req.ParseMultipartForm(maxFormSize); err != nil {
+		return apierror.NewAPIError(validation.InvalidBodyContent, fmt.Sprintf("Failed to parse multipart form: %v", err))
+	}
+
+	job := &rayv1.RayJob{}
+	if err := yaml.Unmarshal([]byte(req.FormValue(formFieldRayJob)), job); err != nil {
+		return apierror.NewAPIError(validation.InvalidBodyContent, fmt.Sprintf("Failed to parse the RayJob: %v", err))
+	}
+	if job.Name == "" {
+		return apierror.NewAPIError(validation.MissingRequired, "name of the RayJob is required")
+	}
+	job.Namespace = namespace
+
+	workingDir, err := readWorkingDir(req)
+	if err != nil {
+		return err
+	}
+
+	// the job is created by the API server, check the user is allowed to create it in the namespace
+	allowed, err := h.reviewer.CanAccess(req.Context(), &authzv1.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      "create",
+		Group:     rayv1.SchemeGroupVersion.Group,
+		Resource:  "rayjobs",
+	})
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return apierror.NewAPIError(validation.PermissionDenied,
+			fmt.Sprintf("creating rayjobs in namespace %s is forbidden", namespace))
+	}
+
+	if err = setWorkingDir(job, getWorkingDirConfigMapName(job.Name)); err != nil {
+		return apierror.NewAPIError(validation.InvalidBodyContent, err.Error())
+	}
+
+	logrus.Debugf("Submit RayJob %s/%s with a working dir of %d bytes", job.Namespace, job.Name, len(workingDir))
+	created, err := h.rayJobs.Create(job)
+	if err != nil {
+		return err
+	}
+
+	if _, err = h.configMaps.Create(newWorkingDirConfigMap(created, workingDir)); err != nil {
+		// the job can't be submitted without the working dir
+		if deleteErr := h.rayJobs.Delete(created.Namespace, created.Name, &metav1.DeleteOptions{}); deleteErr != nil {
+			logrus.Warnf("Failed to delete RayJob %s/%s: %v", created.Namespace, created.Name, deleteErr)
+		}
+		return fmt.Errorf("failed to create the working dir of RayJob %s/%s: %w", created.Namespace, created.Name, err)
+	}
+
+	utils.ResponseOKWithBody(rw, created)
+	return nil
+}
+
+// readWorkingDir reads the uploaded working dir which must be a zip archive
+func readWorkingDir(req *http.Request) ([]byte, error) {
+	file, _, err := req.FormFile(formFieldWorkingDir)
+	if err != nil {
+		return nil, apierror.NewAPIError(validation.MissingRequired, fmt.Sprintf("Failed to read the working dir: %v", err))
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxWorkingDirSize+1))
+	if err != nil {
+		return nil, apierror.NewAPIError(validation.InvalidBodyContent, fmt.Sprintf("Failed to read the working dir: %v", err))
+	}
+	if len(data) > maxWorkingDirSize {
+		return nil, apierror.NewAPIError(validation.MaxLengthExceeded,
+			fmt.Sprintf("the zipped working dir exceeds the max size of %d bytes", maxWorkingDirSize))
+	}
+	if _, err = zip.NewReader(bytes.NewReader(data), int64(len(data))); err != nil {
+		return nil, apierror.NewAPIError(validation.InvalidFormat, fmt.Sprintf("the working dir must be a zip archive: %v", err))
+	}
+	return data, nil
+}
+
+// setWorkingDir mounts the working dir ConfigMap to the submitter pod and sets it as the working_dir of the runtime env
+func setWorkingDir(job *rayv1.RayJob, configMapName string) error {
+	if job.Spec.RuntimeEnv != "" {
+		return fmt.Errorf("runtimeEnv is deprecated, use runtimeEnvYAML instead")
+	}
+
+	runtimeEnv := make(map[string]interface{})
+	if err := yaml.Unmarshal([]byte(job.Spec.RuntimeEnvYAML), &runtimeEnv); err != nil {
+		return fmt.Errorf("invalid runtimeEnvYAML: %w", err)
+	}
+	if runtimeEnv == nil {
+		runtimeEnv = make(map[string]interface{}, 1)
+	}
+	if _, ok := runtimeEnv[runtimeEnvWorkingDirKey]; ok {
+		return fmt.Errorf("working_dir of the runtimeEnvYAML conflicts with the uploaded working dir")
+	}
+	runtimeEnv[runtimeEnvWorkingDirKey] = path.Join(workingDirMountPath, workingDirKey)
+	runtimeEnvYAML, err := yaml.Marshal(runtimeEnv)
+	if err != nil {
+		return err
+	}
+	job.Spec.RuntimeEnvYAML = string(runtimeEnvYAML)
+
+	if job.Spec.SubmitterPodTemplate == nil {
+		job.Spec.SubmitterPodTemplate = getDefaultSubmitterTemplate(job)
+	}
+	podSpec := &job.Spec.SubmitterPodTemplate.Spec
+	if len(podSpec.Containers) == 0 {
+		return fmt.Errorf("containers of the submitterPodTemplate are required")
+	}
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: workingDirVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: configMapName},
+			},
+		},
+	})
+	// KubeRay takes the first container as the submitter
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      workingDirVolumeName,
+		MountPath: workingDirMountPath,
+		ReadOnly:  true,
+	})
+	return nil
+}
+
+// getDefaultSubmitterTemplate returns the submitter template same as the default one of KubeRay, the image of
+// the head is used to avoid the Ray version mismatch
+func getDefaultSubmitterTemplate(job *rayv1.RayJob) *corev1.PodTemplateSpec {
+	image := settings.RayClusterImage.Get()
+	if spec := job.Spec.RayClusterSpec; spec != nil && len(spec.HeadGroupSpec.Template.Spec.Containers) > 0 {
+		image = spec.HeadGroupSpec.Template.Spec.Containers[0].Image
+	}
+
+	return &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  submitterContainerName,
+					Image: image,
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("1"),
+							corev1.ResourceMemory: resource.MustParse("1Gi"),
+						},
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("500m"),
+							corev1.ResourceMemory: resource.MustParse("200Mi"),
+						},
+					},
+				},
+			},
+			RestartPolicy: corev1.RestartPolicyNever,
+		},
+	}
+}
+
+func getWorkingDirConfigMapName(jobName string) string {
+	return fmt.Sprintf("%s-working-dir", jobName)
+}
+
+func newWorkingDirConfigMap(job *rayv1.RayJob, workingDir []byte) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getWorkingDirConfigMapName(job.Name),
+			Namespace: job.Namespace,
+			Labels: map[string]string{
+				constant.LabelRayJobName: job.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: rayv1.SchemeGroupVersion.String(),
+					Kind:       constant.RayJobKind,
+					Name:       job.Name,
+					UID:        job.UID,
+				},
+			},
+		},
+		BinaryData: map[string][]byte{
+			workingDirKey: workingDir,
+		},
+	}
+}
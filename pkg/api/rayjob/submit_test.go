@@ -0,0 +1,51 @@
+package rayjob
+
+import (
+	"testing"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func Test_setWorkingDir(t *testing.T) {
+	job := &rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			Entrypoint:     "python train.py",
+			RuntimeEnvYAML: "pip:\n- torch\n",
+			RayClusterSpec: &rayv1.RayClusterSpec{
+				HeadGroupSpec: rayv1.HeadGroupSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "ray-head", Image: "rayproject/ray:2.9.0"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	require.NoError(t, setWorkingDir(job, "train-working-dir"))
+
+	runtimeEnv := make(map[string]interface{})
+	require.NoError(t, yaml.Unmarshal([]byte(job.Spec.RuntimeEnvYAML), &runtimeEnv))
+	assert.Equal(t, "/home/ray/working-dir/working_dir.zip", runtimeEnv["working_dir"])
+	assert.Equal(t, []interface{}{"torch"}, runtimeEnv["pip"])
+
+	podSpec := job.Spec.SubmitterPodTemplate.Spec
+	require.Len(t, podSpec.Containers, 1)
+	assert.Equal(t, "rayproject/ray:2.9.0", podSpec.Containers[0].Image)
+	assert.Equal(t, corev1.RestartPolicyNever, podSpec.RestartPolicy)
+	require.Len(t, podSpec.Volumes, 1)
+	assert.Equal(t, "train-working-dir", podSpec.Volumes[0].ConfigMap.Name)
+	require.Len(t, podSpec.Containers[0].VolumeMounts, 1)
+	assert.Equal(t, "/home/ray/working-dir", podSpec.Containers[0].VolumeMounts[0].MountPath)
+
+	// the working dir can't be set twice
+	assert.Error(t, setWorkingDir(job, "train-working-dir"))
+
+	job = &rayv1.RayJob{Spec: rayv1.RayJobSpec{RuntimeEnv: "e30="}}
+	assert.Error(t, setWorkingDir(job, "train-working-dir"))
+}
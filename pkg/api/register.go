@@ -5,7 +5,11 @@ import (
 
 	"github.com/oneblock-ai/steve/v2/pkg/server"
 
+	"github.com/oneblock-ai/oneblock/pkg/api/modeltemplate"
+	"github.com/oneblock-ai/oneblock/pkg/api/notebook"
 	"github.com/oneblock-ai/oneblock/pkg/api/queue"
+	"github.com/oneblock-ai/oneblock/pkg/api/raycluster"
+	"github.com/oneblock-ai/oneblock/pkg/api/rayjob"
 	"github.com/oneblock-ai/oneblock/pkg/server/config"
 )
 
@@ -22,5 +26,9 @@ func registerSchemas(mgmt *config.Management, server *server.Server, registers .
 
 func Register(_ context.Context, mgmt *config.Management, server *server.Server) error {
 	return registerSchemas(mgmt, server,
-		queue.RegisterSchema)
+		queue.RegisterSchema,
+		modeltemplate.RegisterSchema,
+		notebook.RegisterSchema,
+		rayjob.RegisterSchema,
+		raycluster.RegisterSchema)
 }
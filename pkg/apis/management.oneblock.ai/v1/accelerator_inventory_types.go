@@ -0,0 +1,48 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName=accinv;accinvs,scope=Cluster
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=`.status.type`
+// +kubebuilder:printcolumn:name="RESOURCE",type="string",JSONPath=`.status.resourceName`
+// +kubebuilder:printcolumn:name="COUNT",type="integer",JSONPath=`.status.count`
+// +kubebuilder:printcolumn:name="ALLOCATABLE",type="integer",JSONPath=`.status.allocatable`
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// AcceleratorInventory is the cluster-wide inventory of an accelerator type discovered from the node labels,
+// it's created once a node of the type is found and removed once none of the nodes is left
+type AcceleratorInventory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status AcceleratorInventoryStatus `json:"status,omitempty"`
+}
+
+type AcceleratorInventoryStatus struct {
+	// Type is the accelerator type used by the nodeSelector of the workloads, e.g., A100
+	Type string `json:"type,omitempty"`
+	// ResourceName is the extended resource name of the device plugin, e.g., nvidia.com/gpu
+	ResourceName corev1.ResourceName `json:"resourceName,omitempty"`
+	// Count is the total capacity of the accelerators of the nodes
+	Count int64 `json:"count"`
+	// Allocatable is the total allocatable accelerators of the nodes
+	Allocatable int64 `json:"allocatable"`
+	// +optional
+	Nodes []AcceleratorNode `json:"nodes,omitempty"`
+}
+
+type AcceleratorNode struct {
+	Name string `json:"name"`
+	// Product is the product name of the accelerator reported by the node labels, e.g., NVIDIA-A100-SXM4-80GB
+	// +optional
+	Product     string `json:"product,omitempty"`
+	Count       int64  `json:"count"`
+	Allocatable int64  `json:"allocatable"`
+}
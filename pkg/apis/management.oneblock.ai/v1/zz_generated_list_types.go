@@ -26,6 +26,23 @@ import (
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// AcceleratorInventoryList is a list of AcceleratorInventory resources
+type AcceleratorInventoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []AcceleratorInventory `json:"items"`
+}
+
+func NewAcceleratorInventory(namespace, name string, obj AcceleratorInventory) *AcceleratorInventory {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("AcceleratorInventory").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // SettingList is a list of Setting resources
 type SettingList struct {
 	metav1.TypeMeta `json:",inline"`
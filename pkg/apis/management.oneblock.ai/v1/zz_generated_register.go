@@ -28,8 +28,9 @@ import (
 )
 
 var (
-	SettingResourceName = "settings"
-	UserResourceName    = "users"
+	AcceleratorInventoryResourceName = "acceleratorinventories"
+	SettingResourceName              = "settings"
+	UserResourceName                 = "users"
 )
 
 // SchemeGroupVersion is group version used to register these objects
@@ -53,6 +54,8 @@ var (
 // Adds the list of known types to Scheme.
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
+		&AcceleratorInventory{},
+		&AcceleratorInventoryList{},
 		&Setting{},
 		&SettingList{},
 		&User{},
@@ -6,3 +6,14 @@ type Volume struct {
 	Name string                           `json:"name"`
 	Spec corev1.PersistentVolumeClaimSpec `json:"spec"`
 }
+
+// ScheduleWindow is a time window opened and closed by the cron expressions of the standard five fields
+// "minute hour day-of-month month day-of-week", e.g., "0 8 * * 1-5" opens the window at 08:00 of the weekdays.
+type ScheduleWindow struct {
+	// Start is the cron expression of the times to open the window
+	Start string `json:"start,omitempty"`
+	// Stop is the cron expression of the times to close the window
+	Stop string `json:"stop,omitempty"`
+	// IANA timezone of the cron expressions, e.g., Asia/Shanghai, defaults to UTC.
+	Timezone string `json:"timezone,omitempty"`
+}
@@ -10,9 +10,20 @@ import (
 )
 
 var (
-	MLServiceCreated condition.Cond = "created"
-	MLServiceReady   condition.Cond = "ready"
-	MLServicePending condition.Cond = "pending"
+	MLServiceCreated  condition.Cond = "created"
+	MLServiceReady    condition.Cond = "ready"
+	MLServicePending  condition.Cond = "pending"
+	MLServiceConflict condition.Cond = "conflict"
+)
+
+type MLServiceBackendType string
+
+const (
+	// MLServiceBackendRayService serves the model by RayService with the RayLLM serve application
+	MLServiceBackendRayService MLServiceBackendType = "RayService"
+	// MLServiceBackendVLLM serves the model by a plain vLLM OpenAI server deployment, it is suitable for the
+	// small models that fit into a single node
+	MLServiceBackendVLLM MLServiceBackendType = "VLLM"
 )
 
 // +genclient
@@ -20,7 +31,9 @@ var (
 // +kubebuilder:subresource:status
 // +kubebuilder:scope=Namespaced
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=`.metadata.creationTimestamp`
-// +kubebuilder:printcolumn:name="ServiceStatus",type=string,JSONPath=".status.rayServiceStatuses.serviceStatus"
+// +kubebuilder:printcolumn:name="Backend",type=string,JSONPath=".spec.backend"
+// +kubebuilder:printcolumn:name="ServiceStatus",type=string,JSONPath=".status.serviceStatus"
+// +kubebuilder:printcolumn:name="Endpoint",type=string,priority=8,JSONPath=".status.endpoint"
 
 // MLService is the Schema for the LLM application service
 type MLService struct {
@@ -36,8 +49,38 @@ type MLServiceSpec struct {
 	ModelTemplateVersionRef *ModelTemplateVersionRef `json:"modelTemplateVersionRef"`
 	// optional
 	HFSecretRef *HFSecretRef `json:"hfSecretRef,omitempty"`
-	// +kubebuilder:validation:Required
-	MLClusterRef *MLClusterRef `json:"mlClusterRef"`
+	// Backend is the serving backend of the model, defaults to RayService
+	// +kubebuilder:validation:Enum=RayService;VLLM
+	// +kubebuilder:default:=RayService
+	Backend MLServiceBackendType `json:"backend,omitempty"`
+	// MLClusterRef is the Ray cluster config of the RayService backend
+	// +optional
+	MLClusterRef *MLClusterRef `json:"mlClusterRef,omitempty"`
+	// VLLMConfig is the deployment config of the VLLM backend
+	// +optional
+	VLLMConfig *VLLMConfig `json:"vllmConfig,omitempty"`
+}
+
+// VLLMConfig defines the vLLM OpenAI server deployment, the replicas and autoscaling range are taken from
+// the deployment config of the model template version
+type VLLMConfig struct {
+	// Image is the vLLM OpenAI server image, defaults to the default-vllm-image setting
+	Image string `json:"image,omitempty"`
+	// Args are the extra arguments of the vLLM OpenAI server, e.g., --max-model-len=4096
+	Args         []string                     `json:"args,omitempty"`
+	Resources    *corev1.ResourceRequirements `json:"resources,omitempty"`
+	NodeSelector map[string]string            `json:"nodeSelector,omitempty"`
+	// If specified, the pod's tolerations.
+	// +optional
+	Tolerations      []corev1.Toleration `json:"tolerations,omitempty"`
+	RuntimeClassName *string             `json:"runtimeClassName,omitempty"`
+	// TargetQueueDepth is the target average number of the requests waiting in the queue of each replica, the HPA
+	// scales on it when the autoscaling range is specified
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default:=5
+	TargetQueueDepth *int32 `json:"targetQueueDepth,omitempty"`
+	// Volume is the model cache volume mounted to the Hugging Face cache directory
+	Volume *Volume `json:"volume,omitempty"`
 }
 
 type HFSecretRef struct {
@@ -92,6 +135,13 @@ type ModelTemplateVersionRef struct {
 
 type MLServiceStatus struct {
 	// Conditions is an array of current conditions
-	Conditions         []v1.Condition           `json:"conditions,omitempty"`
+	Conditions []v1.Condition `json:"conditions,omitempty"`
+	// Backend is the backend serving the model, the workloads of the previous backend are removed once the backend
+	// is switched
+	Backend MLServiceBackendType `json:"backend,omitempty"`
+	// ServiceStatus is the serving status reported by the backend, e.g., Running
+	ServiceStatus string `json:"serviceStatus,omitempty"`
+	// Endpoint is the in-cluster OpenAI compatible endpoint of the service
+	Endpoint           string                   `json:"endpoint,omitempty"`
 	RayServiceStatuses rayv1.RayServiceStatuses `json:"rayServiceStatuses,omitempty"`
 }
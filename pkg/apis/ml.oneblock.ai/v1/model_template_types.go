@@ -169,8 +169,52 @@ type DeploymentConfig struct {
 	// +kubebuilder:validation:Required
 	MaxConcurrentQueries int32 `json:"maxConcurrentQueries"`
 	// Auto scale up/down the number of replicas if the average number of ongoing requests is above/below this value.
-	// Automatically set this to 40% of the maxConcurrentQueries if not specified.
+	// Automatically set this to targetOngoingRequestsRatio of the maxConcurrentQueries if not specified.
 	TargetNumOngoingRequests int32 `json:"targetNumOngoingRequests,omitempty"`
+	// The percentage of the maxConcurrentQueries used as the targetNumOngoingRequests if it is not specified.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default:=40
+	TargetOngoingRequestsRatio int32 `json:"targetOngoingRequestsRatio,omitempty"`
+	// How often to scrape for metrics in seconds, defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	MetricsIntervalS *int32 `json:"metricsIntervalS,omitempty"`
+	// Time window to average over for metrics in seconds, defaults to 30.
+	// +kubebuilder:validation:Minimum=1
+	LookBackPeriodS *int32 `json:"lookBackPeriodS,omitempty"`
+	// Multiplicative factor to speed up or slow down each autoscaling step, e.g., 0.6, defaults to 0.6.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	SmoothingFactor string `json:"smoothingFactor,omitempty"`
+	// How long to wait before scaling down replicas in seconds, defaults to 300.
+	// +kubebuilder:validation:Minimum=0
+	DownscaleDelayS *int32 `json:"downscaleDelayS,omitempty"`
+	// How long to wait before scaling up replicas in seconds, defaults to 60.
+	// +kubebuilder:validation:Minimum=0
+	UpscaleDelayS *int32 `json:"upscaleDelayS,omitempty"`
+	// ScaleToZero allows the model to scale down to zero replicas when there is no traffic,
+	// it is only supported by the RayService backend.
+	ScaleToZero *ScaleToZeroConfig `json:"scaleToZero,omitempty"`
+	// Schedules raise the minReplicas of the model during the time windows, e.g., business hours.
+	Schedules []ReplicaSchedule `json:"schedules,omitempty"`
+}
+
+type ScaleToZeroConfig struct {
+	Enabled bool `json:"enabled"`
+	// How long a replica may take to cold start, i.e., load the model and pass its health check, before it is
+	// restarted in seconds.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default:=300
+	ColdStartTimeoutS int32 `json:"coldStartTimeoutS,omitempty"`
+}
+
+// ReplicaSchedule sets the minReplicas of the model while its window is open, e.g., start "0 9 * * 1-5" and
+// stop "0 18 * * 1-5" for the business hours of the weekdays.
+type ReplicaSchedule struct {
+	Name           string `json:"name,omitempty"`
+	ScheduleWindow `json:",inline"`
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=0
+	MinReplicas int32 `json:"minReplicas"`
 }
 
 type ModelTemplateVersionStatus struct {
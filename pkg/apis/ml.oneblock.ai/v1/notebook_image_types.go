@@ -0,0 +1,46 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type NotebookType string
+
+const (
+	NotebookTypeJupyter    NotebookType = "jupyter"
+	NotebookTypeCodeServer NotebookType = "code-server"
+	NotebookTypeRStudio    NotebookType = "rstudio"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName=nbimage;nbimages,scope=Cluster
+// +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=`.spec.type`
+// +kubebuilder:printcolumn:name="IMAGE",type="string",JSONPath=`.spec.image`
+// +kubebuilder:printcolumn:name="DEFAULT",type="boolean",JSONPath=`.spec.default`
+// +kubebuilder:printcolumn:name="GPU",type="boolean",JSONPath=`.spec.gpuSupported`
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// NotebookImage is an approved container image of the notebooks
+type NotebookImage struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NotebookImageSpec `json:"spec"`
+}
+
+type NotebookImageSpec struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=jupyter;code-server;rstudio
+	Type NotebookType `json:"type"`
+	// +kubebuilder:validation:Required
+	Image       string `json:"image"`
+	Description string `json:"description,omitempty"`
+	// GPUSupported indicates the image has the CUDA libraries for the GPU notebooks
+	GPUSupported bool `json:"gpuSupported,omitempty"`
+	// Default is the default image of the notebook type
+	Default bool `json:"default,omitempty"`
+	// AllowedNamespaces are the namespaces allowed to use the image, the image is allowed in all namespaces if it is empty
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+}
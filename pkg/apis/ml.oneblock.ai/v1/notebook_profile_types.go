@@ -0,0 +1,53 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName=nbprofile;nbprofiles,scope=Cluster
+// +kubebuilder:printcolumn:name="CPU",type="string",JSONPath=`.spec.cpu`
+// +kubebuilder:printcolumn:name="MEMORY",type="string",JSONPath=`.spec.memory`
+// +kubebuilder:printcolumn:name="GPU_TYPE",type="string",JSONPath=`.spec.gpu.type`
+// +kubebuilder:printcolumn:name="GPU_COUNT",type="integer",JSONPath=`.spec.gpu.count`
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// NotebookProfile is a resource and accelerator preset of the notebooks, it is expanded into the notebook
+// pod template by the notebook webhook
+type NotebookProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NotebookProfileSpec `json:"spec"`
+}
+
+type NotebookProfileSpec struct {
+	Description string `json:"description,omitempty"`
+	// CPU is the requests and limits of the notebook container cpu
+	CPU *resource.Quantity `json:"cpu,omitempty"`
+	// Memory is the requests and limits of the notebook container memory
+	Memory *resource.Quantity `json:"memory,omitempty"`
+	// GPU is the accelerator of the notebook container
+	GPU *NotebookProfileGPU `json:"gpu,omitempty"`
+
+	Tolerations      []corev1.Toleration `json:"tolerations,omitempty"`
+	NodeSelector     map[string]string   `json:"nodeSelector,omitempty"`
+	RuntimeClassName *string             `json:"runtimeClassName,omitempty"`
+	// Volumes are added to the notebook pod, each of them is mounted to the notebook container by the VolumeMounts
+	Volumes      []corev1.Volume      `json:"volumes,omitempty"`
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+	Env          []corev1.EnvVar      `json:"env,omitempty"`
+}
+
+type NotebookProfileGPU struct {
+	// Type is the accelerator type, e.g., T4, A100, it is mapped to the resource name and the node selector
+	// +kubebuilder:validation:Required
+	Type string `json:"type"`
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	Count int32 `json:"count"`
+}
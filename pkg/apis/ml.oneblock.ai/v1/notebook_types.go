@@ -11,6 +11,8 @@ import (
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:shortName=nb,scope=Namespaced
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="REASON",type="string",JSONPath=`.status.reason`
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=`.metadata.creationTimestamp`
 
 // Notebook is the Schema for the notebooks API
@@ -28,12 +30,52 @@ type NotebookSpec struct {
 	Template    NotebookTemplateSpec `json:"template,omitempty"`
 	ServiceType corev1.ServiceType   `json:"serviceType,omitempty"`
 	Volumes     []Volume             `json:"volumes,omitempty"`
+	// ProfileRef is the name of the NotebookProfile expanded into the template
+	ProfileRef string `json:"profileRef,omitempty"`
+	// RayClusterRef is the RayCluster connected by the notebook through the Ray client, e.g., ray.init()
+	RayClusterRef *RayClusterReference `json:"rayClusterRef,omitempty"`
+	// Git is the repository cloned into the notebook workspace before the notebook starts
+	Git *NotebookGitSource `json:"git,omitempty"`
+	// Schedule starts the notebook when the window opens and stops it when the window closes, e.g., running on
+	// the working hours of the weekdays. The notebook is only toggled at the scheduled times, it can still be
+	// started or stopped manually in between.
+	Schedule *ScheduleWindow `json:"schedule,omitempty"`
+}
+
+type NotebookGitSource struct {
+	// Repository is the URL of the git repository, e.g., https://github.com/oneblock-ai/oneblock.git
+	// +kubebuilder:validation:Required
+	Repository string `json:"repository"`
+	// Ref is the branch, tag or commit to check out, default to the default branch of the repository
+	Ref string `json:"ref,omitempty"`
+	// TargetDir is the directory relative to the working dir of the notebook, default to the repository name
+	TargetDir string `json:"targetDir,omitempty"`
+	// CredentialSecretRef is the secret of the git credentials in the notebook namespace, it is either a
+	// kubernetes.io/basic-auth secret of the username and password or a kubernetes.io/ssh-auth secret of the ssh-privatekey
+	CredentialSecretRef *corev1.LocalObjectReference `json:"credentialSecretRef,omitempty"`
+}
+
+type RayClusterReference struct {
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// Namespace of the RayCluster, default to the notebook namespace
+	Namespace string `json:"namespace,omitempty"`
 }
 
 type NotebookTemplateSpec struct {
 	Spec corev1.PodSpec `json:"spec,omitempty"`
 }
 
+type NotebookPhase string
+
+const (
+	NotebookPhasePending NotebookPhase = "Pending"
+	NotebookPhasePulling NotebookPhase = "Pulling"
+	NotebookPhaseRunning NotebookPhase = "Running"
+	NotebookPhaseStopped NotebookPhase = "Stopped"
+	NotebookPhaseFailed  NotebookPhase = "Failed"
+)
+
 // NotebookStatus defines the observed state of Dataset
 type NotebookStatus struct {
 	// Conditions is an array of current conditions
@@ -42,4 +84,48 @@ type NotebookStatus struct {
 	ReadyReplicas int32 `json:"readyReplicas"`
 	// ContainerState is the state of underlying container.
 	State corev1.ContainerState `json:"state"`
+	// Phase is the serving phase of the notebook, the notebook is Stopped if it has the stopped annotation.
+	Phase NotebookPhase `json:"phase,omitempty"`
+	// Reason is a brief CamelCase reason of the phase, e.g., ImagePullBackOff or OOMKilled of the Failed phase
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message of the phase
+	Message string `json:"message,omitempty"`
+	// LastActivity is the last time the notebook server reported user activity, it is used by the idle culler.
+	LastActivity *metav1.Time `json:"lastActivity,omitempty"`
+	// RayCluster is the connection status of the referenced RayCluster
+	RayCluster *NotebookRayClusterStatus `json:"rayCluster,omitempty"`
+	// Git is the repository checked out in the notebook workspace
+	Git *NotebookGitStatus `json:"git,omitempty"`
+	// Schedule is the status of the start and stop schedules
+	Schedule *NotebookScheduleStatus `json:"schedule,omitempty"`
+}
+
+type NotebookScheduleStatus struct {
+	// LastScheduleTime is the last scheduled time the notebook was started or stopped at
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// NextStartTime is the next scheduled time to start the notebook
+	NextStartTime *metav1.Time `json:"nextStartTime,omitempty"`
+	// NextStopTime is the next scheduled time to stop the notebook
+	NextStopTime *metav1.Time `json:"nextStopTime,omitempty"`
+}
+
+type NotebookGitStatus struct {
+	Repository string `json:"repository,omitempty"`
+	Ref        string `json:"ref,omitempty"`
+	// Commit is the checked-out commit of the repository when the notebook started
+	Commit string `json:"commit,omitempty"`
+}
+
+type NotebookRayClusterStatus struct {
+	// Address is the Ray client address injected to the RAY_ADDRESS env of the notebook
+	Address string `json:"address,omitempty"`
+	// Reachable indicates the cluster is ready and its head service is assigned, as reported by the RayCluster status
+	Reachable bool `json:"reachable"`
+	// RayVersion is the Ray version of the cluster head image
+	RayVersion string `json:"rayVersion,omitempty"`
+	// ExpectedRayVersion is the Ray version of the default-ray-cluster-image setting
+	ExpectedRayVersion string `json:"expectedRayVersion,omitempty"`
+	// VersionMatched indicates the Ray version of the cluster matches the expected one
+	VersionMatched bool   `json:"versionMatched"`
+	Message        string `json:"message,omitempty"`
 }
@@ -0,0 +1,90 @@
+package v1
+
+import (
+	"github.com/rancher/wrangler/v2/pkg/condition"
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/oneblock-ai/oneblock/pkg/apis/management.oneblock.ai/v1"
+)
+
+var (
+	ServeApplicationCreated  condition.Cond = "created"
+	ServeApplicationReady    condition.Cond = "ready"
+	ServeApplicationPending  condition.Cond = "pending"
+	ServeApplicationConflict condition.Cond = "conflict"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=serveapp;serveapps,scope=Namespaced
+// +kubebuilder:printcolumn:name="IMPORT_PATH",type=string,JSONPath=`.spec.importPath`
+// +kubebuilder:printcolumn:name="ServiceStatus",type=string,JSONPath=".status.rayServiceStatuses.serviceStatus"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// ServeApplication is the Schema for the generic Ray Serve application, it is compiled into a RayService
+type ServeApplication struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ServeApplicationSpec   `json:"spec,omitempty"`
+	Status ServeApplicationStatus `json:"status,omitempty"`
+}
+
+type ServeApplicationSpec struct {
+	// ImportPath is the path to the Ray Serve application or builder, e.g. `app.main:app`
+	// +kubebuilder:validation:Required
+	ImportPath string `json:"importPath"`
+	// RoutePrefix is the HTTP route prefix of the application
+	// +kubebuilder:default:=/
+	RoutePrefix string `json:"routePrefix,omitempty"`
+	// RuntimeEnv is the runtime environment of the application
+	// +optional
+	RuntimeEnv *ServeRuntimeEnv `json:"runtimeEnv,omitempty"`
+	// Args are the arguments passed to the application builder
+	// +optional
+	Args *runtime.RawExtension `json:"args,omitempty"`
+	// Deployments overrides the options of the deployments in the application
+	// +optional
+	Deployments []ServeDeployment `json:"deployments,omitempty"`
+	// optional
+	HFSecretRef *HFSecretRef `json:"hfSecretRef,omitempty"`
+	// +kubebuilder:validation:Required
+	MLClusterRef *MLClusterRef `json:"mlClusterRef"`
+}
+
+type ServeRuntimeEnv struct {
+	// WorkingDir is the local directory or remote URI(e.g., S3 or a zip archive) of the application code
+	WorkingDir string `json:"workingDir,omitempty"`
+	// Pip is the list of pip packages to install
+	Pip     []string          `json:"pip,omitempty"`
+	EnvVars map[string]string `json:"envVars,omitempty"`
+}
+
+type ServeDeployment struct {
+	// +kubebuilder:validation:Required
+	Name                 string `json:"name"`
+	NumReplicas          *int32 `json:"numReplicas,omitempty"`
+	MaxConcurrentQueries *int32 `json:"maxConcurrentQueries,omitempty"`
+	// UserConfig is passed to the reconfigure method of the deployment
+	// +optional
+	UserConfig      *runtime.RawExtension `json:"userConfig,omitempty"`
+	RayActorOptions *ServeRayActorOptions `json:"rayActorOptions,omitempty"`
+}
+
+type ServeRayActorOptions struct {
+	NumCPUs *resource.Quantity `json:"numCpus,omitempty"`
+	NumGPUs *resource.Quantity `json:"numGpus,omitempty"`
+	Memory  *resource.Quantity `json:"memory,omitempty"`
+	// Resources are the custom resources required by each replica, e.g. `accelerator_type:T4: 1`
+	Resources map[string]resource.Quantity `json:"resources,omitempty"`
+}
+
+type ServeApplicationStatus struct {
+	// Conditions is an array of current conditions
+	Conditions         []v1.Condition           `json:"conditions,omitempty"`
+	RayServiceStatuses rayv1.RayServiceStatuses `json:"rayServiceStatuses,omitempty"`
+}
@@ -24,6 +24,7 @@ package v1
 import (
 	managementoneblockaiv1 "github.com/oneblock-ai/oneblock/pkg/apis/management.oneblock.ai/v1"
 	corev1 "k8s.io/api/core/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -123,6 +124,36 @@ func (in *DatasetStatus) DeepCopy() *DatasetStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeploymentConfig) DeepCopyInto(out *DeploymentConfig) {
 	*out = *in
+	if in.MetricsIntervalS != nil {
+		in, out := &in.MetricsIntervalS, &out.MetricsIntervalS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LookBackPeriodS != nil {
+		in, out := &in.LookBackPeriodS, &out.LookBackPeriodS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DownscaleDelayS != nil {
+		in, out := &in.DownscaleDelayS, &out.DownscaleDelayS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.UpscaleDelayS != nil {
+		in, out := &in.UpscaleDelayS, &out.UpscaleDelayS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScaleToZero != nil {
+		in, out := &in.ScaleToZero, &out.ScaleToZero
+		*out = new(ScaleToZeroConfig)
+		**out = **in
+	}
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]ReplicaSchedule, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -287,6 +318,11 @@ func (in *MLServiceSpec) DeepCopyInto(out *MLServiceSpec) {
 		*out = new(MLClusterRef)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.VLLMConfig != nil {
+		in, out := &in.VLLMConfig, &out.VLLMConfig
+		*out = new(VLLMConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -517,7 +553,7 @@ func (in *ModelTemplateVersionRef) DeepCopy() *ModelTemplateVersionRef {
 func (in *ModelTemplateVersionSpec) DeepCopyInto(out *ModelTemplateVersionSpec) {
 	*out = *in
 	in.EngineConfig.DeepCopyInto(&out.EngineConfig)
-	out.DeploymentConfig = in.DeploymentConfig
+	in.DeploymentConfig.DeepCopyInto(&out.DeploymentConfig)
 	in.ScalingConfig.DeepCopyInto(&out.ScalingConfig)
 	return
 }
@@ -581,6 +617,124 @@ func (in *Notebook) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotebookGitSource) DeepCopyInto(out *NotebookGitSource) {
+	*out = *in
+	if in.CredentialSecretRef != nil {
+		in, out := &in.CredentialSecretRef, &out.CredentialSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotebookGitSource.
+func (in *NotebookGitSource) DeepCopy() *NotebookGitSource {
+	if in == nil {
+		return nil
+	}
+	out := new(NotebookGitSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotebookGitStatus) DeepCopyInto(out *NotebookGitStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotebookGitStatus.
+func (in *NotebookGitStatus) DeepCopy() *NotebookGitStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NotebookGitStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotebookImage) DeepCopyInto(out *NotebookImage) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotebookImage.
+func (in *NotebookImage) DeepCopy() *NotebookImage {
+	if in == nil {
+		return nil
+	}
+	out := new(NotebookImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NotebookImage) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotebookImageList) DeepCopyInto(out *NotebookImageList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NotebookImage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotebookImageList.
+func (in *NotebookImageList) DeepCopy() *NotebookImageList {
+	if in == nil {
+		return nil
+	}
+	out := new(NotebookImageList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NotebookImageList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotebookImageSpec) DeepCopyInto(out *NotebookImageSpec) {
+	*out = *in
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotebookImageSpec.
+func (in *NotebookImageSpec) DeepCopy() *NotebookImageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NotebookImageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NotebookList) DeepCopyInto(out *NotebookList) {
 	*out = *in
@@ -614,6 +768,197 @@ func (in *NotebookList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotebookProfile) DeepCopyInto(out *NotebookProfile) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotebookProfile.
+func (in *NotebookProfile) DeepCopy() *NotebookProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(NotebookProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NotebookProfile) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotebookProfileGPU) DeepCopyInto(out *NotebookProfileGPU) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotebookProfileGPU.
+func (in *NotebookProfileGPU) DeepCopy() *NotebookProfileGPU {
+	if in == nil {
+		return nil
+	}
+	out := new(NotebookProfileGPU)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotebookProfileList) DeepCopyInto(out *NotebookProfileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NotebookProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotebookProfileList.
+func (in *NotebookProfileList) DeepCopy() *NotebookProfileList {
+	if in == nil {
+		return nil
+	}
+	out := new(NotebookProfileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NotebookProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotebookProfileSpec) DeepCopyInto(out *NotebookProfileSpec) {
+	*out = *in
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.GPU != nil {
+		in, out := &in.GPU, &out.GPU
+		*out = new(NotebookProfileGPU)
+		**out = **in
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]corev1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]corev1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotebookProfileSpec.
+func (in *NotebookProfileSpec) DeepCopy() *NotebookProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NotebookProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotebookRayClusterStatus) DeepCopyInto(out *NotebookRayClusterStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotebookRayClusterStatus.
+func (in *NotebookRayClusterStatus) DeepCopy() *NotebookRayClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NotebookRayClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotebookScheduleStatus) DeepCopyInto(out *NotebookScheduleStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextStartTime != nil {
+		in, out := &in.NextStartTime, &out.NextStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextStopTime != nil {
+		in, out := &in.NextStopTime, &out.NextStopTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotebookScheduleStatus.
+func (in *NotebookScheduleStatus) DeepCopy() *NotebookScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NotebookScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NotebookSpec) DeepCopyInto(out *NotebookSpec) {
 	*out = *in
@@ -625,6 +970,21 @@ func (in *NotebookSpec) DeepCopyInto(out *NotebookSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RayClusterRef != nil {
+		in, out := &in.RayClusterRef, &out.RayClusterRef
+		*out = new(RayClusterReference)
+		**out = **in
+	}
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(NotebookGitSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(ScheduleWindow)
+		**out = **in
+	}
 	return
 }
 
@@ -647,6 +1007,25 @@ func (in *NotebookStatus) DeepCopyInto(out *NotebookStatus) {
 		copy(*out, *in)
 	}
 	in.State.DeepCopyInto(&out.State)
+	if in.LastActivity != nil {
+		in, out := &in.LastActivity, &out.LastActivity
+		*out = (*in).DeepCopy()
+	}
+	if in.RayCluster != nil {
+		in, out := &in.RayCluster, &out.RayCluster
+		*out = new(NotebookRayClusterStatus)
+		**out = **in
+	}
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(NotebookGitStatus)
+		**out = **in
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(NotebookScheduleStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -693,6 +1072,22 @@ func (in *PromptFormat) DeepCopy() *PromptFormat {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayClusterReference) DeepCopyInto(out *RayClusterReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayClusterReference.
+func (in *RayClusterReference) DeepCopy() *RayClusterReference {
+	if in == nil {
+		return nil
+	}
+	out := new(RayClusterReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RayClusterSpec) DeepCopyInto(out *RayClusterSpec) {
 	*out = *in
@@ -716,6 +1111,39 @@ func (in *RayClusterSpec) DeepCopy() *RayClusterSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaSchedule) DeepCopyInto(out *ReplicaSchedule) {
+	*out = *in
+	out.ScheduleWindow = in.ScheduleWindow
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaSchedule.
+func (in *ReplicaSchedule) DeepCopy() *ReplicaSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleToZeroConfig) DeepCopyInto(out *ScaleToZeroConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleToZeroConfig.
+func (in *ScaleToZeroConfig) DeepCopy() *ScaleToZeroConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleToZeroConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScalingConfig) DeepCopyInto(out *ScalingConfig) {
 	*out = *in
@@ -739,6 +1167,305 @@ func (in *ScalingConfig) DeepCopy() *ScalingConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleWindow) DeepCopyInto(out *ScheduleWindow) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleWindow.
+func (in *ScheduleWindow) DeepCopy() *ScheduleWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServeApplication) DeepCopyInto(out *ServeApplication) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServeApplication.
+func (in *ServeApplication) DeepCopy() *ServeApplication {
+	if in == nil {
+		return nil
+	}
+	out := new(ServeApplication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServeApplication) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServeApplicationList) DeepCopyInto(out *ServeApplicationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ServeApplication, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServeApplicationList.
+func (in *ServeApplicationList) DeepCopy() *ServeApplicationList {
+	if in == nil {
+		return nil
+	}
+	out := new(ServeApplicationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServeApplicationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServeApplicationSpec) DeepCopyInto(out *ServeApplicationSpec) {
+	*out = *in
+	if in.RuntimeEnv != nil {
+		in, out := &in.RuntimeEnv, &out.RuntimeEnv
+		*out = new(ServeRuntimeEnv)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Deployments != nil {
+		in, out := &in.Deployments, &out.Deployments
+		*out = make([]ServeDeployment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HFSecretRef != nil {
+		in, out := &in.HFSecretRef, &out.HFSecretRef
+		*out = new(HFSecretRef)
+		**out = **in
+	}
+	if in.MLClusterRef != nil {
+		in, out := &in.MLClusterRef, &out.MLClusterRef
+		*out = new(MLClusterRef)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServeApplicationSpec.
+func (in *ServeApplicationSpec) DeepCopy() *ServeApplicationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServeApplicationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServeApplicationStatus) DeepCopyInto(out *ServeApplicationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]managementoneblockaiv1.Condition, len(*in))
+		copy(*out, *in)
+	}
+	in.RayServiceStatuses.DeepCopyInto(&out.RayServiceStatuses)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServeApplicationStatus.
+func (in *ServeApplicationStatus) DeepCopy() *ServeApplicationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ServeApplicationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServeDeployment) DeepCopyInto(out *ServeDeployment) {
+	*out = *in
+	if in.NumReplicas != nil {
+		in, out := &in.NumReplicas, &out.NumReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxConcurrentQueries != nil {
+		in, out := &in.MaxConcurrentQueries, &out.MaxConcurrentQueries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.UserConfig != nil {
+		in, out := &in.UserConfig, &out.UserConfig
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RayActorOptions != nil {
+		in, out := &in.RayActorOptions, &out.RayActorOptions
+		*out = new(ServeRayActorOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServeDeployment.
+func (in *ServeDeployment) DeepCopy() *ServeDeployment {
+	if in == nil {
+		return nil
+	}
+	out := new(ServeDeployment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServeRayActorOptions) DeepCopyInto(out *ServeRayActorOptions) {
+	*out = *in
+	if in.NumCPUs != nil {
+		in, out := &in.NumCPUs, &out.NumCPUs
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.NumGPUs != nil {
+		in, out := &in.NumGPUs, &out.NumGPUs
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]resource.Quantity, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServeRayActorOptions.
+func (in *ServeRayActorOptions) DeepCopy() *ServeRayActorOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ServeRayActorOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServeRuntimeEnv) DeepCopyInto(out *ServeRuntimeEnv) {
+	*out = *in
+	if in.Pip != nil {
+		in, out := &in.Pip, &out.Pip
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnvVars != nil {
+		in, out := &in.EnvVars, &out.EnvVars
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServeRuntimeEnv.
+func (in *ServeRuntimeEnv) DeepCopy() *ServeRuntimeEnv {
+	if in == nil {
+		return nil
+	}
+	out := new(ServeRuntimeEnv)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VLLMConfig) DeepCopyInto(out *VLLMConfig) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.TargetQueueDepth != nil {
+		in, out := &in.TargetQueueDepth, &out.TargetQueueDepth
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Volume != nil {
+		in, out := &in.Volume, &out.Volume
+		*out = new(Volume)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VLLMConfig.
+func (in *VLLMConfig) DeepCopy() *VLLMConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VLLMConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Volume) DeepCopyInto(out *Volume) {
 	*out = *in
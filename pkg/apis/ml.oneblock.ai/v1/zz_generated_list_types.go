@@ -108,3 +108,54 @@ func NewNotebook(namespace, name string, obj Notebook) *Notebook {
 	obj.Namespace = namespace
 	return &obj
 }
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NotebookImageList is a list of NotebookImage resources
+type NotebookImageList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []NotebookImage `json:"items"`
+}
+
+func NewNotebookImage(namespace, name string, obj NotebookImage) *NotebookImage {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("NotebookImage").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NotebookProfileList is a list of NotebookProfile resources
+type NotebookProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []NotebookProfile `json:"items"`
+}
+
+func NewNotebookProfile(namespace, name string, obj NotebookProfile) *NotebookProfile {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("NotebookProfile").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ServeApplicationList is a list of ServeApplication resources
+type ServeApplicationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ServeApplication `json:"items"`
+}
+
+func NewServeApplication(namespace, name string, obj ServeApplication) *ServeApplication {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("ServeApplication").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
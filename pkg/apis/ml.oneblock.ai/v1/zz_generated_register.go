@@ -33,6 +33,9 @@ var (
 	ModelTemplateResourceName        = "modeltemplates"
 	ModelTemplateVersionResourceName = "modeltemplateversions"
 	NotebookResourceName             = "notebooks"
+	NotebookImageResourceName        = "notebookimages"
+	NotebookProfileResourceName      = "notebookprofiles"
+	ServeApplicationResourceName     = "serveapplications"
 )
 
 // SchemeGroupVersion is group version used to register these objects
@@ -66,6 +69,12 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ModelTemplateVersionList{},
 		&Notebook{},
 		&NotebookList{},
+		&NotebookImage{},
+		&NotebookImageList{},
+		&NotebookProfile{},
+		&NotebookProfileList{},
+		&ServeApplication{},
+		&ServeApplicationList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
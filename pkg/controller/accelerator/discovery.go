@@ -0,0 +1,70 @@
+package accelerator
+
+import (
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+)
+
+const (
+	// the Intel GPU plugin labels the node with gpu.intel.com/platform_<platform>.present, e.g., platform_gpu_max_1550
+	intelGPUPlatformPrefix  = "platform_"
+	intelGPUPlatformPresent = ".present"
+)
+
+// nodeAccelerator is the accelerator of a node discovered from the labels of the device plugins
+type nodeAccelerator struct {
+	acceleratorType string
+	product         string
+	resourceName    corev1.ResourceName
+}
+
+// discoverAccelerator returns the accelerator of the node by the labels of the NVIDIA GPU feature discovery, the
+// Intel GPU plugin and the AMD GPU node labeller, it returns nil if the product is unknown
+func discoverAccelerator(node *corev1.Node) *nodeAccelerator {
+	if product := node.Labels[constant.LabelNvidiaGPUProduct]; product != "" {
+		return matchAccelerator(product, utils.ResourceNvidiaGPU)
+	}
+
+	if product := node.Labels[constant.LabelAmdGPUProductName]; product != "" {
+		return matchAccelerator(product, utils.ResourceAmdGPU)
+	}
+
+	keys := make([]string, 0)
+	for key := range node.Labels {
+		if strings.HasPrefix(key, constant.LabelIntelGPUPrefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		platform := strings.TrimPrefix(key, constant.LabelIntelGPUPrefix)
+		if !strings.HasPrefix(platform, intelGPUPlatformPrefix) || !strings.HasSuffix(platform, intelGPUPlatformPresent) ||
+			node.Labels[key] != "true" {
+			continue
+		}
+		product := strings.TrimSuffix(strings.TrimPrefix(platform, intelGPUPlatformPrefix), intelGPUPlatformPresent)
+		if accelerator := matchAccelerator(product, utils.ResourceIntelGPU); accelerator != nil {
+			return accelerator
+		}
+	}
+	return nil
+}
+
+// matchAccelerator matches the product name with the accelerator type registry, the matched type must be provided by
+// the resource of the device plugin
+func matchAccelerator(product string, resourceName corev1.ResourceName) *nodeAccelerator {
+	acceleratorType := utils.GetAcceleratorTypeByProductName(product)
+	if acceleratorType == "" || utils.GetAcceleratorResourceName(acceleratorType) != resourceName {
+		return nil
+	}
+	return &nodeAccelerator{
+		acceleratorType: acceleratorType,
+		product:         product,
+		resourceName:    resourceName,
+	}
+}
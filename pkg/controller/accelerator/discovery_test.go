@@ -0,0 +1,202 @@
+package accelerator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+)
+
+func newNode(name string, labels map[string]string, resourceName corev1.ResourceName, capacity, allocatable int64) *corev1.Node {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	if resourceName != "" {
+		node.Status.Capacity = corev1.ResourceList{resourceName: *resource.NewQuantity(capacity, resource.DecimalSI)}
+		node.Status.Allocatable = corev1.ResourceList{resourceName: *resource.NewQuantity(allocatable, resource.DecimalSI)}
+	}
+	return node
+}
+
+func Test_discoverAccelerator(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		labels   map[string]string
+		expected *nodeAccelerator
+	}{
+		{
+			name:   "nvidia tesla",
+			labels: map[string]string{constant.LabelNvidiaGPUProduct: "Tesla-V100-SXM2-16GB"},
+			expected: &nodeAccelerator{
+				acceleratorType: utils.NvidiaTeslaV100,
+				product:         "Tesla-V100-SXM2-16GB",
+				resourceName:    utils.ResourceNvidiaGPU,
+			},
+		},
+		{
+			name:   "the type with the most words wins",
+			labels: map[string]string{constant.LabelNvidiaGPUProduct: "NVIDIA-A100-80G-PCIe"},
+			expected: &nodeAccelerator{
+				acceleratorType: utils.NvidiaA10080g,
+				product:         "NVIDIA-A100-80G-PCIe",
+				resourceName:    utils.ResourceNvidiaGPU,
+			},
+		},
+		{
+			name:   "the specific variant wins the generic type",
+			labels: map[string]string{constant.LabelNvidiaGPUProduct: "NVIDIA-A100-SXM4-80GB"},
+			expected: &nodeAccelerator{
+				acceleratorType: utils.NvidiaA10080g,
+				product:         "NVIDIA-A100-SXM4-80GB",
+				resourceName:    utils.ResourceNvidiaGPU,
+			},
+		},
+		{
+			name:     "P4 doesn't match the substring of P40",
+			labels:   map[string]string{constant.LabelNvidiaGPUProduct: "Tesla-P40"},
+			expected: nil,
+		},
+		{
+			name:     "L4 doesn't match L40S",
+			labels:   map[string]string{constant.LabelNvidiaGPUProduct: "NVIDIA-L40S"},
+			expected: nil,
+		},
+		{
+			name: "intel gpu platform",
+			labels: map[string]string{
+				"gpu.intel.com/device-id.0380-0bd5.present":   "true",
+				"gpu.intel.com/platform_gpu_max_1550.count":   "8",
+				"gpu.intel.com/platform_gpu_max_1550.present": "true",
+			},
+			expected: &nodeAccelerator{
+				acceleratorType: utils.IntelMax1550,
+				product:         "gpu_max_1550",
+				resourceName:    utils.ResourceIntelGPU,
+			},
+		},
+		{
+			name:   "amd instinct",
+			labels: map[string]string{constant.LabelAmdGPUProductName: "AMD_Instinct_MI210"},
+			expected: &nodeAccelerator{
+				acceleratorType: utils.AmdInstinctMi210,
+				product:         "AMD_Instinct_MI210",
+				resourceName:    utils.ResourceAmdGPU,
+			},
+		},
+		{
+			name:     "node without accelerators",
+			labels:   map[string]string{"kubernetes.io/os": "linux"},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, discoverAccelerator(newNode("node1", tc.labels, "", 0, 0)))
+		})
+	}
+}
+
+func Test_getInventoryStatus(t *testing.T) {
+	labels := map[string]string{constant.LabelNvidiaGPUProduct: "Tesla-T4", constant.LabelAcceleratorType: utils.NvidiaTeslaT4}
+	nodes := []*corev1.Node{
+		newNode("node2", labels, utils.ResourceNvidiaGPU, 4, 3),
+		newNode("node1", labels, utils.ResourceNvidiaGPU, 2, 2),
+		// the node labeled by the users
+		newNode("node3", map[string]string{constant.LabelAcceleratorType: utils.NvidiaTeslaT4}, "", 0, 0),
+	}
+
+	status := getInventoryStatus(utils.NvidiaTeslaT4, nodes)
+	assert.Equal(t, utils.NvidiaTeslaT4, status.Type)
+	assert.Equal(t, utils.ResourceNvidiaGPU, status.ResourceName)
+	assert.Equal(t, int64(6), status.Count)
+	assert.Equal(t, int64(5), status.Allocatable)
+	if assert.Len(t, status.Nodes, 3) {
+		assert.Equal(t, "node1", status.Nodes[0].Name)
+		assert.Equal(t, "Tesla-T4", status.Nodes[0].Product)
+		assert.Equal(t, int64(4), status.Nodes[1].Count)
+		assert.Equal(t, int64(3), status.Nodes[1].Allocatable)
+		assert.Empty(t, status.Nodes[2].Product)
+	}
+}
+
+func Test_syncAcceleratorTypeLabel(t *testing.T) {
+	var testCases = []struct {
+		name               string
+		labels             map[string]string
+		annotations        map[string]string
+		expectedType       string
+		expectedUnchanged  bool
+		expectedNotLabeled bool
+	}{
+		{
+			name:         "label the discovered type",
+			labels:       map[string]string{constant.LabelNvidiaGPUProduct: "Tesla-T4"},
+			expectedType: utils.NvidiaTeslaT4,
+		},
+		{
+			name:              "keep the discovered type",
+			labels:            map[string]string{constant.LabelNvidiaGPUProduct: "Tesla-T4", constant.LabelAcceleratorType: utils.NvidiaTeslaT4},
+			annotations:       map[string]string{constant.AnnotationDiscoveredAcceleratorType: utils.NvidiaTeslaT4},
+			expectedUnchanged: true,
+		},
+		{
+			name:         "re-detect the type once the accelerator is replaced",
+			labels:       map[string]string{constant.LabelNvidiaGPUProduct: "NVIDIA-A10G", constant.LabelAcceleratorType: utils.NvidiaTeslaT4},
+			annotations:  map[string]string{constant.AnnotationDiscoveredAcceleratorType: utils.NvidiaTeslaT4},
+			expectedType: utils.NvidiaTeslaA10g,
+		},
+		{
+			name:               "remove the discovered type once the accelerator is removed",
+			labels:             map[string]string{constant.LabelAcceleratorType: utils.NvidiaTeslaT4},
+			annotations:        map[string]string{constant.AnnotationDiscoveredAcceleratorType: utils.NvidiaTeslaT4},
+			expectedNotLabeled: true,
+		},
+		{
+			name:              "keep the label set by the users",
+			labels:            map[string]string{constant.LabelNvidiaGPUProduct: "NVIDIA-A10G", constant.LabelAcceleratorType: utils.NvidiaTeslaT4},
+			expectedUnchanged: true,
+		},
+		{
+			name:              "keep the label changed by the users",
+			labels:            map[string]string{constant.LabelNvidiaGPUProduct: "NVIDIA-A10G", constant.LabelAcceleratorType: utils.NvidiaTeslaT4},
+			annotations:       map[string]string{constant.AnnotationDiscoveredAcceleratorType: utils.NvidiaTeslaA10g},
+			expectedUnchanged: true,
+		},
+		{
+			name:         "take over the label of the discovered type",
+			labels:       map[string]string{constant.LabelNvidiaGPUProduct: "Tesla-T4", constant.LabelAcceleratorType: utils.NvidiaTeslaT4},
+			expectedType: utils.NvidiaTeslaT4,
+		},
+		{
+			name:              "no accelerator",
+			labels:            map[string]string{},
+			expectedUnchanged: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			node := newNode("node1", tc.labels, "", 0, 0)
+			node.Annotations = tc.annotations
+			nodeCpy := syncAcceleratorTypeLabel(node)
+			if tc.expectedUnchanged {
+				assert.Nil(t, nodeCpy)
+				return
+			}
+			if !assert.NotNil(t, nodeCpy) {
+				return
+			}
+			if tc.expectedNotLabeled {
+				assert.NotContains(t, nodeCpy.Labels, constant.LabelAcceleratorType)
+				assert.NotContains(t, nodeCpy.Annotations, constant.AnnotationDiscoveredAcceleratorType)
+				return
+			}
+			assert.Equal(t, tc.expectedType, nodeCpy.Labels[constant.LabelAcceleratorType])
+			assert.Equal(t, tc.expectedType, nodeCpy.Annotations[constant.AnnotationDiscoveredAcceleratorType])
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package accelerator
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	ctlcorev1 "github.com/rancher/wrangler/v2/pkg/generated/controllers/core/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	mgmtv1 "github.com/oneblock-ai/oneblock/pkg/apis/management.oneblock.ai/v1"
+	ctlmgmtv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/management.oneblock.ai/v1"
+	"github.com/oneblock-ai/oneblock/pkg/server/config"
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+)
+
+const (
+	acceleratorControllerDiscoverNode  = "accelerator.discoverNode"
+	acceleratorControllerSyncInventory = "accelerator.syncInventory"
+)
+
+// handler discovers the accelerators of the nodes and keeps the AcceleratorInventory of each accelerator type,
+// the discovered nodes are labeled with the accelerator type which is selected by the nodeSelector of the workloads.
+// The discovered type is also kept by an annotation to tell it from the label set by the users, the label set by the
+// users is respected while the discovered one follows the accelerators of the node
+type handler struct {
+	nodes          ctlcorev1.NodeClient
+	nodeCache      ctlcorev1.NodeCache
+	inventories    ctlmgmtv1.AcceleratorInventoryController
+	inventoryCache ctlmgmtv1.AcceleratorInventoryCache
+}
+
+func Register(ctx context.Context, mgmt *config.Management) error {
+	nodes := mgmt.CoreFactory.Core().V1().Node()
+	inventories := mgmt.OneBlockMgmtFactory.Management().V1().AcceleratorInventory()
+	h := &handler{
+		nodes:          nodes,
+		nodeCache:      nodes.Cache(),
+		inventories:    inventories,
+		inventoryCache: inventories.Cache(),
+	}
+
+	nodes.OnChange(ctx, acceleratorControllerDiscoverNode, h.OnNodeChanged)
+	inventories.OnChange(ctx, acceleratorControllerSyncInventory, h.OnInventoryChanged)
+	return nil
+}
+
+// OnNodeChanged labels the node with the discovered accelerator type and makes sure the inventory of the type exists,
+// the inventories of the node are enqueued since the accelerator type of the node may be changed or the node is removed
+func (h *handler) OnNodeChanged(name string, node *corev1.Node) (*corev1.Node, error) {
+	if node == nil || node.DeletionTimestamp != nil {
+		return node, h.enqueueInventories(name, "")
+	}
+
+	if nodeCpy := syncAcceleratorTypeLabel(node); nodeCpy != nil {
+		// the inventories are synced once the labeled node is synced to the cache
+		logrus.Infof("Labeling node %s with the discovered accelerator type %q", node.Name,
+			nodeCpy.Labels[constant.LabelAcceleratorType])
+		return h.nodes.Update(nodeCpy)
+	}
+
+	acceleratorType := node.Labels[constant.LabelAcceleratorType]
+	if err := h.enqueueInventories(node.Name, acceleratorType); err != nil {
+		return node, err
+	}
+	if acceleratorType == "" {
+		return node, nil
+	}
+	return node, h.ensureInventory(acceleratorType)
+}
+
+// enqueueInventories enqueues the inventory of the accelerator type and the inventories still listing the node,
+// e.g., the node is removed or its accelerator is replaced
+func (h *handler) enqueueInventories(nodeName, acceleratorType string) error {
+	if acceleratorType != "" {
+		h.inventories.Enqueue(GetInventoryName(acceleratorType))
+	}
+
+	inventories, err := h.inventoryCache.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, inventory := range inventories {
+		if inventory.Status.Type == acceleratorType {
+			continue
+		}
+		if slices.ContainsFunc(inventory.Status.Nodes, func(node mgmtv1.AcceleratorNode) bool {
+			return node.Name == nodeName
+		}) {
+			h.inventories.Enqueue(inventory.Name)
+		}
+	}
+	return nil
+}
+
+// syncAcceleratorTypeLabel returns a copy of the node with the accelerator type label and annotation updated to the
+// discovered accelerator, it returns nil if nothing is changed. The label set by the users is kept, the label equal
+// to the discovered type is taken over, e.g., the one labeled before the annotation is introduced.
+func syncAcceleratorTypeLabel(node *corev1.Node) *corev1.Node {
+	acceleratorType, labeled := node.Labels[constant.LabelAcceleratorType]
+	discoveredType, discovered := node.Annotations[constant.AnnotationDiscoveredAcceleratorType]
+
+	var newType string
+	if accelerator := discoverAccelerator(node); accelerator != nil {
+		newType = accelerator.acceleratorType
+	}
+	if labeled && (!discovered || discoveredType != acceleratorType) && (acceleratorType != newType || newType == "") {
+		return nil
+	}
+	if newType == "" && !labeled && !discovered {
+		return nil
+	}
+	if newType != "" && acceleratorType == newType && discoveredType == newType {
+		return nil
+	}
+
+	nodeCpy := node.DeepCopy()
+	if newType == "" {
+		delete(nodeCpy.Labels, constant.LabelAcceleratorType)
+		delete(nodeCpy.Annotations, constant.AnnotationDiscoveredAcceleratorType)
+		return nodeCpy
+	}
+	if nodeCpy.Labels == nil {
+		nodeCpy.Labels = make(map[string]string, 1)
+	}
+	if nodeCpy.Annotations == nil {
+		nodeCpy.Annotations = make(map[string]string, 1)
+	}
+	nodeCpy.Labels[constant.LabelAcceleratorType] = newType
+	nodeCpy.Annotations[constant.AnnotationDiscoveredAcceleratorType] = newType
+	return nodeCpy
+}
+
+func (h *handler) ensureInventory(acceleratorType string) error {
+	name := GetInventoryName(acceleratorType)
+	if _, err := h.inventoryCache.Get(name); err == nil || !errors.IsNotFound(err) {
+		return err
+	}
+
+	logrus.Infof("Creating the inventory of accelerator type %s", acceleratorType)
+	_, err := h.inventories.Create(&mgmtv1.AcceleratorInventory{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				constant.LabelAcceleratorType: acceleratorType,
+			},
+		},
+	})
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// OnInventoryChanged syncs the status of the inventory from the nodes of the accelerator type, the inventory is
+// removed once none of the nodes is left
+func (h *handler) OnInventoryChanged(_ string, inventory *mgmtv1.AcceleratorInventory) (*mgmtv1.AcceleratorInventory, error) {
+	if inventory == nil || inventory.DeletionTimestamp != nil {
+		return inventory, nil
+	}
+
+	acceleratorType := inventory.Labels[constant.LabelAcceleratorType]
+	nodes, err := h.nodeCache.List(labels.SelectorFromSet(map[string]string{
+		constant.LabelAcceleratorType: acceleratorType,
+	}))
+	if err != nil {
+		return inventory, err
+	}
+
+	if acceleratorType == "" || len(nodes) == 0 {
+		logrus.Infof("Deleting the inventory %s since there are no nodes of the accelerator type", inventory.Name)
+		if err = h.inventories.Delete(inventory.Name, &metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return inventory, err
+		}
+		return nil, nil
+	}
+
+	status := getInventoryStatus(acceleratorType, nodes)
+	if reflect.DeepEqual(status, inventory.Status) {
+		return inventory, nil
+	}
+	inventoryCpy := inventory.DeepCopy()
+	inventoryCpy.Status = status
+	return h.inventories.UpdateStatus(inventoryCpy)
+}
+
+func getInventoryStatus(acceleratorType string, nodes []*corev1.Node) mgmtv1.AcceleratorInventoryStatus {
+	status := mgmtv1.AcceleratorInventoryStatus{
+		Type:         acceleratorType,
+		ResourceName: utils.GetAcceleratorResourceName(acceleratorType),
+		Nodes:        make([]mgmtv1.AcceleratorNode, 0, len(nodes)),
+	}
+
+	for _, node := range nodes {
+		acceleratorNode := mgmtv1.AcceleratorNode{Name: node.Name}
+		if accelerator := discoverAccelerator(node); accelerator != nil {
+			acceleratorNode.Product = accelerator.product
+		}
+		if status.ResourceName != "" {
+			capacity := node.Status.Capacity[status.ResourceName]
+			allocatable := node.Status.Allocatable[status.ResourceName]
+			acceleratorNode.Count = capacity.Value()
+			acceleratorNode.Allocatable = allocatable.Value()
+		}
+		status.Count += acceleratorNode.Count
+		status.Allocatable += acceleratorNode.Allocatable
+		status.Nodes = append(status.Nodes, acceleratorNode)
+	}
+	sort.Slice(status.Nodes, func(i, j int) bool { return status.Nodes[i].Name < status.Nodes[j].Name })
+	return status
+}
+
+// GetInventoryName returns the name of the AcceleratorInventory of the accelerator type
+func GetInventoryName(acceleratorType string) string {
+	return strings.ToLower(acceleratorType)
+}
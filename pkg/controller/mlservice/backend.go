@@ -0,0 +1,168 @@
+package mlservice
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+)
+
+const (
+	rayServeServicePort = 8000
+)
+
+// servingBackend serves the model of the MLService, the serving status and endpoint of all backends are
+// reported to the MLService status by setServingStatus
+type servingBackend interface {
+	// Serve creates or updates the serving workloads of the MLService
+	Serve(mlService *mlv1.MLService, modelTmpVersion *mlv1.ModelTemplateVersion) error
+	// Cleanup removes the serving workloads of the MLService, it is called once the MLService switches to another backend
+	Cleanup(mlService *mlv1.MLService) error
+	// CreatePVCs creates the persistent volumes required by the serving workloads
+	CreatePVCs(mlService *mlv1.MLService) error
+	// Endpoint returns the in-cluster OpenAI compatible endpoint of the MLService
+	Endpoint(mlService *mlv1.MLService) string
+}
+
+func getBackendType(mlService *mlv1.MLService) mlv1.MLServiceBackendType {
+	if mlService.Spec.Backend == "" {
+		return mlv1.MLServiceBackendRayService
+	}
+	return mlService.Spec.Backend
+}
+
+// setServingStatus sets the serving status and endpoint reported by the backend of the MLService
+func setServingStatus(mlService *mlv1.MLService, serviceStatus, endpoint string, ready bool) {
+	mlService.Status.ServiceStatus = serviceStatus
+	mlService.Status.Endpoint = endpoint
+	if ready {
+		mlv1.MLServiceReady.True(mlService)
+		mlv1.MLServicePending.False(mlService)
+		mlv1.MLServicePending.Reason(mlService, "")
+	} else {
+		mlv1.MLServiceReady.False(mlService)
+		mlv1.MLServicePending.True(mlService)
+		mlv1.MLServicePending.Reason(mlService, serviceStatus)
+	}
+}
+
+// conflictError is returned by the backend if the serving workloads of the MLService name are not controlled by it,
+// the workloads are never taken over
+type conflictError struct {
+	message string
+}
+
+func (e *conflictError) Error() string {
+	return e.message
+}
+
+// rayServiceBackend serves the model by the RayService with the RayLLM serve application
+type rayServiceBackend struct {
+	*Handler
+}
+
+func (b *rayServiceBackend) Serve(mlService *mlv1.MLService, modelTmpVersion *mlv1.ModelTemplateVersion) error {
+	if mlService.Spec.MLClusterRef == nil {
+		return fmt.Errorf("mlClusterRef is required by the %s backend", mlv1.MLServiceBackendRayService)
+	}
+
+	// get the modelRef template version and save it as a configmap
+	if _, err := b.createModelConfigMap(modelTmpVersion, mlService.Namespace); err != nil {
+		return err
+	}
+
+	// serve the LLM model use RayService
+	raySvc, err := b.rayServiceCache.Get(mlService.Namespace, mlService.Name)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	// the RayService of the same name may belong to a ServeApplication or be created by the users
+	if raySvc != nil && !isControlledByMLService(raySvc, mlService) {
+		return &conflictError{
+			message: fmt.Sprintf("RayService %s/%s is not owned by the MLService", raySvc.Namespace, raySvc.Name),
+		}
+	}
+
+	serveConfig, next, err := getServeConfigV2(mlService.Name, modelTmpVersion, time.Now())
+	if err != nil {
+		return err
+	}
+	// re-sync the serve config when the next replica schedule window starts or ends
+	if next > 0 {
+		b.mlService.EnqueueAfter(mlService.Namespace, mlService.Name, next)
+	}
+
+	// ensuring ML cluster, create a new one by RayService if not exist
+	if raySvc == nil {
+		owners := generateMLServiceOwnerReference(mlService)
+		rayService, err := getRayServiceConfig(mlService, modelTmpVersion, serveConfig, owners, b.releaseName)
+		if err != nil {
+			return err
+		}
+
+		_, err = b.rayService.Create(rayService)
+		return err
+	}
+
+	// updating the RayService if it is modified
+	raySvcCpy := raySvc.DeepCopy()
+	raySvcCpy.Spec.ServeConfigV2 = serveConfig
+	SetRayClusterImage(mlService.Spec.MLClusterRef, raySvcCpy)
+	SetRayClusterWorkerGroupConfig(mlService.Spec.MLClusterRef, mlService.Spec.HFSecretRef, raySvcCpy)
+	if !reflect.DeepEqual(raySvc.Spec, raySvcCpy.Spec) {
+		logrus.Debugf("updating RayService: %s, spec:%v", raySvcCpy.Name, raySvcCpy.Spec)
+		if _, err = b.rayService.Update(raySvcCpy); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *rayServiceBackend) Cleanup(mlService *mlv1.MLService) error {
+	raySvc, err := b.rayServiceCache.Get(mlService.Namespace, mlService.Name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if !isControlledByMLService(raySvc, mlService) {
+		return nil
+	}
+
+	return b.rayService.Delete(raySvc.Namespace, raySvc.Name, &metav1.DeleteOptions{})
+}
+
+// isControlledByMLService checks the RayService is controlled by the MLService, the RayServices created before the
+// controller flag is set in the owner references are matched by the UID
+func isControlledByMLService(raySvc *rayv1.RayService, mlService *mlv1.MLService) bool {
+	if metav1.IsControlledBy(raySvc, mlService) {
+		return true
+	}
+	for _, owner := range raySvc.OwnerReferences {
+		if owner.Kind == MLServiceKind && owner.UID == mlService.UID && owner.Controller == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *rayServiceBackend) CreatePVCs(mlService *mlv1.MLService) error {
+	if mlService.Spec.MLClusterRef == nil {
+		return nil
+	}
+	return b.createRayClusterPVCs(mlService.Name, mlService.Namespace, mlService.Spec.MLClusterRef)
+}
+
+func (b *rayServiceBackend) Endpoint(mlService *mlv1.MLService) string {
+	// the serve service is created by kuberay with the name of <rayService>-serve-svc
+	return fmt.Sprintf("http://%s-serve-svc.%s.svc.cluster.local:%d/v1", mlService.Name, mlService.Namespace, rayServeServicePort)
+}
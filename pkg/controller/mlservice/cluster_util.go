@@ -12,24 +12,27 @@ import (
 	"github.com/oneblock-ai/oneblock/pkg/controller/raycluster"
 	"github.com/oneblock-ai/oneblock/pkg/settings"
 	"github.com/oneblock-ai/oneblock/pkg/utils"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
 )
 
 const (
 	huggingFaceHubTokenEnvName = "HUGGING_FACE_HUB_TOKEN" // #nosec G101
 )
 
-func GetRayClusterSpecConfig(mlSvc *mlv1.MLService, modelTmpVersion *mlv1.ModelTemplateVersion, releaseName string) (*rayv1.RayClusterSpec, error) {
-	mlClusterRef := mlSvc.Spec.MLClusterRef
+// GetRayClusterSpecConfig returns the rayCluster spec of the serving workload(e.g., MLService or ServeApplication),
+// the model config is mounted to the head group only if the modelTmpVersion is specified
+func GetRayClusterSpecConfig(name, namespace string, mlClusterRef *mlv1.MLClusterRef, hfRef *mlv1.HFSecretRef,
+	modelTmpVersion *mlv1.ModelTemplateVersion, releaseName string) (*rayv1.RayClusterSpec, error) {
 	clusterImg := getRayClusterImage(mlClusterRef.RayClusterSpec.Image)
 
-	headGroupSpec, err := GetHeadGroupSpecConfig(mlSvc, modelTmpVersion, releaseName, clusterImg)
+	headGroupSpec, err := GetHeadGroupSpecConfig(name, namespace, modelTmpVersion, releaseName, clusterImg)
 	if err != nil {
 		return nil, err
 	}
 
-	workerGroupSpecs := make([]rayv1.WorkerGroupSpec, len(mlSvc.Spec.MLClusterRef.RayClusterSpec.WorkerGroupSpec))
-	for i, wgCfg := range mlSvc.Spec.MLClusterRef.RayClusterSpec.WorkerGroupSpec {
-		workerGroupSpecs[i], err = GetDefaultWorkerGroupSpecConfig(wgCfg, clusterImg, mlSvc.Spec.HFSecretRef)
+	workerGroupSpecs := make([]rayv1.WorkerGroupSpec, len(mlClusterRef.RayClusterSpec.WorkerGroupSpec))
+	for i, wgCfg := range mlClusterRef.RayClusterSpec.WorkerGroupSpec {
+		workerGroupSpecs[i], err = GetDefaultWorkerGroupSpecConfig(wgCfg, clusterImg, hfRef)
 		if err != nil {
 			return nil, err
 		}
@@ -66,7 +69,7 @@ func getDefaultAutoScalingOptions() *rayv1.AutoscalerOptions {
 // GetHeadGroupSpecConfig returns the head group spec of the rayCluster
 // 1. GCS and persistent log is enabled by default for the head group
 // 2. add model config mount point
-func GetHeadGroupSpecConfig(mlService *mlv1.MLService, modelTmpVersion *mlv1.ModelTemplateVersion, releaseName, image string) (*rayv1.HeadGroupSpec, error) {
+func GetHeadGroupSpecConfig(name, namespace string, modelTmpVersion *mlv1.ModelTemplateVersion, releaseName, image string) (*rayv1.HeadGroupSpec, error) {
 	rayStartParams := map[string]string{
 		"num-cpus":       "0", // Setting "num-cpus: 0" to avoid any Ray actors or tasks being scheduled on the Ray head Pod.
 		"redis-password": "$REDIS_PASSWORD",
@@ -79,7 +82,7 @@ func GetHeadGroupSpecConfig(mlService *mlv1.MLService, modelTmpVersion *mlv1.Mod
 			Name: "ray-logs",
 			VolumeSource: corev1.VolumeSource{
 				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-					ClaimName: getHeadGroupVolName(mlService.Name),
+					ClaimName: getHeadGroupVolName(name),
 				},
 			},
 		},
@@ -92,7 +95,7 @@ func GetHeadGroupSpecConfig(mlService *mlv1.MLService, modelTmpVersion *mlv1.Mod
 				Name:  "ray-head",
 				Image: image,
 				Ports: getDefaultClusterPorts(),
-				Env:   raycluster.GetHeadNodeRedisEnvConfig(releaseName, mlService.Namespace),
+				Env:   raycluster.GetHeadNodeRedisEnvConfig(releaseName, namespace),
 				Resources: corev1.ResourceRequirements{
 					Requests: corev1.ResourceList{
 						corev1.ResourceCPU:    resource.MustParse("500m"),
@@ -116,7 +119,7 @@ func GetHeadGroupSpecConfig(mlService *mlv1.MLService, modelTmpVersion *mlv1.Mod
 	}
 
 	// add model config
-	if mlService.Spec.ModelTemplateVersionRef != nil {
+	if modelTmpVersion != nil {
 		modelVol := GetModelVolume(modelTmpVersion)
 		podSpec.Volumes = append(podSpec.Volumes, modelVol)
 		podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
@@ -300,14 +303,14 @@ func getDefaultClusterPorts() []corev1.ContainerPort {
 	}
 }
 
-func SetRayClusterImage(mlSvc *mlv1.MLService, service *rayv1.RayService) {
-	img := getRayClusterImage(mlSvc.Spec.MLClusterRef.RayClusterSpec.Image)
+func SetRayClusterImage(mlClusterRef *mlv1.MLClusterRef, service *rayv1.RayService) {
+	img := getRayClusterImage(mlClusterRef.RayClusterSpec.Image)
 	service.Spec.RayClusterSpec.HeadGroupSpec.Template.Spec.Containers[0].Image = img
 	service.Spec.RayClusterSpec.WorkerGroupSpecs[0].Template.Spec.Containers[0].Image = img
 }
 
-func SetRayClusterWorkerGroupConfig(mlSvc *mlv1.MLService, service *rayv1.RayService) {
-	for _, workerGroup := range mlSvc.Spec.MLClusterRef.RayClusterSpec.WorkerGroupSpec {
+func SetRayClusterWorkerGroupConfig(mlClusterRef *mlv1.MLClusterRef, hfRef *mlv1.HFSecretRef, service *rayv1.RayService) {
+	for _, workerGroup := range mlClusterRef.RayClusterSpec.WorkerGroupSpec {
 		for i, svcWorkerGroup := range service.Spec.RayClusterSpec.WorkerGroupSpecs {
 			if svcWorkerGroup.GroupName == workerGroup.Name {
 				svcWorkerGroup.Replicas = workerGroup.Replicas
@@ -322,13 +325,12 @@ func SetRayClusterWorkerGroupConfig(mlSvc *mlv1.MLService, service *rayv1.RaySer
 				}
 
 				svcWorkerGroup.Template.Spec.RuntimeClassName = workerGroup.RuntimeClassName
-				svcWorkerGroup.Template.Spec.NodeSelector = workerGroup.NodeSelector
+				svcWorkerGroup.Template.Spec.NodeSelector = getWorkerGroupNodeSelector(workerGroup)
 				svcWorkerGroup.Template.Spec.Tolerations = workerGroup.Tolerations
 				if workerGroup.Resources != nil {
 					svcWorkerGroup.Template.Spec.Containers[0].Resources = *workerGroup.Resources
 				}
 
-				hfRef := mlSvc.Spec.HFSecretRef
 				workerGroupEnv := setWorkerGroupHFEnv(hfRef, svcWorkerGroup)
 				if workerGroupEnv != nil {
 					svcWorkerGroup.Template.Spec.Containers[0].Env = workerGroupEnv
@@ -365,6 +367,31 @@ func setWorkerGroupHFEnv(hfRef *mlv1.HFSecretRef, workerGroup rayv1.WorkerGroupS
 	return workerGroupEnv
 }
 
+// getWorkerGroupNodeSelector selects the nodes of the accelerator type if the worker group asks for a single known
+// accelerator type, the nodes are labeled with their types by the accelerator discovery
+func getWorkerGroupNodeSelector(spec mlv1.WorkerGroupSpec) map[string]string {
+	if len(spec.AcceleratorTypes) != 1 {
+		return spec.NodeSelector
+	}
+	if _, ok := spec.NodeSelector[constant.LabelAcceleratorType]; ok {
+		return spec.NodeSelector
+	}
+
+	var acceleratorType string
+	for aType := range spec.AcceleratorTypes {
+		acceleratorType = utils.GetAcceleratorTypeByProductName(aType)
+	}
+	if utils.GetAcceleratorResourceName(acceleratorType) == "" {
+		return spec.NodeSelector
+	}
+
+	nodeSelector := make(map[string]string, len(spec.NodeSelector)+1)
+	for k, v := range spec.NodeSelector {
+		nodeSelector[k] = v
+	}
+	return utils.MergeNodeSelector(nodeSelector, utils.GetAcceleratorNodeSelector(acceleratorType))
+}
+
 func configResourceAccelerators(spec mlv1.WorkerGroupSpec) string {
 	var accelerators string
 	if spec.AcceleratorTypes != nil {
@@ -381,9 +408,9 @@ func getHeadGroupVolName(mlSvcName string) string {
 	return fmt.Sprintf(mlSvcName + "-head-log")
 }
 
-func getHeadGroupVolume(mlSvc *mlv1.MLService) mlv1.Volume {
+func getHeadGroupVolume(name string) mlv1.Volume {
 	return mlv1.Volume{
-		Name: getHeadGroupVolName(mlSvc.Name),
+		Name: getHeadGroupVolName(name),
 		Spec: corev1.PersistentVolumeClaimSpec{
 			AccessModes: []corev1.PersistentVolumeAccessMode{
 				corev1.ReadWriteOnce,
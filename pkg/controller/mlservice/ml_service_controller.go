@@ -5,33 +5,46 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/rancher/wrangler/v2/pkg/apply"
 	"github.com/rancher/wrangler/v2/pkg/condition"
+	ctlappsv1 "github.com/rancher/wrangler/v2/pkg/generated/controllers/apps/v1"
 	ctlcorev1 "github.com/rancher/wrangler/v2/pkg/generated/controllers/core/v1"
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 
 	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
 	ctloneblockv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ml.oneblock.ai/v1"
 	ctlrayv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ray.io/v1"
 	"github.com/oneblock-ai/oneblock/pkg/server/config"
 	"github.com/oneblock-ai/oneblock/pkg/utils"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
 )
 
 const (
 	mlServiceControllerOnChange   = "mlService.onChange"
 	mlServiceControllerCreatePVC  = "mlService.createPVCFromAnnotation"
 	mlServiceControllerSyncStatus = "mlService.syncRayServiceStatus"
+	mlServiceControllerSyncVLLM   = "mlService.syncVLLMDeploymentStatus"
+
+	serveAppControllerOnChange  = "serveApplication.onChange"
+	serveAppControllerCreatePVC = "serveApplication.createPVCs"
 )
 
 type Handler struct {
 	ctx                       context.Context
 	releaseName               string
+	apply                     apply.Apply
+	backends                  map[mlv1.MLServiceBackendType]servingBackend
 	mlService                 ctloneblockv1.MLServiceController
 	mlServiceCache            ctloneblockv1.MLServiceCache
+	serveApp                  ctloneblockv1.ServeApplicationController
+	serveAppCache             ctloneblockv1.ServeApplicationCache
 	rayService                ctlrayv1.RayServiceController
 	rayServiceCache           ctlrayv1.RayServiceCache
+	deploymentCache           ctlappsv1.DeploymentCache
 	configmap                 ctlcorev1.ConfigMapController
 	configmapCache            ctlcorev1.ConfigMapCache
 	secret                    ctlcorev1.SecretController
@@ -43,18 +56,24 @@ type Handler struct {
 
 func Register(ctx context.Context, mgmt *config.Management) error {
 	mlService := mgmt.OneBlockMLFactory.Ml().V1().MLService()
+	serveApps := mgmt.OneBlockMLFactory.Ml().V1().ServeApplication()
 	templateVersion := mgmt.OneBlockMLFactory.Ml().V1().ModelTemplateVersion()
 	rayService := mgmt.KubeRayFactory.Ray().V1().RayService()
 	pvcs := mgmt.CoreFactory.Core().V1().PersistentVolumeClaim()
 	configmaps := mgmt.CoreFactory.Core().V1().ConfigMap()
 	secrets := mgmt.CoreFactory.Core().V1().Secret()
+	deployments := mgmt.AppsFactory.Apps().V1().Deployment()
 	handler := &Handler{
 		ctx:                       ctx,
 		releaseName:               mgmt.ReleaseName,
+		apply:                     mgmt.Apply,
 		mlService:                 mlService,
 		mlServiceCache:            mlService.Cache(),
+		serveApp:                  serveApps,
+		serveAppCache:             serveApps.Cache(),
 		rayService:                rayService,
 		rayServiceCache:           rayService.Cache(),
+		deploymentCache:           deployments.Cache(),
 		configmap:                 configmaps,
 		configmapCache:            configmaps.Cache(),
 		secret:                    secrets,
@@ -63,16 +82,23 @@ func Register(ctx context.Context, mgmt *config.Management) error {
 		modelTemplateVersionCache: templateVersion.Cache(),
 		pvcHandler:                utils.NewPVCHandler(pvcs, pvcs.Cache()),
 	}
+	handler.backends = map[mlv1.MLServiceBackendType]servingBackend{
+		mlv1.MLServiceBackendRayService: &rayServiceBackend{handler},
+		mlv1.MLServiceBackendVLLM:       &vllmBackend{handler},
+	}
 
 	mlService.OnChange(ctx, mlServiceControllerOnChange, handler.OnChange)
 	mlService.OnChange(ctx, mlServiceControllerCreatePVC, handler.createMLServicePVCs)
+	serveApps.OnChange(ctx, serveAppControllerOnChange, handler.OnServeApplicationChange)
+	serveApps.OnChange(ctx, serveAppControllerCreatePVC, handler.createServeApplicationPVCs)
 	rayService.OnChange(ctx, mlServiceControllerSyncStatus, handler.syncRayServiceStatus)
+	deployments.OnChange(ctx, mlServiceControllerSyncVLLM, handler.syncVLLMDeploymentStatus)
 	return nil
 }
 
 // OnChange method will help to serve the LLM model inference
 // 1. sync required resources like model config and secrets to the local NS
-// 2. serve and reconcile the serving parameters using the RayService
+// 2. serve and reconcile the serving parameters using the backend of the MLService, e.g., RayService or vLLM
 func (h *Handler) OnChange(_ string, mlService *mlv1.MLService) (*mlv1.MLService, error) {
 	if mlService == nil || mlService.DeletionTimestamp != nil {
 		return mlService, nil
@@ -96,17 +122,12 @@ func (h *Handler) OnChange(_ string, mlService *mlv1.MLService) (*mlv1.MLService
 		return mlService, err
 	}
 
-	// get the modelRef template version and save it as a configmap
-	if _, err = h.createModelConfigMap(modelTmpVersion, mlService.Namespace); err != nil {
-		if err = h.updateMLServiceCondition(mlService, mlv1.MLServiceCreated, false, err.Error()); err != nil {
-			return mlService, err
-		}
-		return mlService, err
-	}
-
 	// sync HF secret to the local ns
 	if mlService.Spec.HFSecretRef != nil {
 		if err = h.SyncClusterSecretsToLocalNS(mlService.Spec.HFSecretRef, mlService.Namespace); err != nil {
+			if conflict, ok := err.(*conflictError); ok {
+				return mlService, h.setMLServiceConflict(mlService, conflict)
+			}
 			if err = h.updateMLServiceCondition(mlService, mlv1.MLServiceCreated, false, err.Error()); err != nil {
 				return mlService, err
 			}
@@ -114,70 +135,97 @@ func (h *Handler) OnChange(_ string, mlService *mlv1.MLService) (*mlv1.MLService
 		}
 	}
 
-	// serve the LLM model use RayService
-	raySvc, err := h.rayServiceCache.Get(mlService.Namespace, mlService.Name)
-	if err != nil && !errors.IsNotFound(err) {
+	backendType := getBackendType(mlService)
+	// clean up the serving workloads of the previous backend once the backend is switched
+	if previous, ok := h.backends[mlService.Status.Backend]; ok && mlService.Status.Backend != backendType {
+		if err = previous.Cleanup(mlService); err != nil {
+			return mlService, err
+		}
+	}
+
+	backend, ok := h.backends[backendType]
+	if !ok {
+		err = fmt.Errorf("unsupported serving backend %s", backendType)
 		if err = h.updateMLServiceCondition(mlService, mlv1.MLServiceCreated, false, err.Error()); err != nil {
 			return mlService, err
 		}
 		return mlService, err
 	}
 
-	// ensuring ML cluster, create a new one by RayService if not exist
-	if raySvc == nil {
-		owners := generateMLServiceOwnerReference(mlService)
-		rayService, err := getRayServiceConfig(mlService, modelTmpVersion, owners, h.releaseName)
-		if err != nil {
-			if err = h.updateMLServiceCondition(mlService, mlv1.MLServiceCreated, false, err.Error()); err != nil {
-				return mlService, err
-			}
+	if err = backend.Serve(mlService, modelTmpVersion); err != nil {
+		if conflict, ok := err.(*conflictError); ok {
+			return mlService, h.setMLServiceConflict(mlService, conflict)
+		}
+		if err = h.updateMLServiceCondition(mlService, mlv1.MLServiceCreated, false, err.Error()); err != nil {
 			return mlService, err
 		}
-
-		raySvc, err = h.rayService.Create(rayService)
-		if err != nil {
-			if err = h.updateMLServiceCondition(mlService, mlv1.MLServiceCreated, false, err.Error()); err != nil {
-				return mlService, err
-			}
+		return mlService, err
+	}
+	if mlv1.MLServiceConflict.IsTrue(mlService) {
+		if err = h.updateMLServiceCondition(mlService, mlv1.MLServiceConflict, false, ""); err != nil {
 			return mlService, err
 		}
-
-		return mlService, h.updateMLServiceCondition(mlService, mlv1.MLServiceCreated, true, "")
 	}
 
-	// updating the RayService if it is modified
-	raySvcCpy := raySvc.DeepCopy()
-	SetRayClusterImage(mlService, raySvcCpy)
-	SetRayClusterWorkerGroupConfig(mlService, raySvcCpy)
-	if !reflect.DeepEqual(raySvc.Spec, raySvcCpy.Spec) {
-		logrus.Debugf("updating RayService: %s, spec:%v", raySvcCpy.Name, raySvcCpy.Spec)
-		if _, err = h.rayService.Update(raySvcCpy); err != nil {
+	if mlService.Status.Backend != backendType {
+		svcCpy := mlService.DeepCopy()
+		svcCpy.Status.Backend = backendType
+		updated, err := h.mlService.UpdateStatus(svcCpy)
+		if err != nil {
 			return mlService, err
 		}
+		mlService = updated
 	}
 
-	return nil, nil
+	return mlService, h.updateMLServiceCondition(mlService, mlv1.MLServiceCreated, true, "")
+}
+
+// setMLServiceConflict reports the conflict of the MLService, it's not retried until the MLService or the conflicted
+// resource is changed
+func (h *Handler) setMLServiceConflict(mlService *mlv1.MLService, conflict *conflictError) error {
+	logrus.Warnf("MLService %s/%s is conflicted: %s", mlService.Namespace, mlService.Name, conflict.message)
+	return h.updateMLServiceCondition(mlService, mlv1.MLServiceConflict, true, conflict.message)
 }
 
 func (h *Handler) createModelConfigMap(modelTemplateVersion *mlv1.ModelTemplateVersion, namespace string) (*corev1.ConfigMap, error) {
-	modelCfg, err := h.configmapCache.Get(modelTemplateVersion.Namespace, modelTemplateVersion.Name)
+	modelCfg, err := h.configmapCache.Get(namespace, modelTemplateVersion.Name)
 	if err != nil && !errors.IsNotFound(err) {
 		return nil, err
 	}
-	// since model reference cannot be modified, if the configmap already exists, just return it
+	data := map[string]string{
+		GetModelConfigMapKey(modelTemplateVersion.Name): modelTemplateVersion.Status.GeneratedModelConfig,
+	}
+	// since model reference cannot be modified, if the configmap already exists, just return it,
+	// the configmaps created by the previous versions are labeled to be garbage-collected, while the configmaps
+	// of the same name created by the users are never taken over
 	if modelCfg != nil {
-		return modelCfg, nil
+		if modelCfg.Labels[constant.LabelModelTemplateVersionName] == modelTemplateVersion.Name {
+			return modelCfg, nil
+		}
+		if !reflect.DeepEqual(modelCfg.Data, data) {
+			return nil, &conflictError{
+				message: fmt.Sprintf("configmap %s/%s of the model config is not created by oneblock", namespace, modelCfg.Name),
+			}
+		}
+		cfgCpy := modelCfg.DeepCopy()
+		if cfgCpy.Labels == nil {
+			cfgCpy.Labels = make(map[string]string, 1)
+		}
+		cfgCpy.Labels[constant.LabelModelTemplateVersionName] = modelTemplateVersion.Name
+		return h.configmap.Update(cfgCpy)
 	}
 
-	// create a new configmap
+	// create a new configmap, it is shared by the services of the same model in the namespace and
+	// garbage-collected once none of them is left
 	modelCfg = &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      modelTemplateVersion.Name,
 			Namespace: namespace,
+			Labels: map[string]string{
+				constant.LabelModelTemplateVersionName: modelTemplateVersion.Name,
+			},
 		},
-		Data: map[string]string{
-			GetModelConfigMapKey(modelTemplateVersion.Name): modelTemplateVersion.Status.GeneratedModelConfig,
-		},
+		Data: data,
 	}
 	return h.configmap.Create(modelCfg)
 }
@@ -187,13 +235,27 @@ func (h *Handler) createMLServicePVCs(_ string, mlService *mlv1.MLService) (*mlv
 		return mlService, nil
 	}
 
+	backend, ok := h.backends[getBackendType(mlService)]
+	if !ok {
+		return mlService, nil
+	}
+
+	if err := backend.CreatePVCs(mlService); err != nil {
+		return nil, err
+	}
+
+	return mlService, nil
+}
+
+// createRayClusterPVCs creates the head group log PVC and the worker group PVCs of the serving RayService
+func (h *Handler) createRayClusterPVCs(name, namespace string, mlClusterRef *mlv1.MLClusterRef) error {
 	var volumes = make([]mlv1.Volume, 0)
 	var volumeNames = make([]string, 0)
-	headGroupVol := getHeadGroupVolume(mlService)
+	headGroupVol := getHeadGroupVolume(name)
 	volumes = append(volumes, headGroupVol)
 	volumeNames = append(volumeNames, headGroupVol.Name)
 
-	for _, wg := range mlService.Spec.MLClusterRef.RayClusterSpec.WorkerGroupSpec {
+	for _, wg := range mlClusterRef.RayClusterSpec.WorkerGroupSpec {
 		if wg.Volume != nil {
 			volumes = append(volumes, *wg.Volume)
 			volumeNames = append(volumeNames, wg.Name)
@@ -201,19 +263,21 @@ func (h *Handler) createMLServicePVCs(_ string, mlService *mlv1.MLService) (*mlv
 	}
 
 	if len(volumes) == 0 {
-		return nil, nil
+		return nil
 	}
 
 	// skip PVC garbage collection by not setting its ownerRefs
-	err := h.pvcHandler.CreatePVCByVolume(volumes, mlService.Namespace, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	return mlService, nil
+	return h.pvcHandler.CreatePVCByVolume(volumes, namespace, nil)
 }
 
+// SyncClusterSecretsToLocalNS copies the HF secret to the namespace of the service, the copy is rotated once the HF
+// secret changes and garbage-collected once there are no services left in the namespace
 func (h *Handler) SyncClusterSecretsToLocalNS(hfRef *mlv1.HFSecretRef, namespace string) error {
+	// the HF secret is used directly in its own namespace
+	if hfRef.Namespace == namespace {
+		return nil
+	}
+
 	hfSecret, err := h.secretCache.Get(hfRef.Namespace, hfRef.Name)
 	if err != nil {
 		return fmt.Errorf("fail to find the HF secret: %v", err)
@@ -233,16 +297,25 @@ func (h *Handler) SyncClusterSecretsToLocalNS(hfRef *mlv1.HFSecretRef, namespace
 			},
 			Data: hfSecret.Data,
 		}
+		utils.SetSyncedSecretSource(newSecret, hfSecret)
 		if _, err = h.secret.Create(newSecret); err != nil {
 			return fmt.Errorf("failed to sync HF secret to ns %s: %v", namespace, err)
 		}
 		return nil
 	}
 
-	if !reflect.DeepEqual(hfSecret.Data, nsSecret.Data) {
-		secretCpy := nsSecret.DeepCopy()
-		secretCpy.Data = hfSecret.Data
-
+	// the synced secrets and the copies synced by the previous versions are adopted to be rotated and
+	// garbage-collected, while the other secrets of the same name are owned by the users and never taken over
+	if utils.GetSyncedSecretSource(nsSecret) == "" && !reflect.DeepEqual(nsSecret.Data, hfSecret.Data) {
+		return &conflictError{
+			message: fmt.Sprintf("secret %s/%s is not synced from the HF secret %s/%s", namespace, nsSecret.Name,
+				hfRef.Namespace, hfRef.Name),
+		}
+	}
+	secretCpy := nsSecret.DeepCopy()
+	secretCpy.Data = hfSecret.Data
+	utils.SetSyncedSecretSource(secretCpy, hfSecret)
+	if !reflect.DeepEqual(nsSecret, secretCpy) {
 		if _, err = h.secret.Update(secretCpy); err != nil {
 			return fmt.Errorf("failed to update secret %s:%s, %v", nsSecret.Name, nsSecret.Namespace, err)
 		}
@@ -257,16 +330,18 @@ func generateMLServiceOwnerReference(mlService *mlv1.MLService) []metav1.OwnerRe
 			Kind:       mlService.Kind,
 			Name:       mlService.Name,
 			UID:        mlService.UID,
+			Controller: pointer.Bool(true),
 		},
 	}
 }
 
 func (h *Handler) updateMLServiceCondition(service *mlv1.MLService, cond condition.Cond, isTrue bool, message string) error {
 	if isTrue {
-		if cond.IsTrue(service) {
+		if cond.IsTrue(service) && cond.GetMessage(service) == message {
 			return nil
 		}
 		cond.True(service)
+		cond.Message(service, message)
 	} else {
 		if cond.IsFalse(service) && cond.GetMessage(service) == message {
 			return nil
@@ -8,7 +8,8 @@ import (
 )
 
 const (
-	MLServiceKind = "MLService"
+	MLServiceKind        = "MLService"
+	ServeApplicationKind = "ServeApplication"
 )
 
 func (h *Handler) syncRayServiceStatus(_ string, rayService *rayv1.RayService) (*rayv1.RayService, error) {
@@ -16,7 +17,11 @@ func (h *Handler) syncRayServiceStatus(_ string, rayService *rayv1.RayService) (
 		return nil, nil
 	}
 
-	ownerRef := getMLServiceOwner(rayService.OwnerReferences)
+	if ownerRef := getOwnerByKind(rayService.OwnerReferences, ServeApplicationKind); ownerRef != nil {
+		return h.syncServeApplicationStatus(ownerRef, rayService)
+	}
+
+	ownerRef := getOwnerByKind(rayService.OwnerReferences, MLServiceKind)
 	if ownerRef == nil {
 		return nil, nil
 	}
@@ -26,19 +31,48 @@ func (h *Handler) syncRayServiceStatus(_ string, rayService *rayv1.RayService) (
 		return rayService, err
 	}
 
+	if getBackendType(mlService) != mlv1.MLServiceBackendRayService {
+		return nil, nil
+	}
+
 	// only update the service status when its status or generation is changed
+	endpoint := h.backends[mlv1.MLServiceBackendRayService].Endpoint(mlService)
 	if mlService.Status.RayServiceStatuses.ServiceStatus != rayService.Status.ServiceStatus ||
-		mlService.Status.RayServiceStatuses.ObservedGeneration != rayService.Status.ObservedGeneration {
+		mlService.Status.RayServiceStatuses.ObservedGeneration != rayService.Status.ObservedGeneration ||
+		mlService.Status.Endpoint != endpoint {
 		mlServiceCpy := mlService.DeepCopy()
 		mlServiceCpy.Status.RayServiceStatuses = rayService.Status
+		setServingStatus(mlServiceCpy, string(rayService.Status.ServiceStatus), endpoint,
+			rayService.Status.ServiceStatus == rayv1.Running)
+		if _, err = h.mlService.UpdateStatus(mlServiceCpy); err != nil {
+			return rayService, err
+		}
+	}
+
+	return nil, nil
+}
+
+func (h *Handler) syncServeApplicationStatus(ownerRef *v1.OwnerReference, rayService *rayv1.RayService) (*rayv1.RayService, error) {
+	serveApp, err := h.serveAppCache.Get(rayService.Namespace, ownerRef.Name)
+	if err != nil {
+		return rayService, err
+	}
+
+	// only update the application status when its status or generation is changed
+	if serveApp.Status.RayServiceStatuses.ServiceStatus != rayService.Status.ServiceStatus ||
+		serveApp.Status.RayServiceStatuses.ObservedGeneration != rayService.Status.ObservedGeneration {
+		serveAppCpy := serveApp.DeepCopy()
+		serveAppCpy.Status.RayServiceStatuses = rayService.Status
 		if rayService.Status.ServiceStatus == rayv1.Running {
-			mlv1.MLServiceReady.True(mlServiceCpy)
+			mlv1.ServeApplicationReady.True(serveAppCpy)
+			mlv1.ServeApplicationPending.False(serveAppCpy)
+			mlv1.ServeApplicationPending.Reason(serveAppCpy, "")
 		} else {
-			mlv1.MLServiceReady.False(mlServiceCpy)
-			mlv1.MLServicePending.True(mlServiceCpy)
-			mlv1.MLServicePending.Reason(mlServiceCpy, string(rayService.Status.ServiceStatus))
+			mlv1.ServeApplicationReady.False(serveAppCpy)
+			mlv1.ServeApplicationPending.True(serveAppCpy)
+			mlv1.ServeApplicationPending.Reason(serveAppCpy, string(rayService.Status.ServiceStatus))
 		}
-		if _, err = h.mlService.UpdateStatus(mlServiceCpy); err != nil {
+		if _, err = h.serveApp.UpdateStatus(serveAppCpy); err != nil {
 			return rayService, err
 		}
 	}
@@ -46,13 +80,13 @@ func (h *Handler) syncRayServiceStatus(_ string, rayService *rayv1.RayService) (
 	return nil, nil
 }
 
-func getMLServiceOwner(ownerRefers []v1.OwnerReference) *v1.OwnerReference {
+func getOwnerByKind(ownerRefers []v1.OwnerReference, kind string) *v1.OwnerReference {
 	if ownerRefers == nil || len(ownerRefers) == 0 {
 		return nil
 	}
 
 	for _, owner := range ownerRefers {
-		if owner.Kind == MLServiceKind {
+		if owner.Kind == kind {
 			return &owner
 		}
 	}
@@ -0,0 +1,185 @@
+package mlservice
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/rancher/wrangler/v2/pkg/condition"
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+)
+
+// OnServeApplicationChange compiles the generic Ray Serve application into a RayService,
+// and reconciles the serve config and cluster shape when it is modified
+func (h *Handler) OnServeApplicationChange(_ string, serveApp *mlv1.ServeApplication) (*mlv1.ServeApplication, error) {
+	if serveApp == nil || serveApp.DeletionTimestamp != nil {
+		return serveApp, nil
+	}
+
+	// sync HF secret to the local ns
+	if serveApp.Spec.HFSecretRef != nil {
+		if err := h.SyncClusterSecretsToLocalNS(serveApp.Spec.HFSecretRef, serveApp.Namespace); err != nil {
+			if conflict, ok := err.(*conflictError); ok {
+				logrus.Warnf("ServeApplication %s/%s is conflicted: %s", serveApp.Namespace, serveApp.Name, conflict.message)
+				return serveApp, h.updateServeApplicationCondition(serveApp, mlv1.ServeApplicationConflict, true, conflict.message)
+			}
+			if err = h.updateServeApplicationCondition(serveApp, mlv1.ServeApplicationCreated, false, err.Error()); err != nil {
+				return serveApp, err
+			}
+			return serveApp, err
+		}
+	}
+
+	raySvc, err := h.rayServiceCache.Get(serveApp.Namespace, serveApp.Name)
+	if err != nil && !errors.IsNotFound(err) {
+		if err = h.updateServeApplicationCondition(serveApp, mlv1.ServeApplicationCreated, false, err.Error()); err != nil {
+			return serveApp, err
+		}
+		return serveApp, err
+	}
+
+	// the RayService of the same name may belong to an MLService or be created by the users, it's never taken over
+	conflict, err := h.getServeApplicationConflict(serveApp, raySvc)
+	if err != nil {
+		return serveApp, err
+	}
+	if conflict != "" {
+		logrus.Warnf("ServeApplication %s/%s is conflicted: %s", serveApp.Namespace, serveApp.Name, conflict)
+		return serveApp, h.updateServeApplicationCondition(serveApp, mlv1.ServeApplicationConflict, true, conflict)
+	}
+	if mlv1.ServeApplicationConflict.IsTrue(serveApp) {
+		if err = h.updateServeApplicationCondition(serveApp, mlv1.ServeApplicationConflict, false, ""); err != nil {
+			return serveApp, err
+		}
+	}
+
+	if raySvc == nil {
+		owners := generateServeApplicationOwnerReference(serveApp)
+		rayService, err := getServeApplicationRayServiceConfig(serveApp, owners, h.releaseName)
+		if err != nil {
+			if err = h.updateServeApplicationCondition(serveApp, mlv1.ServeApplicationCreated, false, err.Error()); err != nil {
+				return serveApp, err
+			}
+			return serveApp, err
+		}
+
+		if _, err = h.rayService.Create(rayService); err != nil {
+			if err = h.updateServeApplicationCondition(serveApp, mlv1.ServeApplicationCreated, false, err.Error()); err != nil {
+				return serveApp, err
+			}
+			return serveApp, err
+		}
+
+		return serveApp, h.updateServeApplicationCondition(serveApp, mlv1.ServeApplicationCreated, true, "")
+	}
+
+	// updating the RayService if the serve config or cluster shape is modified
+	serveConfig, err := getServeApplicationConfigV2(serveApp)
+	if err != nil {
+		if err = h.updateServeApplicationCondition(serveApp, mlv1.ServeApplicationCreated, false, err.Error()); err != nil {
+			return serveApp, err
+		}
+		return serveApp, err
+	}
+
+	raySvcCpy := raySvc.DeepCopy()
+	raySvcCpy.Spec.ServeConfigV2 = serveConfig
+	SetRayClusterImage(serveApp.Spec.MLClusterRef, raySvcCpy)
+	SetRayClusterWorkerGroupConfig(serveApp.Spec.MLClusterRef, serveApp.Spec.HFSecretRef, raySvcCpy)
+	if !reflect.DeepEqual(raySvc.Spec, raySvcCpy.Spec) {
+		logrus.Debugf("updating RayService: %s, spec:%v", raySvcCpy.Name, raySvcCpy.Spec)
+		if _, err = h.rayService.Update(raySvcCpy); err != nil {
+			return serveApp, err
+		}
+	}
+
+	return nil, nil
+}
+
+func (h *Handler) createServeApplicationPVCs(_ string, serveApp *mlv1.ServeApplication) (*mlv1.ServeApplication, error) {
+	if serveApp == nil || serveApp.DeletionTimestamp != nil {
+		return serveApp, nil
+	}
+
+	// the PVCs are named by the RayService, they are shared with the conflicted RayService otherwise
+	raySvc, err := h.rayServiceCache.Get(serveApp.Namespace, serveApp.Name)
+	if err != nil && !errors.IsNotFound(err) {
+		return serveApp, err
+	}
+	conflict, err := h.getServeApplicationConflict(serveApp, raySvc)
+	if err != nil || conflict != "" {
+		return serveApp, err
+	}
+
+	if err := h.createRayClusterPVCs(serveApp.Name, serveApp.Namespace, serveApp.Spec.MLClusterRef); err != nil {
+		return nil, err
+	}
+
+	return serveApp, nil
+}
+
+// getServeApplicationConflict returns the reason why the RayService of the application name can't be managed by
+// the application, it's empty if the name is free or the RayService is controlled by the application
+func (h *Handler) getServeApplicationConflict(serveApp *mlv1.ServeApplication, raySvc *rayv1.RayService) (string, error) {
+	if raySvc != nil && !isControlledByServeApplication(raySvc, serveApp) {
+		return fmt.Sprintf("RayService %s/%s is not owned by the application", raySvc.Namespace, raySvc.Name), nil
+	}
+
+	if _, err := h.mlServiceCache.Get(serveApp.Namespace, serveApp.Name); err == nil {
+		return fmt.Sprintf("MLService %s/%s of the same name exists", serveApp.Namespace, serveApp.Name), nil
+	} else if !errors.IsNotFound(err) {
+		return "", err
+	}
+	return "", nil
+}
+
+// isControlledByServeApplication checks the RayService is controlled by the application, the RayServices created
+// before the controller flag is set in the owner references are matched by the UID
+func isControlledByServeApplication(raySvc *rayv1.RayService, serveApp *mlv1.ServeApplication) bool {
+	if metav1.IsControlledBy(raySvc, serveApp) {
+		return true
+	}
+	for _, owner := range raySvc.OwnerReferences {
+		if owner.Kind == ServeApplicationKind && owner.UID == serveApp.UID && owner.Controller == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func generateServeApplicationOwnerReference(serveApp *mlv1.ServeApplication) []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		{
+			APIVersion: serveApp.APIVersion,
+			Kind:       serveApp.Kind,
+			Name:       serveApp.Name,
+			UID:        serveApp.UID,
+			Controller: pointer.Bool(true),
+		},
+	}
+}
+
+func (h *Handler) updateServeApplicationCondition(serveApp *mlv1.ServeApplication, cond condition.Cond, isTrue bool, message string) error {
+	if isTrue {
+		if cond.IsTrue(serveApp) {
+			return nil
+		}
+		cond.True(serveApp)
+	} else {
+		if cond.IsFalse(serveApp) && cond.GetMessage(serveApp) == message {
+			return nil
+		}
+		cond.False(serveApp)
+		cond.Message(serveApp, message)
+	}
+	serveAppCpy := serveApp.DeepCopy()
+	if _, err := h.serveApp.UpdateStatus(serveAppCpy); err != nil {
+		return err
+	}
+	return nil
+}
@@ -2,12 +2,17 @@ package mlservice
 
 import (
 	"fmt"
+	"time"
 
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 	yaml "gopkg.in/yaml.v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
 
 	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+	"github.com/oneblock-ai/oneblock/pkg/controller/modeltemplate"
+	"github.com/oneblock-ai/oneblock/pkg/utils"
 	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
 )
 
@@ -16,40 +21,82 @@ type ServeConfig struct {
 }
 
 type ServeApplication struct {
-	Name        string    `yaml:"name,omitempty"`
-	RoutePrefix string    `yaml:"route_prefix,omitempty"`
-	ImportPath  string    `yaml:"import_path,omitempty"`
-	Args        ServeArgs `yaml:"args,omitempty"`
+	Name        string            `yaml:"name,omitempty"`
+	RoutePrefix string            `yaml:"route_prefix,omitempty"`
+	ImportPath  string            `yaml:"import_path,omitempty"`
+	RuntimeEnv  *ServeRuntimeEnv  `yaml:"runtime_env,omitempty"`
+	Args        interface{}       `yaml:"args,omitempty"`
+	Deployments []ServeDeployment `yaml:"deployments,omitempty"`
+}
+
+type ServeRuntimeEnv struct {
+	WorkingDir string            `yaml:"working_dir,omitempty"`
+	Pip        []string          `yaml:"pip,omitempty"`
+	EnvVars    map[string]string `yaml:"env_vars,omitempty"`
+}
+
+type ServeDeployment struct {
+	Name                 string                `yaml:"name"`
+	NumReplicas          *int32                `yaml:"num_replicas,omitempty"`
+	MaxConcurrentQueries *int32                `yaml:"max_concurrent_queries,omitempty"`
+	UserConfig           interface{}           `yaml:"user_config,omitempty"`
+	RayActorOptions      *ServeRayActorOptions `yaml:"ray_actor_options,omitempty"`
+}
+
+type ServeRayActorOptions struct {
+	NumCPUs   *float64           `yaml:"num_cpus,omitempty"`
+	NumGPUs   *float64           `yaml:"num_gpus,omitempty"`
+	Memory    *int64             `yaml:"memory,omitempty"`
+	Resources map[string]float64 `yaml:"resources,omitempty"`
 }
 
 type ServeArgs struct {
-	Models []string `json:"models,omitempty"`
+	// Models are the paths of the mounted model config files or the inlined model configs
+	Models []interface{} `json:"models,omitempty"`
 }
 
-func getRayServiceConfig(mlService *mlv1.MLService, modelTmpVersion *mlv1.ModelTemplateVersion,
+func getRayServiceConfig(mlService *mlv1.MLService, modelTmpVersion *mlv1.ModelTemplateVersion, serveConfig string,
 	owners []metav1.OwnerReference, releaseName string) (*rayv1.RayService, error) {
+	rayClusterSpec, err := GetRayClusterSpecConfig(mlService.Name, mlService.Namespace, mlService.Spec.MLClusterRef,
+		mlService.Spec.HFSecretRef, modelTmpVersion, releaseName)
+	if err != nil {
+		return nil, err
+	}
+
+	raySvc := newRayService(mlService.Name, mlService.Namespace, owners, serveConfig, rayClusterSpec)
+	raySvc.Annotations[constant.AnnoModelTemplateVersionName] = modelTmpVersion.Name
+	return raySvc, nil
+}
 
-	serveConfig, err := getServeConfigV2(mlService.Name, getModelConfigPath(modelTmpVersion.Name))
+// getServeApplicationRayServiceConfig compiles the ServeApplication into a RayService
+func getServeApplicationRayServiceConfig(serveApp *mlv1.ServeApplication, owners []metav1.OwnerReference,
+	releaseName string) (*rayv1.RayService, error) {
+	serveConfig, err := getServeApplicationConfigV2(serveApp)
 	if err != nil {
 		return nil, err
 	}
 
-	rayClusterSpec, err := GetRayClusterSpecConfig(mlService, modelTmpVersion, releaseName)
+	rayClusterSpec, err := GetRayClusterSpecConfig(serveApp.Name, serveApp.Namespace, serveApp.Spec.MLClusterRef,
+		serveApp.Spec.HFSecretRef, nil, releaseName)
 	if err != nil {
 		return nil, err
 	}
 
-	raySvc := &rayv1.RayService{
+	return newRayService(serveApp.Name, serveApp.Namespace, owners, serveConfig, rayClusterSpec), nil
+}
+
+func newRayService(name, namespace string, owners []metav1.OwnerReference, serveConfig string,
+	rayClusterSpec *rayv1.RayClusterSpec) *rayv1.RayService {
+	return &rayv1.RayService{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      mlService.Name,
-			Namespace: mlService.Namespace,
+			Name:      name,
+			Namespace: namespace,
+			// the queue bound to the namespace is assigned by the webhook
 			Labels: map[string]string{
 				constant.LabelRaySchedulerName: constant.VolcanoSchedulerName,
-				constant.LabelVolcanoQueueName: constant.DefaultQueueName,
 			},
 			Annotations: map[string]string{
-				constant.AnnotationRayFTEnabledKey:    "true",
-				constant.AnnoModelTemplateVersionName: modelTmpVersion.Name,
+				constant.AnnotationRayFTEnabledKey: "true",
 			},
 			OwnerReferences: owners,
 		},
@@ -58,15 +105,37 @@ func getRayServiceConfig(mlService *mlv1.MLService, modelTmpVersion *mlv1.ModelT
 			RayClusterSpec: *rayClusterSpec,
 		},
 	}
-
-	return raySvc, nil
 }
 
 func getModelConfigPath(modelName string) string {
 	return fmt.Sprintf("./models/%s.yaml", modelName)
 }
 
-func getServeConfigV2(name, modelPath string) (string, error) {
+// getServeConfigV2 returns the RayLLM serve config of the model template version and the duration until the next
+// replica schedule window opens or closes, the duration is zero if the model has no replica schedules.
+func getServeConfigV2(name string, modelTmpVersion *mlv1.ModelTemplateVersion, now time.Time) (string, time.Duration, error) {
+	deploymentCfg := modelTmpVersion.Spec.DeploymentConfig
+	var model interface{} = getModelConfigPath(modelTmpVersion.Name)
+	var next time.Duration
+	// the mounted model config can't follow the schedule windows, the model config is inlined into the args
+	// with the scheduled min replicas instead
+	if len(deploymentCfg.Schedules) > 0 {
+		var (
+			minReplicas int32
+			err         error
+		)
+		if deploymentCfg.ScaleToZero == nil || !deploymentCfg.ScaleToZero.Enabled {
+			minReplicas = deploymentCfg.MinReplicas
+		}
+		minReplicas, next, err = utils.GetScheduledMinReplicas(minReplicas, deploymentCfg.Schedules, now)
+		if err != nil {
+			return "", 0, err
+		}
+		if model, err = getScheduledModelConfig(modelTmpVersion.Status.GeneratedModelConfig, minReplicas); err != nil {
+			return "", 0, err
+		}
+	}
+
 	serveCfg := &ServeConfig{
 		Applications: []ServeApplication{
 			{
@@ -74,8 +143,8 @@ func getServeConfigV2(name, modelPath string) (string, error) {
 				RoutePrefix: "/",
 				ImportPath:  "rayllm.backend:router_application",
 				Args: ServeArgs{
-					Models: []string{
-						modelPath,
+					Models: []interface{}{
+						model,
 					},
 				},
 			},
@@ -83,11 +152,105 @@ func getServeConfigV2(name, modelPath string) (string, error) {
 	}
 	serveCfgStr, err := yaml.Marshal(serveCfg)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal mlserve config: %v", err)
+		return "", 0, fmt.Errorf("failed to marshal mlserve config: %v", err)
+	}
+	return string(serveCfgStr), next, nil
+}
+
+// getScheduledModelConfig returns the generated model config with the min replicas of the open schedule windows
+func getScheduledModelConfig(generatedModelConfig string, minReplicas int32) (*modeltemplate.RayLLMModelConfig, error) {
+	modelConfig := &modeltemplate.RayLLMModelConfig{}
+	if err := yaml.Unmarshal([]byte(generatedModelConfig), modelConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse the generated model config: %v", err)
+	}
+
+	autoScalingConfig := &modelConfig.DeploymentConfig.AutoScalingConfig
+	autoScalingConfig.MinReplicas = minReplicas
+	if autoScalingConfig.InitialReplicas < minReplicas {
+		autoScalingConfig.InitialReplicas = minReplicas
+	}
+	return modelConfig, nil
+}
+
+// getServeApplicationConfigV2 returns the Ray Serve config of the ServeApplication
+func getServeApplicationConfigV2(serveApp *mlv1.ServeApplication) (string, error) {
+	app := ServeApplication{
+		Name:        serveApp.Name,
+		RoutePrefix: serveApp.Spec.RoutePrefix,
+		ImportPath:  serveApp.Spec.ImportPath,
+	}
+	if app.RoutePrefix == "" {
+		app.RoutePrefix = "/"
+	}
+
+	if env := serveApp.Spec.RuntimeEnv; env != nil {
+		app.RuntimeEnv = &ServeRuntimeEnv{
+			WorkingDir: env.WorkingDir,
+			Pip:        env.Pip,
+			EnvVars:    env.EnvVars,
+		}
+	}
+
+	args, err := rawExtensionToValue(serveApp.Spec.Args)
+	if err != nil {
+		return "", fmt.Errorf("invalid args of serve application %s: %v", serveApp.Name, err)
+	}
+	app.Args = args
+
+	for _, d := range serveApp.Spec.Deployments {
+		deployment := ServeDeployment{
+			Name:                 d.Name,
+			NumReplicas:          d.NumReplicas,
+			MaxConcurrentQueries: d.MaxConcurrentQueries,
+		}
+		if deployment.UserConfig, err = rawExtensionToValue(d.UserConfig); err != nil {
+			return "", fmt.Errorf("invalid user config of deployment %s: %v", d.Name, err)
+		}
+		if d.RayActorOptions != nil {
+			deployment.RayActorOptions = getServeRayActorOptions(d.RayActorOptions)
+		}
+		app.Deployments = append(app.Deployments, deployment)
+	}
+
+	serveCfgStr, err := yaml.Marshal(&ServeConfig{Applications: []ServeApplication{app}})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal serve application config: %v", err)
 	}
 	return string(serveCfgStr), nil
 }
 
+func getServeRayActorOptions(opts *mlv1.ServeRayActorOptions) *ServeRayActorOptions {
+	actorOpts := &ServeRayActorOptions{}
+	if opts.NumCPUs != nil {
+		actorOpts.NumCPUs = pointer.Float64(opts.NumCPUs.AsApproximateFloat64())
+	}
+	if opts.NumGPUs != nil {
+		actorOpts.NumGPUs = pointer.Float64(opts.NumGPUs.AsApproximateFloat64())
+	}
+	if opts.Memory != nil {
+		actorOpts.Memory = pointer.Int64(opts.Memory.Value())
+	}
+	if len(opts.Resources) > 0 {
+		actorOpts.Resources = make(map[string]float64, len(opts.Resources))
+		for name, quantity := range opts.Resources {
+			actorOpts.Resources[name] = quantity.AsApproximateFloat64()
+		}
+	}
+	return actorOpts
+}
+
+// rawExtensionToValue converts the raw JSON to a generic value, so it can be marshaled into the serve config YAML
+func rawExtensionToValue(raw *runtime.RawExtension) (interface{}, error) {
+	if raw == nil || len(raw.Raw) == 0 {
+		return nil, nil
+	}
+	var value interface{}
+	if err := yaml.Unmarshal(raw.Raw, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
 func GetModelConfigMapKey(modelTpmVersionName string) string {
 	return fmt.Sprintf("%s.yaml", modelTpmVersionName)
 }
@@ -0,0 +1,115 @@
+package mlservice
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+)
+
+func Test_getServeApplicationConfigV2(t *testing.T) {
+	cpu := resource.MustParse("100m")
+	serveApp := &mlv1.ServeApplication{
+		ObjectMeta: metav1.ObjectMeta{Name: "fruit-app", Namespace: "default"},
+		Spec: mlv1.ServeApplicationSpec{
+			ImportPath: "fruit.deployment_graph",
+			RuntimeEnv: &mlv1.ServeRuntimeEnv{
+				WorkingDir: "https://example.com/test_dag.zip",
+				Pip:        []string{"requests"},
+			},
+			Args: &runtime.RawExtension{Raw: []byte(`{"model":"foo"}`)},
+			Deployments: []mlv1.ServeDeployment{
+				{
+					Name:        "MangoStand",
+					NumReplicas: pointer.Int32(2),
+					UserConfig:  &runtime.RawExtension{Raw: []byte(`{"price":3}`)},
+					RayActorOptions: &mlv1.ServeRayActorOptions{
+						NumCPUs: &cpu,
+					},
+				},
+			},
+		},
+	}
+
+	config, err := getServeApplicationConfigV2(serveApp)
+	require.NoError(t, err)
+	assert.Equal(t, `applications:
+- name: fruit-app
+  route_prefix: /
+  import_path: fruit.deployment_graph
+  runtime_env:
+    working_dir: https://example.com/test_dag.zip
+    pip:
+    - requests
+  args:
+    model: foo
+  deployments:
+  - name: MangoStand
+    num_replicas: 2
+    user_config:
+      price: 3
+    ray_actor_options:
+      num_cpus: 0.1
+`, config)
+}
+
+func Test_getServeConfigV2Schedules(t *testing.T) {
+	modelTmpVersion := &mlv1.ModelTemplateVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: "yi-6b-v1", Namespace: "default"},
+		Spec: mlv1.ModelTemplateVersionSpec{
+			DeploymentConfig: mlv1.DeploymentConfig{
+				Replicas:    1,
+				MinReplicas: 1,
+				MaxReplicas: 4,
+			},
+		},
+		Status: mlv1.ModelTemplateVersionStatus{
+			GeneratedModelConfig: "deployment_config:\n  auto_scaling_config:\n    min_replicas: 1\n    max_replicas: 4\n" +
+				"    initial_replicas: 1\n",
+		},
+	}
+
+	config, next, err := getServeConfigV2("yi-6b", modelTmpVersion, time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, next)
+	assert.Contains(t, config, "./models/yi-6b-v1.yaml")
+
+	modelTmpVersion.Spec.DeploymentConfig.Schedules = []mlv1.ReplicaSchedule{
+		{
+			Name:           "business-hours",
+			ScheduleWindow: mlv1.ScheduleWindow{Start: "0 9 * * 1-5", Stop: "0 18 * * 1-5"},
+			MinReplicas:    3,
+		},
+	}
+	// Monday 10:00 UTC is in the business hours, the window closes in 8 hours
+	monday := time.Date(2024, 1, 8, 10, 0, 0, 0, time.UTC)
+	config, next, err = getServeConfigV2("yi-6b", modelTmpVersion, monday)
+	require.NoError(t, err)
+	assert.Equal(t, 8*time.Hour, next)
+	assert.NotContains(t, config, "./models/yi-6b-v1.yaml")
+	// the model config is inlined as a mapping instead of a string
+	assert.Contains(t, config, "  args:\n    models:\n    - deployment_config:\n        auto_scaling_config:\n          min_replicas: 3\n")
+	assert.Contains(t, config, "initial_replicas: 3")
+
+	// Saturday is out of the business hours, the window opens on Monday 09:00
+	saturday := time.Date(2024, 1, 13, 9, 0, 0, 0, time.UTC)
+	config, next, err = getServeConfigV2("yi-6b", modelTmpVersion, saturday)
+	require.NoError(t, err)
+	assert.Equal(t, 48*time.Hour, next)
+	assert.Contains(t, config, "min_replicas: 1")
+
+	// the overnight window opened on Friday is still open on Saturday morning
+	modelTmpVersion.Spec.DeploymentConfig.Schedules[0].Start = "0 20 * * 5"
+	modelTmpVersion.Spec.DeploymentConfig.Schedules[0].Stop = "0 10 * * 6"
+	config, next, err = getServeConfigV2("yi-6b", modelTmpVersion, saturday)
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, next)
+	assert.Contains(t, config, "min_replicas: 3")
+}
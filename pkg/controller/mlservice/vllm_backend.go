@@ -0,0 +1,427 @@
+package mlservice
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	yaml "gopkg.in/yaml.v2"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+	"github.com/oneblock-ai/oneblock/pkg/settings"
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+)
+
+const (
+	vllmServerPort          = 8000
+	vllmContainerName       = "vllm"
+	vllmHFCacheDir          = "/root/.cache/huggingface"
+	vllmApplySetID          = "mlservice-vllm"
+	defaultTargetQueueDepth = 5
+
+	vllmServiceStatusRunning = "Running"
+	vllmServiceStatusPending = "WaitForDeploymentReady"
+	vllmServiceStatusFailed  = "DeploymentFailed"
+)
+
+// vllmBackend serves the model by a plain vLLM OpenAI server Deployment, Service and HPA,
+// it skips the overhead of the Ray head, Redis GCS and autoscaler for the small single-node models
+type vllmBackend struct {
+	*Handler
+}
+
+func (b *vllmBackend) Serve(mlService *mlv1.MLService, modelTmpVersion *mlv1.ModelTemplateVersion) error {
+	// the scale-to-zero config is ignored since the HPA can't scale the Deployment to zero
+	deploymentCfg := modelTmpVersion.Spec.DeploymentConfig
+	minReplicas, next, err := utils.GetScheduledMinReplicas(deploymentCfg.MinReplicas, deploymentCfg.Schedules, time.Now())
+	if err != nil {
+		return err
+	}
+	// re-sync the HPA when the next replica schedule window opens or closes
+	if next > 0 {
+		b.mlService.EnqueueAfter(mlService.Namespace, mlService.Name, next)
+	}
+
+	objs, err := getVLLMObjects(mlService, modelTmpVersion, minReplicas)
+	if err != nil {
+		return err
+	}
+
+	return b.apply.
+		WithDynamicLookup().
+		WithOwner(mlService).
+		WithSetOwnerReference(true, false).
+		WithSetID(vllmApplySetID).
+		ApplyObjects(objs...)
+}
+
+func (b *vllmBackend) Cleanup(mlService *mlv1.MLService) error {
+	if _, err := b.deploymentCache.Get(mlService.Namespace, mlService.Name); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	// apply an empty object set to remove the vLLM deployment, service and HPA
+	return b.apply.
+		WithDynamicLookup().
+		WithOwner(mlService).
+		WithSetID(vllmApplySetID).
+		ApplyObjects()
+}
+
+func (b *vllmBackend) CreatePVCs(mlService *mlv1.MLService) error {
+	vllmConfig := mlService.Spec.VLLMConfig
+	if vllmConfig == nil || vllmConfig.Volume == nil {
+		return nil
+	}
+
+	// skip PVC garbage collection by not setting its ownerRefs
+	return b.pvcHandler.CreatePVCByVolume([]mlv1.Volume{*vllmConfig.Volume}, mlService.Namespace, nil)
+}
+
+func (b *vllmBackend) Endpoint(mlService *mlv1.MLService) string {
+	return fmt.Sprintf("http://%s.%s.svc.cluster.local:%d/v1", mlService.Name, mlService.Namespace, vllmServerPort)
+}
+
+func (h *Handler) syncVLLMDeploymentStatus(_ string, deployment *appsv1.Deployment) (*appsv1.Deployment, error) {
+	if deployment == nil || deployment.DeletionTimestamp != nil {
+		return nil, nil
+	}
+
+	ownerRef := getOwnerByKind(deployment.OwnerReferences, MLServiceKind)
+	if ownerRef == nil {
+		return nil, nil
+	}
+
+	mlService, err := h.mlServiceCache.Get(deployment.Namespace, ownerRef.Name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return deployment, err
+	}
+
+	if getBackendType(mlService) != mlv1.MLServiceBackendVLLM {
+		return nil, nil
+	}
+
+	serviceStatus, ready := getVLLMServiceStatus(deployment)
+	endpoint := h.backends[mlv1.MLServiceBackendVLLM].Endpoint(mlService)
+	if mlService.Status.ServiceStatus == serviceStatus && mlService.Status.Endpoint == endpoint {
+		return nil, nil
+	}
+
+	mlServiceCpy := mlService.DeepCopy()
+	mlServiceCpy.Status.RayServiceStatuses = rayv1.RayServiceStatuses{}
+	setServingStatus(mlServiceCpy, serviceStatus, endpoint, ready)
+	if _, err = h.mlService.UpdateStatus(mlServiceCpy); err != nil {
+		return deployment, err
+	}
+
+	return nil, nil
+}
+
+func getVLLMServiceStatus(deployment *appsv1.Deployment) (string, bool) {
+	for _, cond := range deployment.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing && cond.Status == corev1.ConditionFalse {
+			return vllmServiceStatusFailed, false
+		}
+	}
+
+	if deployment.Status.AvailableReplicas > 0 {
+		return vllmServiceStatusRunning, true
+	}
+	return vllmServiceStatusPending, false
+}
+
+func getVLLMObjects(mlService *mlv1.MLService, modelTmpVersion *mlv1.ModelTemplateVersion,
+	minReplicas int32) ([]runtime.Object, error) {
+	vllmConfig := mlService.Spec.VLLMConfig
+	if vllmConfig == nil {
+		vllmConfig = &mlv1.VLLMConfig{}
+	}
+
+	args, err := getVLLMArgs(modelTmpVersion, vllmConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := map[string]string{
+		constant.LabelMLServiceName: mlService.Name,
+	}
+
+	deploymentCfg := modelTmpVersion.Spec.DeploymentConfig
+	if minReplicas < 1 {
+		minReplicas = 1
+	}
+	enableHPA := deploymentCfg.MaxReplicas > minReplicas
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mlService.Name,
+			Namespace: mlService.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: getVLLMPodSpec(mlService, modelTmpVersion, vllmConfig, args),
+			},
+		},
+	}
+	// the replicas are managed by the HPA if autoscaling is enabled
+	if !enableHPA {
+		replicas := deploymentCfg.Replicas
+		if replicas < minReplicas {
+			replicas = minReplicas
+		}
+		deployment.Spec.Replicas = pointer.Int32(replicas)
+	}
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mlService.Name,
+			Namespace: mlService.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeClusterIP,
+			Selector: labels,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       vllmServerPort,
+					TargetPort: intstr.FromInt(vllmServerPort),
+				},
+			},
+		},
+	}
+
+	objs := []runtime.Object{deployment, service}
+	if enableHPA {
+		objs = append(objs, getVLLMHPA(mlService, labels, minReplicas, deploymentCfg.MaxReplicas, vllmConfig))
+	}
+
+	return objs, nil
+}
+
+func getVLLMPodSpec(mlService *mlv1.MLService, modelTmpVersion *mlv1.ModelTemplateVersion, vllmConfig *mlv1.VLLMConfig,
+	args []string) corev1.PodSpec {
+	image := vllmConfig.Image
+	if image == "" {
+		image = settings.VLLMImage.Get()
+	}
+
+	container := corev1.Container{
+		Name:  vllmContainerName,
+		Image: image,
+		Args:  args,
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          "http",
+				ContainerPort: vllmServerPort,
+			},
+		},
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path: "/health",
+					Port: intstr.FromInt(vllmServerPort),
+				},
+			},
+			PeriodSeconds: 10,
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      "dshm",
+				MountPath: "/dev/shm",
+			},
+		},
+	}
+	if vllmConfig.Resources != nil {
+		container.Resources = *vllmConfig.Resources.DeepCopy()
+	}
+	setVLLMGPULimit(&container.Resources, modelTmpVersion.Spec.ScalingConfig.NumWorkers)
+
+	// the SyncClusterSecretsToLocalNS method will helps to sync the HF secret to the local namespace
+	if hfRef := mlService.Spec.HFSecretRef; hfRef != nil {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name: huggingFaceHubTokenEnvName,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: hfRef.Name,
+					},
+					Key: hfRef.SecretKey,
+				},
+			},
+		})
+	}
+
+	podSpec := corev1.PodSpec{
+		Volumes: []corev1.Volume{
+			{
+				Name: "dshm",
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{
+						Medium: corev1.StorageMediumMemory,
+					},
+				},
+			},
+		},
+		NodeSelector:     vllmConfig.NodeSelector,
+		Tolerations:      vllmConfig.Tolerations,
+		RuntimeClassName: vllmConfig.RuntimeClassName,
+	}
+
+	if vllmConfig.Volume != nil {
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: vllmConfig.Volume.Name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: vllmConfig.Volume.Name,
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      vllmConfig.Volume.Name,
+			MountPath: vllmHFCacheDir,
+		})
+	}
+
+	podSpec.Containers = []corev1.Container{container}
+	return podSpec
+}
+
+// setVLLMGPULimit requests a GPU for each tensor parallel worker if no accelerator is set in the resource limits,
+// the requests of the extended resources default to the limits
+func setVLLMGPULimit(resources *corev1.ResourceRequirements, numWorkers int32) {
+	for name := range resources.Limits {
+		if utils.IsAcceleratorResource(name) {
+			return
+		}
+	}
+	if numWorkers < 1 {
+		numWorkers = 1
+	}
+	if resources.Limits == nil {
+		resources.Limits = corev1.ResourceList{}
+	}
+	resources.Limits[utils.ResourceNvidiaGPU] = *resource.NewQuantity(int64(numWorkers), resource.DecimalSI)
+}
+
+// getVLLMHPA returns the HPA scaling on the queue depth of the vLLM server, the GPU-bound server is saturated
+// long before its CPU, the queue depth metric is served by the custom metrics API, e.g., the prometheus adapter
+func getVLLMHPA(mlService *mlv1.MLService, labels map[string]string, minReplicas, maxReplicas int32,
+	vllmConfig *mlv1.VLLMConfig) *autoscalingv2.HorizontalPodAutoscaler {
+	target := int64(defaultTargetQueueDepth)
+	if vllmConfig.TargetQueueDepth != nil {
+		target = int64(*vllmConfig.TargetQueueDepth)
+	}
+
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mlService.Name,
+			Namespace: mlService.Namespace,
+			Labels:    labels,
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: appsv1.SchemeGroupVersion.String(),
+				Kind:       "Deployment",
+				Name:       mlService.Name,
+			},
+			MinReplicas: pointer.Int32(minReplicas),
+			MaxReplicas: maxReplicas,
+			Metrics: []autoscalingv2.MetricSpec{
+				{
+					Type: autoscalingv2.PodsMetricSourceType,
+					Pods: &autoscalingv2.PodsMetricSource{
+						Metric: autoscalingv2.MetricIdentifier{
+							Name: settings.VLLMQueueDepthMetric.Get(),
+						},
+						Target: autoscalingv2.MetricTarget{
+							Type:         autoscalingv2.AverageValueMetricType,
+							AverageValue: resource.NewQuantity(target, resource.DecimalSI),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// getVLLMArgs returns the vLLM OpenAI server arguments of the model, the engine kwargs(vLLMArgs) of the model
+// template version are converted to the command line flags, e.g., max_model_len: 4096 => --max-model-len=4096
+func getVLLMArgs(modelTmpVersion *mlv1.ModelTemplateVersion, vllmConfig *mlv1.VLLMConfig) ([]string, error) {
+	spec := modelTmpVersion.Spec
+	if spec.MirrorConfig != "" {
+		return nil, fmt.Errorf("mirror config of model %s is not supported by the %s backend", spec.ModelID, mlv1.MLServiceBackendVLLM)
+	}
+
+	model := spec.HFModelID
+	if model == "" {
+		model = spec.ModelID
+	}
+
+	args := []string{
+		"--model", model,
+		"--served-model-name", spec.ModelID,
+		"--host", "0.0.0.0",
+		"--port", strconv.Itoa(vllmServerPort),
+	}
+	if spec.ScalingConfig.NumWorkers > 1 {
+		args = append(args, "--tensor-parallel-size", strconv.Itoa(int(spec.ScalingConfig.NumWorkers)))
+	}
+
+	engineKwargs := map[string]interface{}{}
+	if spec.EngineConfig.VLLMArgs != "" {
+		if err := yaml.Unmarshal([]byte(spec.EngineConfig.VLLMArgs), &engineKwargs); err != nil {
+			return nil, fmt.Errorf("failed to convert vllmArgs, error: %s", err.Error())
+		}
+	}
+	if _, ok := engineKwargs["max_model_len"]; !ok && spec.EngineConfig.MaxTotalTokens > 0 {
+		engineKwargs["max_model_len"] = spec.EngineConfig.MaxTotalTokens
+	}
+
+	keys := make([]string, 0, len(engineKwargs))
+	for k := range engineKwargs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		flag := "--" + strings.ReplaceAll(k, "_", "-")
+		switch v := engineKwargs[k].(type) {
+		case bool:
+			if v {
+				args = append(args, flag)
+			}
+		case nil:
+			continue
+		default:
+			args = append(args, fmt.Sprintf("%s=%v", flag, v))
+		}
+	}
+
+	return append(args, vllmConfig.Args...), nil
+}
@@ -0,0 +1,103 @@
+package mlservice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+)
+
+func Test_getVLLMArgs(t *testing.T) {
+	modelTmpVersion := &mlv1.ModelTemplateVersion{
+		Spec: mlv1.ModelTemplateVersionSpec{
+			ModelID: "01-ai/Yi-6B-Chat",
+			EngineConfig: mlv1.EngineConfig{
+				MaxTotalTokens: 4096,
+				VLLMArgs:       "trust_remote_code: true\ngpu_memory_utilization: 0.9\nenforce_eager: false\n",
+			},
+			ScalingConfig: mlv1.ScalingConfig{NumWorkers: 2},
+		},
+	}
+
+	args, err := getVLLMArgs(modelTmpVersion, &mlv1.VLLMConfig{Args: []string{"--dtype=half"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"--model", "01-ai/Yi-6B-Chat",
+		"--served-model-name", "01-ai/Yi-6B-Chat",
+		"--host", "0.0.0.0",
+		"--port", "8000",
+		"--tensor-parallel-size", "2",
+		"--gpu-memory-utilization=0.9",
+		"--max-model-len=4096",
+		"--trust-remote-code",
+		"--dtype=half",
+	}, args)
+
+	modelTmpVersion.Spec.MirrorConfig = "s3://models/yi-6b"
+	_, err = getVLLMArgs(modelTmpVersion, &mlv1.VLLMConfig{})
+	assert.Error(t, err)
+}
+
+func Test_getVLLMObjects(t *testing.T) {
+	mlService := &mlv1.MLService{
+		ObjectMeta: metav1.ObjectMeta{Name: "yi-6b", Namespace: "default"},
+		Spec: mlv1.MLServiceSpec{
+			Backend: mlv1.MLServiceBackendVLLM,
+		},
+	}
+	modelTmpVersion := &mlv1.ModelTemplateVersion{
+		Spec: mlv1.ModelTemplateVersionSpec{
+			ModelID: "01-ai/Yi-6B-Chat",
+			DeploymentConfig: mlv1.DeploymentConfig{
+				Replicas:    1,
+				MinReplicas: 1,
+				MaxReplicas: 1,
+			},
+		},
+	}
+
+	objs, err := getVLLMObjects(mlService, modelTmpVersion, 1)
+	require.NoError(t, err)
+	require.Len(t, objs, 2)
+	deployment := objs[0].(*appsv1.Deployment)
+	assert.Equal(t, int32(1), *deployment.Spec.Replicas)
+	// a GPU is requested by default
+	limits := deployment.Spec.Template.Spec.Containers[0].Resources.Limits
+	assert.Equal(t, "1", limits.Name(utils.ResourceNvidiaGPU, resource.DecimalSI).String())
+
+	// the accelerator set in the resources is kept
+	mlService.Spec.VLLMConfig = &mlv1.VLLMConfig{
+		Resources: &corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{"amd.com/gpu": resource.MustParse("2")},
+		},
+	}
+	objs, err = getVLLMObjects(mlService, modelTmpVersion, 1)
+	require.NoError(t, err)
+	limits = objs[0].(*appsv1.Deployment).Spec.Template.Spec.Containers[0].Resources.Limits
+	assert.Len(t, limits, 1)
+	assert.Equal(t, "2", limits.Name("amd.com/gpu", resource.DecimalSI).String())
+
+	// the replicas are managed by the HPA if the autoscaling range is specified
+	modelTmpVersion.Spec.DeploymentConfig.MaxReplicas = 3
+	objs, err = getVLLMObjects(mlService, modelTmpVersion, 1)
+	require.NoError(t, err)
+	require.Len(t, objs, 3)
+	assert.Nil(t, objs[0].(*appsv1.Deployment).Spec.Replicas)
+	hpa := objs[2].(*autoscalingv2.HorizontalPodAutoscaler)
+	assert.Equal(t, int32(1), *hpa.Spec.MinReplicas)
+	assert.Equal(t, int32(3), hpa.Spec.MaxReplicas)
+	// the HPA scales on the queue depth instead of the CPU utilization
+	if assert.Len(t, hpa.Spec.Metrics, 1) {
+		assert.Equal(t, autoscalingv2.PodsMetricSourceType, hpa.Spec.Metrics[0].Type)
+		assert.Equal(t, "vllm_num_requests_waiting", hpa.Spec.Metrics[0].Pods.Metric.Name)
+		assert.Equal(t, "5", hpa.Spec.Metrics[0].Pods.Target.AverageValue.String())
+	}
+}
@@ -2,6 +2,7 @@ package modeltemplate
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	yaml "gopkg.in/yaml.v3"
@@ -58,6 +59,7 @@ type DeploymentConfig struct {
 	AutoScalingConfig    AutoScalingConfig `yaml:"auto_scaling_config"`
 	MaxConcurrentQueries int32             `yaml:"max_concurrent_queries"`
 	RayActorOptions      RayActorOptions   `yaml:"ray_actor_options"`
+	HealthCheckTimeoutS  int32             `yaml:"health_check_timeout_s,omitempty"`
 }
 
 type RayActorOptions struct {
@@ -78,11 +80,21 @@ type AutoScalingConfig struct {
 
 const (
 	MaxConcurrentRatio = 40
+
+	defaultMetricsIntervalS = 10
+	defaultLookBackPeriodS  = 30
+	defaultSmoothingFactor  = 0.6
+	defaultDownscaleDelayS  = 300
+	defaultUpscaleDelayS    = 60
 )
 
 func generateRayLLMModelConfig(modelTmpVersion *mlv1.ModelTemplateVersion) (string, error) {
 	rayLLMModelConfig := RayLLMModelConfig{}
-	rayLLMModelConfig.DeploymentConfig = setDeploymentConfig(modelTmpVersion)
+	deploymentConfig, err := setDeploymentConfig(modelTmpVersion)
+	if err != nil {
+		return "", err
+	}
+	rayLLMModelConfig.DeploymentConfig = deploymentConfig
 	rayLLMModelConfig.ScalingConfig = setScalingConfig(modelTmpVersion)
 
 	engineConfig, err := setEngineConfig(modelTmpVersion)
@@ -177,27 +189,56 @@ func setScalingConfig(model *mlv1.ModelTemplateVersion) ScalingConfig {
 	}
 }
 
-func setDeploymentConfig(model *mlv1.ModelTemplateVersion) DeploymentConfig {
+func setDeploymentConfig(model *mlv1.ModelTemplateVersion) (DeploymentConfig, error) {
 	modelDeploymentConfig := model.Spec.DeploymentConfig
 	maxConcurrentQueries := modelDeploymentConfig.MaxConcurrentQueries
 	if modelDeploymentConfig.TargetNumOngoingRequests == 0 {
-		modelDeploymentConfig.TargetNumOngoingRequests = (maxConcurrentQueries * MaxConcurrentRatio) / 100
+		ratio := modelDeploymentConfig.TargetOngoingRequestsRatio
+		if ratio == 0 {
+			ratio = MaxConcurrentRatio
+		}
+		modelDeploymentConfig.TargetNumOngoingRequests = (maxConcurrentQueries * ratio) / 100
+	}
+
+	smoothingFactor := float32(defaultSmoothingFactor)
+	if modelDeploymentConfig.SmoothingFactor != "" {
+		factor, err := strconv.ParseFloat(modelDeploymentConfig.SmoothingFactor, 32)
+		if err != nil {
+			return DeploymentConfig{}, fmt.Errorf("invalid smoothing factor %s, error: %s",
+				modelDeploymentConfig.SmoothingFactor, err.Error())
+		}
+		smoothingFactor = float32(factor)
 	}
+
 	deploymentConfig := DeploymentConfig{
 		AutoScalingConfig: AutoScalingConfig{
 			MinReplicas:                        modelDeploymentConfig.MinReplicas,
 			MaxReplicas:                        modelDeploymentConfig.MaxReplicas,
 			InitialReplicas:                    modelDeploymentConfig.Replicas,
 			TargetNumOngoingRequestsPerReplica: modelDeploymentConfig.TargetNumOngoingRequests,
-			MetricsIntervalS:                   10.0,
-			LookBackPeriodS:                    30.0,
-			SmoothingFactor:                    0.6,
-			DownscaleDelayS:                    300.0,
-			UpscaleDelayS:                      60.0,
+			MetricsIntervalS:                   getSeconds(modelDeploymentConfig.MetricsIntervalS, defaultMetricsIntervalS),
+			LookBackPeriodS:                    getSeconds(modelDeploymentConfig.LookBackPeriodS, defaultLookBackPeriodS),
+			SmoothingFactor:                    smoothingFactor,
+			DownscaleDelayS:                    getSeconds(modelDeploymentConfig.DownscaleDelayS, defaultDownscaleDelayS),
+			UpscaleDelayS:                      getSeconds(modelDeploymentConfig.UpscaleDelayS, defaultUpscaleDelayS),
 		},
 		MaxConcurrentQueries: maxConcurrentQueries,
 	}
-	return deploymentConfig
+
+	// the replicas are scaled down to zero when there is no traffic, the first request waits for the cold start
+	// which loads the model before the replica passes its health check
+	if scaleToZero := modelDeploymentConfig.ScaleToZero; scaleToZero != nil && scaleToZero.Enabled {
+		deploymentConfig.AutoScalingConfig.MinReplicas = 0
+		deploymentConfig.HealthCheckTimeoutS = scaleToZero.ColdStartTimeoutS
+	}
+	return deploymentConfig, nil
+}
+
+func getSeconds(seconds *int32, defaultSeconds float32) float32 {
+	if seconds == nil {
+		return defaultSeconds
+	}
+	return float32(*seconds)
 }
 
 func setPrivateModel(engineConfig *EngineConfig, model *mlv1.ModelTemplateVersion) error {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
 	"sync"
 
 	"github.com/sirupsen/logrus"
@@ -213,19 +214,34 @@ func (h *TemplateHandler) AssignVersion(_ string, tpv *mlv1.ModelTemplateVersion
 		}
 	}
 
-	if _, err = h.templateVersionClient.Update(tpvCopy); err != nil {
+	// the imported version number is only honored once, strip the annotation after reading it
+	importedVersion, err := h.getImportedVersion(tpv)
+	if err != nil {
+		return tpv, err
+	}
+	delete(tpvCopy.Annotations, constant.AnnotationModelTemplateVersion)
+
+	updated, err := h.templateVersionClient.Update(tpvCopy)
+	if err != nil {
 		return tpv, err
 	}
 
-	// assign version
-	tpvCopy.Status.Version = h.latestVersionMap[utils.NewRef(tp.Namespace, tp.Name)] + 1
+	// assign version, the version imported from a bundle keeps its version number
+	ref := utils.NewRef(tp.Namespace, tp.Name)
+	tpvCopy = updated.DeepCopy()
+	tpvCopy.Status.Version = importedVersion
+	if tpvCopy.Status.Version == 0 {
+		tpvCopy.Status.Version = h.latestVersionMap[ref] + 1
+	}
 	mlv1.ModelTemplateVersionAssigned.True(&tpvCopy.Status)
 	if tpv, err = h.templateVersionClient.UpdateStatus(tpvCopy); err != nil {
 		return tpv, err
 	}
 
 	h.mutex.Lock()
-	h.latestVersionMap[utils.NewRef(tp.Namespace, tp.Name)]++
+	if tpvCopy.Status.Version > h.latestVersionMap[ref] {
+		h.latestVersionMap[ref] = tpvCopy.Status.Version
+	}
 	h.mutex.Unlock()
 	logrus.Debugf("assigned new version: %v", h.latestVersionMap)
 
@@ -234,6 +250,34 @@ func (h *TemplateHandler) AssignVersion(_ string, tpv *mlv1.ModelTemplateVersion
 	return nil, nil
 }
 
+// getImportedVersion returns the version number of the imported template version, it returns 0 if the version
+// isn't imported, the number is invalid or the number is already taken by another version of the template
+func (h *TemplateHandler) getImportedVersion(tpv *mlv1.ModelTemplateVersion) (int, error) {
+	value, ok := tpv.Annotations[constant.AnnotationModelTemplateVersion]
+	if !ok {
+		return 0, nil
+	}
+	version, err := strconv.Atoi(value)
+	if err != nil || version <= 0 {
+		logrus.Warnf("invalid imported version %s of template version %s/%s, assigning a new version",
+			value, tpv.Namespace, tpv.Name)
+		return 0, nil
+	}
+
+	tpvs, err := h.templateVersionCache.List(tpv.Namespace, labels.Everything())
+	if err != nil {
+		return 0, err
+	}
+	for _, v := range tpvs {
+		if v.Name != tpv.Name && v.Spec.TemplateName == tpv.Spec.TemplateName && v.Status.Version == version {
+			logrus.Warnf("imported version %d of template version %s/%s is taken by %s, assigning a new version",
+				version, tpv.Namespace, tpv.Name, v.Name)
+			return 0, nil
+		}
+	}
+	return version, nil
+}
+
 // templateVersionByCreationTimestamp sorts a list of TemplateVersion by creation timestamp, using their names as a tie breaker.
 type templateVersionByCreationTimestamp []*mlv1.ModelTemplateVersion
 
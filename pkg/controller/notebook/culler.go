@@ -0,0 +1,268 @@
+package notebook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	ctlcorev1 "github.com/rancher/wrangler/v2/pkg/generated/controllers/core/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+	ctlmlv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ml.oneblock.ai/v1"
+	"github.com/oneblock-ai/oneblock/pkg/settings"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+)
+
+// Note: the idle culling is referred to the kubeflow notebook culler
+// https://github.com/kubeflow/kubeflow/blob/master/components/notebook-controller/controllers/culling_controller.go
+
+const (
+	cullCheckPeriod    = time.Minute
+	cullRequestTimeout = 10 * time.Second
+	kernelStateBusy    = "busy"
+
+	EventReasonIdleCulled = "IdleCulled"
+)
+
+// idleCuller stops the running notebooks which have no user activity for the idle time, the activity is
+// queried from the notebook server through the notebook service
+type idleCuller struct {
+	httpClient   *http.Client
+	notebooks    ctlmlv1.NotebookClient
+	secretCache  ctlcorev1.SecretCache
+	recorder     record.EventRecorder
+	enqueueAfter func(namespace, name string, duration time.Duration)
+	// serverURL returns the base URL of the notebook server
+	serverURL func(notebook *mlv1.Notebook) string
+}
+
+type jupyterStatus struct {
+	LastActivity time.Time `json:"last_activity"`
+}
+
+type jupyterKernel struct {
+	LastActivity   time.Time `json:"last_activity"`
+	ExecutionState string    `json:"execution_state"`
+}
+
+type codeServerHeartbeat struct {
+	LastHeartbeat int64 `json:"lastHeartbeat"`
+}
+
+func newIdleCuller(notebooks ctlmlv1.NotebookController, secretCache ctlcorev1.SecretCache,
+	recorder record.EventRecorder) *idleCuller {
+	return &idleCuller{
+		httpClient:   &http.Client{Timeout: cullRequestTimeout},
+		notebooks:    notebooks,
+		secretCache:  secretCache,
+		recorder:     recorder,
+		enqueueAfter: notebooks.EnqueueAfter,
+		serverURL:    GetNotebookServerURL,
+	}
+}
+
+func (c *idleCuller) OnChanged(_ string, notebook *mlv1.Notebook) (*mlv1.Notebook, error) {
+	if notebook == nil || notebook.DeletionTimestamp != nil || isNotebookStopped(notebook) {
+		return notebook, nil
+	}
+
+	idleTime := getCullIdleTime(notebook)
+	if idleTime <= 0 {
+		return notebook, nil
+	}
+
+	// check the activity of the notebook periodically
+	c.enqueueAfter(notebook.Namespace, notebook.Name, cullCheckPeriod)
+	readySince := getNotebookReadySince(notebook)
+	if notebook.Status.ReadyReplicas == 0 || readySince == nil {
+		return notebook, nil
+	}
+
+	lastActivity, err := c.getLastActivity(notebook)
+	if err != nil {
+		logrus.Warnf("Failed to get the activity of notebook %s/%s: %v", notebook.Namespace, notebook.Name, err)
+		return notebook, nil
+	}
+	if lastActivity == nil {
+		return notebook, nil
+	}
+	// the notebook is idle since it gets ready at the earliest, neither the activity before it is stopped nor the
+	// zero activity reported before any connection culls the restarted notebook
+	if lastActivity.Before(*readySince) {
+		lastActivity = readySince
+	}
+
+	if notebook.Status.LastActivity == nil || lastActivity.After(notebook.Status.LastActivity.Time) {
+		nbCpy := notebook.DeepCopy()
+		nbCpy.Status.LastActivity = &metav1.Time{Time: *lastActivity}
+		updated, err := c.notebooks.UpdateStatus(nbCpy)
+		if err != nil {
+			return notebook, err
+		}
+		notebook = updated
+	}
+
+	idle := time.Since(notebook.Status.LastActivity.Time)
+	if idle < idleTime {
+		return notebook, nil
+	}
+
+	logrus.Infof("Stopping notebook %s/%s which is idle for %s", notebook.Namespace, notebook.Name, idle.Round(time.Second))
+	nbCpy := notebook.DeepCopy()
+	if nbCpy.Annotations == nil {
+		nbCpy.Annotations = make(map[string]string, 1)
+	}
+	nbCpy.Annotations[constant.AnnotationResourceStopped] = time.Now().UTC().Format(time.RFC3339)
+	updated, err := c.notebooks.Update(nbCpy)
+	if err != nil {
+		return notebook, err
+	}
+
+	c.recorder.Eventf(updated, corev1.EventTypeNormal, EventReasonIdleCulled,
+		"Notebook is stopped since it has been idle from %s", notebook.Status.LastActivity.UTC().Format(time.RFC3339))
+	return updated, nil
+}
+
+// getCullIdleTime returns the idle time of the notebook to be culled, the annotation of the notebook
+// overrides the setting, a non-positive value disables the culling
+func getCullIdleTime(notebook *mlv1.Notebook) time.Duration {
+	minutes := settings.NotebookCullIdleTime.GetInt()
+	if value, ok := notebook.Annotations[constant.AnnotationNotebookCullIdleTime]; ok {
+		m, err := strconv.Atoi(value)
+		if err != nil {
+			logrus.Warnf("Invalid cull idle time %s of notebook %s/%s, using the default %d minutes",
+				value, notebook.Namespace, notebook.Name, minutes)
+		} else {
+			minutes = m
+		}
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// getNotebookReadySince returns the time the notebook pod gets ready, it returns nil if the pod is not ready
+func getNotebookReadySince(notebook *mlv1.Notebook) *time.Time {
+	for _, c := range notebook.Status.Conditions {
+		if string(c.Type) != string(corev1.PodReady) || c.Status != metav1.ConditionTrue {
+			continue
+		}
+		readySince, err := time.Parse(time.RFC3339, c.LastTransitionTime)
+		if err != nil {
+			return nil
+		}
+		return &readySince
+	}
+	return nil
+}
+
+// getLastActivity returns the last activity time reported by the notebook server, the time is zero if no activity
+// is reported yet, and it returns nil if the notebook type doesn't support the activity query
+func (c *idleCuller) getLastActivity(notebook *mlv1.Notebook) (*time.Time, error) {
+	switch {
+	case isJupyterNotebook(notebook):
+		return c.getJupyterLastActivity(notebook)
+	case notebook.Labels[constant.LabelNotebookType] == string(mlv1.NotebookTypeCodeServer):
+		return c.getCodeServerLastActivity(notebook)
+	default:
+		return nil, nil
+	}
+}
+
+func (c *idleCuller) getJupyterLastActivity(notebook *mlv1.Notebook) (*time.Time, error) {
+	token, err := c.getJupyterToken(notebook)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := c.serverURL(notebook) + GetNotebookBasePath(notebook)
+	kernels := make([]jupyterKernel, 0)
+	if err := c.getJSON(baseURL+"/api/kernels", token, &kernels); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var lastActivity time.Time
+	for _, kernel := range kernels {
+		// the notebook is in use while any of the kernels is running
+		if kernel.ExecutionState == kernelStateBusy {
+			return &now, nil
+		}
+		if kernel.LastActivity.After(lastActivity) {
+			lastActivity = kernel.LastActivity
+		}
+	}
+
+	status := &jupyterStatus{}
+	if err := c.getJSON(baseURL+"/api/status", token, status); err != nil {
+		return nil, err
+	}
+	if status.LastActivity.After(lastActivity) {
+		lastActivity = status.LastActivity
+	}
+	return &lastActivity, nil
+}
+
+func (c *idleCuller) getCodeServerLastActivity(notebook *mlv1.Notebook) (*time.Time, error) {
+	heartbeat := &codeServerHeartbeat{}
+	// the heartbeat of the code-server doesn't require the authentication
+	if err := c.getJSON(c.serverURL(notebook)+"/healthz", "", heartbeat); err != nil {
+		return nil, err
+	}
+	// the heartbeat is zero until any client connects to the code-server
+	var lastActivity time.Time
+	if heartbeat.LastHeartbeat > 0 {
+		lastActivity = time.UnixMilli(heartbeat.LastHeartbeat)
+	}
+	return &lastActivity, nil
+}
+
+// getJupyterToken returns the token of the jupyter server set by the token env of the notebook container, either
+// by the value or the secret, it returns an empty string if the token is not set
+func (c *idleCuller) getJupyterToken(notebook *mlv1.Notebook) (string, error) {
+	containers := notebook.Spec.Template.Spec.Containers
+	if len(containers) == 0 {
+		return "", nil
+	}
+	for _, env := range containers[0].Env {
+		if env.Name != TokenEnvVar {
+			continue
+		}
+		if env.ValueFrom == nil || env.ValueFrom.SecretKeyRef == nil {
+			return env.Value, nil
+		}
+		ref := env.ValueFrom.SecretKeyRef
+		secret, err := c.secretCache.Get(notebook.Namespace, ref.Name)
+		if err != nil {
+			return "", fmt.Errorf("failed to get the token secret %s: %v", ref.Name, err)
+		}
+		return string(secret.Data[ref.Key]), nil
+	}
+	return "", nil
+}
+
+// getJSON requests the notebook server with the token and decodes the JSON response
+func (c *idleCuller) getJSON(url, token string, obj interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "token "+token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d of %s", resp.StatusCode, url)
+	}
+	return json.NewDecoder(resp.Body).Decode(obj)
+}
@@ -0,0 +1,194 @@
+package notebook
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	mgmtv1 "github.com/oneblock-ai/oneblock/pkg/apis/management.oneblock.ai/v1"
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+	"github.com/oneblock-ai/oneblock/pkg/generated/clientset/versioned/fake"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+	"github.com/oneblock-ai/oneblock/pkg/utils/fakeclients"
+)
+
+func TestIdleCuller_getLastActivity(t *testing.T) {
+	kernels := `[{"id":"k1","last_activity":"2024-01-01T10:00:00Z","execution_state":"idle"}]`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		// the jupyter API requires the token of the notebook
+		if strings.HasPrefix(req.URL.Path, "/api/") && req.Header.Get("Authorization") != "token secret-token" {
+			rw.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch req.URL.Path {
+		case "/api/kernels":
+			_, _ = rw.Write([]byte(kernels))
+		case "/api/status":
+			_, _ = rw.Write([]byte(`{"last_activity":"2024-01-01T09:00:00Z","connections":0,"kernels":1}`))
+		case "/healthz":
+			_, _ = rw.Write([]byte(`{"status":"expired","lastHeartbeat":1704103200000}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	clientSet := k8sfake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "nb-token", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("secret-token")},
+	})
+	c := &idleCuller{
+		httpClient:  server.Client(),
+		secretCache: fakeclients.SecretCache(clientSet.CoreV1().Secrets),
+		serverURL: func(_ *mlv1.Notebook) string {
+			return server.URL
+		},
+	}
+	notebook := &mlv1.Notebook{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nb-jupyter",
+			Namespace: "default",
+			Labels:    map[string]string{constant.LabelNotebookType: string(mlv1.NotebookTypeJupyter)},
+		},
+		Spec: mlv1.NotebookSpec{
+			Template: mlv1.NotebookTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "nb-jupyter",
+							Env:  []corev1.EnvVar{{Name: TokenEnvVar, Value: "wrong-token"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	_, err := c.getLastActivity(notebook)
+	assert.Error(t, err)
+
+	// the token is read from the secret referred by the token env
+	notebook.Spec.Template.Spec.Containers[0].Env[0] = corev1.EnvVar{
+		Name: TokenEnvVar,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "nb-token"},
+				Key:                  "token",
+			},
+		},
+	}
+	lastActivity, err := c.getLastActivity(notebook)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), lastActivity.UTC())
+
+	// the notebook is active while the kernel is busy
+	kernels = `[{"id":"k1","last_activity":"2024-01-01T10:00:00Z","execution_state":"busy"}]`
+	lastActivity, err = c.getLastActivity(notebook)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), *lastActivity, time.Minute)
+
+	notebook.Labels[constant.LabelNotebookType] = string(mlv1.NotebookTypeCodeServer)
+	lastActivity, err = c.getLastActivity(notebook)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), lastActivity.UTC())
+
+	notebook.Labels[constant.LabelNotebookType] = "rstudio"
+	lastActivity, err = c.getLastActivity(notebook)
+	require.NoError(t, err)
+	assert.Nil(t, lastActivity)
+}
+
+func Test_getCullIdleTime(t *testing.T) {
+	notebook := &mlv1.Notebook{}
+	// the culling is opt-in by default
+	assert.Zero(t, getCullIdleTime(notebook))
+
+	notebook.Annotations = map[string]string{constant.AnnotationNotebookCullIdleTime: "30"}
+	assert.Equal(t, 30*time.Minute, getCullIdleTime(notebook))
+
+	notebook.Annotations[constant.AnnotationNotebookCullIdleTime] = "0"
+	assert.Zero(t, getCullIdleTime(notebook))
+}
+
+func TestIdleCuller_OnChanged(t *testing.T) {
+	var lastHeartbeat int64
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		_, _ = rw.Write([]byte(fmt.Sprintf(`{"status":"expired","lastHeartbeat":%d}`, lastHeartbeat)))
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	newNotebook := func(readySince time.Time, lastActivity *time.Time) *mlv1.Notebook {
+		notebook := &mlv1.Notebook{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "nb-code",
+				Namespace:   "default",
+				Labels:      map[string]string{constant.LabelNotebookType: string(mlv1.NotebookTypeCodeServer)},
+				Annotations: map[string]string{constant.AnnotationNotebookCullIdleTime: "60"},
+			},
+			Status: mlv1.NotebookStatus{
+				ReadyReplicas: 1,
+				Conditions: []mgmtv1.Condition{
+					{
+						Type:               "Ready",
+						Status:             metav1.ConditionTrue,
+						LastTransitionTime: readySince.UTC().Format(time.RFC3339),
+					},
+				},
+			},
+		}
+		if lastActivity != nil {
+			notebook.Status.LastActivity = &metav1.Time{Time: *lastActivity}
+		}
+		return notebook
+	}
+	onChanged := func(notebook *mlv1.Notebook) *mlv1.Notebook {
+		c := &idleCuller{
+			httpClient:   server.Client(),
+			notebooks:    fakeclients.NotebookClient(fake.NewSimpleClientset(notebook).MlV1().Notebooks),
+			recorder:     record.NewFakeRecorder(10),
+			enqueueAfter: func(_, _ string, _ time.Duration) {},
+			serverURL: func(_ *mlv1.Notebook) string {
+				return server.URL
+			},
+		}
+		updated, err := c.OnChanged("", notebook)
+		require.NoError(t, err)
+		return updated
+	}
+
+	// the zero heartbeat reported before any connection doesn't cull the new notebook
+	readySince := now.Add(-10 * time.Minute)
+	notebook := onChanged(newNotebook(readySince, nil))
+	assert.False(t, isNotebookStopped(notebook))
+	require.NotNil(t, notebook.Status.LastActivity)
+	assert.WithinDuration(t, readySince, notebook.Status.LastActivity.Time, time.Second)
+
+	// the notebook without any connection is culled once it has been idle since it gets ready
+	notebook = onChanged(newNotebook(now.Add(-2*time.Hour), nil))
+	assert.True(t, isNotebookStopped(notebook))
+
+	// the last activity before the notebook is stopped doesn't cull the restarted notebook
+	staleActivity := now.Add(-48 * time.Hour)
+	lastHeartbeat = staleActivity.UnixMilli()
+	readySince = now.Add(-5 * time.Minute)
+	notebook = onChanged(newNotebook(readySince, &staleActivity))
+	assert.False(t, isNotebookStopped(notebook))
+	assert.WithinDuration(t, readySince, notebook.Status.LastActivity.Time, time.Second)
+
+	// the recent activity keeps the notebook running
+	lastHeartbeat = now.Add(-time.Minute).UnixMilli()
+	notebook = onChanged(newNotebook(now.Add(-3*time.Hour), &staleActivity))
+	assert.False(t, isNotebookStopped(notebook))
+	assert.WithinDuration(t, now.Add(-time.Minute), notebook.Status.LastActivity.Time, time.Second)
+}
@@ -0,0 +1,196 @@
+package notebook
+
+import (
+	"path"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+	"github.com/oneblock-ai/oneblock/pkg/settings"
+)
+
+const (
+	// DefaultNotebookUID is the uid of the jovyan user of the kubeflow notebook images
+	DefaultNotebookUID = int64(1000)
+
+	GitInitContainerName = "git-clone"
+	gitSecretVolumeName  = "git-credentials"
+	gitSecretMountPath   = "/etc/git-secret"
+)
+
+// gitCloneScript clones the repository on the first start, the existing repository is updated by a fast-forward pull
+// and the local changes of the workspace are kept if it fails, the checked-out commit is written to the termination
+// message which is reported to the notebook status
+const gitCloneScript = `set -e
+export HOME=/tmp
+git config --global --add safe.directory '*'
+if [ -f ` + gitSecretMountPath + `/ssh-privatekey ]; then
+  install -m 0600 ` + gitSecretMountPath + `/ssh-privatekey /tmp/ssh-privatekey
+  export GIT_SSH_COMMAND="ssh -i /tmp/ssh-privatekey -o StrictHostKeyChecking=accept-new"
+fi
+if [ -f ` + gitSecretMountPath + `/password ]; then
+  git config --global credential.helper '!f() { echo "username=$(cat ` + gitSecretMountPath + `/username 2>/dev/null || echo git)"; echo "password=$(cat ` + gitSecretMountPath + `/password)"; }; f'
+fi
+if [ ! -d "$GIT_TARGET_DIR/.git" ]; then
+  git clone -q "$GIT_REPOSITORY" "$GIT_TARGET_DIR"
+  cd "$GIT_TARGET_DIR"
+  [ -z "$GIT_REF" ] || git checkout -q "$GIT_REF"
+else
+  cd "$GIT_TARGET_DIR"
+  { git fetch -q origin && { [ -z "$GIT_REF" ] || git checkout -q "$GIT_REF"; } &&
+    { [ "$(git rev-parse --abbrev-ref HEAD)" = "HEAD" ] || git merge -q --ff-only "@{u}"; }; } ||
+    echo "Failed to update the repository, keeping the workspace as is"
+fi
+git rev-parse HEAD > /dev/termination-log
+`
+
+// setGitInitContainer sets the init container cloning the git repository into the workspace volume of the notebook,
+// the init container is removed if the git repository is not specified
+func setGitInitContainer(notebook *mlv1.Notebook, podSpec *corev1.PodSpec) {
+	removeGitInitContainer(podSpec)
+
+	git := notebook.Spec.Git
+	if git == nil {
+		return
+	}
+	container := &podSpec.Containers[0]
+	mount := getWorkspaceMount(container)
+	if mount == nil {
+		logrus.Warnf("Skipping cloning git repository of notebook %s/%s, the working dir %s isn't mounted by any volume",
+			notebook.Namespace, notebook.Name, getWorkingDir(container))
+		return
+	}
+
+	initContainer := corev1.Container{
+		Name:    GitInitContainerName,
+		Image:   settings.NotebookGitImage.Get(),
+		Command: []string{"/bin/sh", "-c", gitCloneScript},
+		Env: []corev1.EnvVar{
+			{Name: "GIT_REPOSITORY", Value: git.Repository},
+			{Name: "GIT_REF", Value: git.Ref},
+			{Name: "GIT_TARGET_DIR", Value: GetGitTargetDir(notebook, container)},
+		},
+		VolumeMounts: []corev1.VolumeMount{*mount},
+	}
+
+	// the repository is written as the notebook user, so that it can be modified in the notebook
+	switch {
+	case container.SecurityContext != nil && container.SecurityContext.RunAsUser != nil:
+		initContainer.SecurityContext = &corev1.SecurityContext{
+			RunAsUser:  container.SecurityContext.RunAsUser,
+			RunAsGroup: container.SecurityContext.RunAsGroup,
+		}
+	case podSpec.SecurityContext == nil || podSpec.SecurityContext.RunAsUser == nil:
+		initContainer.SecurityContext = &corev1.SecurityContext{
+			RunAsUser:  pointer.Int64(DefaultNotebookUID),
+			RunAsGroup: pointer.Int64(DefaultFSGroup),
+		}
+	}
+
+	if git.CredentialSecretRef != nil && git.CredentialSecretRef.Name != "" {
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: gitSecretVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  git.CredentialSecretRef.Name,
+					DefaultMode: pointer.Int32(0440),
+				},
+			},
+		})
+		initContainer.VolumeMounts = append(initContainer.VolumeMounts, corev1.VolumeMount{
+			Name:      gitSecretVolumeName,
+			MountPath: gitSecretMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	podSpec.InitContainers = append([]corev1.Container{initContainer}, podSpec.InitContainers...)
+}
+
+func removeGitInitContainer(podSpec *corev1.PodSpec) {
+	for i, c := range podSpec.InitContainers {
+		if c.Name == GitInitContainerName {
+			podSpec.InitContainers = append(podSpec.InitContainers[:i:i], podSpec.InitContainers[i+1:]...)
+			break
+		}
+	}
+	for i, v := range podSpec.Volumes {
+		if v.Name == gitSecretVolumeName {
+			podSpec.Volumes = append(podSpec.Volumes[:i:i], podSpec.Volumes[i+1:]...)
+			break
+		}
+	}
+}
+
+func getWorkingDir(container *corev1.Container) string {
+	if container.WorkingDir == "" {
+		return DefaultWorkingDir
+	}
+	return container.WorkingDir
+}
+
+// getWorkspaceMount returns the volume mount of the notebook container which contains the working dir
+func getWorkspaceMount(container *corev1.Container) *corev1.VolumeMount {
+	workingDir := getWorkingDir(container)
+	var workspace *corev1.VolumeMount
+	for i, mount := range container.VolumeMounts {
+		mountPath := strings.TrimSuffix(mount.MountPath, "/")
+		if workingDir != mountPath && !strings.HasPrefix(workingDir, mountPath+"/") {
+			continue
+		}
+		// the deepest mount of the working dir takes effect
+		if workspace == nil || len(mountPath) > len(workspace.MountPath) {
+			workspace = &container.VolumeMounts[i]
+		}
+	}
+	if workspace == nil {
+		return nil
+	}
+	mount := *workspace
+	mount.ReadOnly = false
+	return &mount
+}
+
+// GetGitTargetDir returns the absolute directory of the git repository in the notebook container
+func GetGitTargetDir(notebook *mlv1.Notebook, container *corev1.Container) string {
+	git := notebook.Spec.Git
+	targetDir := git.TargetDir
+	if targetDir == "" {
+		targetDir = getGitRepositoryName(git.Repository)
+	}
+	return path.Join(getWorkingDir(container), targetDir)
+}
+
+// getGitRepositoryName returns the repository name of the URL, e.g., oneblock of https://github.com/oneblock-ai/oneblock.git
+// or git@github.com:oneblock-ai/oneblock.git
+func getGitRepositoryName(repository string) string {
+	name := strings.TrimSuffix(strings.TrimSuffix(repository, "/"), ".git")
+	if i := strings.LastIndexAny(name, "/:"); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// getGitStatus returns the git status from the termination message of the git init container,
+// the previous status is kept until the init container is terminated successfully
+func getGitStatus(notebook *mlv1.Notebook, pod *corev1.Pod) *mlv1.NotebookGitStatus {
+	git := notebook.Spec.Git
+	if git == nil {
+		return nil
+	}
+
+	for _, cs := range pod.Status.InitContainerStatuses {
+		if cs.Name != GitInitContainerName || cs.State.Terminated == nil || cs.State.Terminated.ExitCode != 0 {
+			continue
+		}
+		return &mlv1.NotebookGitStatus{
+			Repository: git.Repository,
+			Ref:        git.Ref,
+			Commit:     strings.TrimSpace(cs.State.Terminated.Message),
+		}
+	}
+	return notebook.Status.Git
+}
@@ -0,0 +1,72 @@
+package notebook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+)
+
+func Test_setGitInitContainer(t *testing.T) {
+	notebook := &mlv1.Notebook{
+		Spec: mlv1.NotebookSpec{
+			Git: &mlv1.NotebookGitSource{
+				Repository:          "git@github.com:oneblock-ai/oneblock.git",
+				Ref:                 "main",
+				CredentialSecretRef: &corev1.LocalObjectReference{Name: "git-ssh"},
+			},
+		},
+	}
+	podSpec := &corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name: "nb",
+				VolumeMounts: []corev1.VolumeMount{
+					{Name: "dshm", MountPath: "/dev/shm"},
+					{Name: "workspace", MountPath: "/home/jovyan"},
+				},
+			},
+		},
+	}
+
+	setGitInitContainer(notebook, podSpec)
+	// the injection is idempotent
+	setGitInitContainer(notebook, podSpec)
+	require.Len(t, podSpec.InitContainers, 1)
+	require.Len(t, podSpec.Volumes, 1)
+
+	initContainer := podSpec.InitContainers[0]
+	assert.Equal(t, GitInitContainerName, initContainer.Name)
+	assert.Contains(t, initContainer.Env, corev1.EnvVar{Name: "GIT_TARGET_DIR", Value: "/home/jovyan/oneblock"})
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "workspace", MountPath: "/home/jovyan"},
+		{Name: gitSecretVolumeName, MountPath: gitSecretMountPath, ReadOnly: true},
+	}, initContainer.VolumeMounts)
+	assert.Equal(t, DefaultNotebookUID, *initContainer.SecurityContext.RunAsUser)
+
+	pod := &corev1.Pod{
+		Status: corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: GitInitContainerName,
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{Message: "0123abcd\n"},
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, &mlv1.NotebookGitStatus{
+		Repository: "git@github.com:oneblock-ai/oneblock.git",
+		Ref:        "main",
+		Commit:     "0123abcd",
+	}, getGitStatus(notebook, pod))
+
+	notebook.Spec.Git = nil
+	setGitInitContainer(notebook, podSpec)
+	assert.Empty(t, podSpec.InitContainers)
+	assert.Empty(t, podSpec.Volumes)
+}
@@ -17,11 +17,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 
-	mgmtv1 "github.com/oneblock-ai/oneblock/pkg/apis/management.oneblock.ai/v1"
 	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
 	ctlmlv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ml.oneblock.ai/v1"
+	ctlrayv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ray.io/v1"
 	"github.com/oneblock-ai/oneblock/pkg/server/config"
 	"github.com/oneblock-ai/oneblock/pkg/utils"
 	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
@@ -37,6 +38,13 @@ const (
 	// DefaultFSGroup The default fsGroup of PodSecurityContext.
 	// https://kubernetes.io/docs/reference/generated/kubernetes-api/v1.23/#podsecuritycontext-v1-core
 	DefaultFSGroup = int64(100)
+
+	// PrefixEnvVar is the base URL env of the kubeflow notebook images
+	PrefixEnvVar = "NB_PREFIX"
+	// TokenEnvVar is the token env of the jupyter server to authenticate the API requests
+	TokenEnvVar = "JUPYTER_TOKEN"
+	// DefaultWorkingDir is the home dir of the jovyan user of the kubeflow notebook images
+	DefaultWorkingDir = "/home/jovyan"
 )
 
 type Handler struct {
@@ -47,13 +55,19 @@ type Handler struct {
 	services         ctlcorev1.ServiceClient
 	serviceCache     ctlcorev1.ServiceCache
 	podCache         ctlcorev1.PodCache
+	rayClusterCache  ctlrayv1.RayClusterCache
 	pvcHandler       *utils.PVCHandler
+	recorder         record.EventRecorder
 }
 
 const (
 	notebookControllerOnChange  = "notebook.onChange"
 	notebookControllerCreatePVC = "notebook.createNoteBookPVC"
 	notebookControllerWatchPods = "notebook.watchPods"
+	notebookControllerCullIdle  = "notebook.cullIdle"
+	notebookControllerSchedule  = "notebook.schedule"
+
+	notebookControllerAgentName = "oneblock-notebook-controller"
 )
 
 func Register(ctx context.Context, mgmt *config.Management) error {
@@ -62,6 +76,8 @@ func Register(ctx context.Context, mgmt *config.Management) error {
 	services := mgmt.CoreFactory.Core().V1().Service()
 	pods := mgmt.CoreFactory.Core().V1().Pod()
 	pvcs := mgmt.CoreFactory.Core().V1().PersistentVolumeClaim()
+	rayClusters := mgmt.KubeRayFactory.Ray().V1().RayCluster()
+	recorder := mgmt.NewRecorder(notebookControllerAgentName)
 	h := Handler{
 		scheme:           mgmt.Scheme,
 		notebooks:        notebooks,
@@ -70,12 +86,22 @@ func Register(ctx context.Context, mgmt *config.Management) error {
 		services:         services,
 		serviceCache:     services.Cache(),
 		podCache:         pods.Cache(),
+		rayClusterCache:  rayClusters.Cache(),
 		pvcHandler:       utils.NewPVCHandler(pvcs, pvcs.Cache()),
+		recorder:         recorder,
 	}
 
 	notebooks.OnChange(ctx, notebookControllerOnChange, h.OnChanged)
 	notebooks.OnChange(ctx, notebookControllerCreatePVC, h.createNoteBookPVC)
 	relatedresource.Watch(ctx, notebookControllerWatchPods, h.ReconcileNotebookPodOwners, notebooks, pods)
+
+	culler := newIdleCuller(notebooks, mgmt.CoreFactory.Core().V1().Secret().Cache(), recorder)
+	notebooks.OnChange(ctx, notebookControllerCullIdle, culler.OnChanged)
+
+	scheduler := newScheduler(notebooks, recorder)
+	notebooks.OnChange(ctx, notebookControllerSchedule, scheduler.OnChanged)
+
+	registerRayClusterHandler(ctx, notebooks, rayClusters, mgmt.ClientSet.NetworkingV1(), mgmt.Apply)
 	return nil
 }
 
@@ -90,10 +116,13 @@ func (h *Handler) OnChanged(_ string, notebook *mlv1.Notebook) (*mlv1.Notebook,
 		return notebook, err
 	}
 
-	// sync pod template spec from ss to notebook after update
-	if !reflect.DeepEqual(ss.Spec.Template.Spec, notebook.Spec.Template.Spec) {
+	// sync pod template spec from ss to notebook after update, the injected Ray address is kept out of the notebook
+	// so that it is removed with the RayCluster reference
+	podSpec := ss.Spec.Template.Spec.DeepCopy()
+	removeRayAddressEnv(podSpec)
+	if !reflect.DeepEqual(*podSpec, notebook.Spec.Template.Spec) {
 		nbCpy := notebook.DeepCopy()
-		nbCpy.Spec.Template.Spec = ss.Spec.Template.Spec
+		nbCpy.Spec.Template.Spec = *podSpec
 		if _, err = h.notebooks.Update(nbCpy); err != nil {
 			return notebook, err
 		}
@@ -120,6 +149,9 @@ func (h *Handler) ensureStatefulSet(notebook *mlv1.Notebook) (*v1.StatefulSet, e
 		if errors.IsNotFound(err) {
 			logrus.Infof("Generating statefulset for notebook %s/%s", notebook.Namespace, notebook.Name)
 			ss = getNoteBookStatefulSet(notebook)
+			if err = h.injectPodSpec(notebook, &ss.Spec.Template.Spec); err != nil {
+				return nil, err
+			}
 
 			if err = ctrl.SetControllerReference(notebook, ss, h.scheme); err != nil {
 				return nil, err
@@ -129,10 +161,18 @@ func (h *Handler) ensureStatefulSet(notebook *mlv1.Notebook) (*v1.StatefulSet, e
 		return nil, err
 	}
 
-	if !reflect.DeepEqual(notebook.Spec.Template.Spec, ss.Spec.Template.Spec) {
+	// reconcile the replicas from the stopped annotation, the notebook can be stopped or started at any time
+	replicas := getNotebookReplicas(notebook)
+	podSpec := notebook.Spec.Template.Spec.DeepCopy()
+	if err = h.injectPodSpec(notebook, podSpec); err != nil {
+		return nil, err
+	}
+	if !reflect.DeepEqual(*podSpec, ss.Spec.Template.Spec) ||
+		ss.Spec.Replicas == nil || *ss.Spec.Replicas != replicas {
 		logrus.Infof("Updating notebook statefulset %s/%s", notebook.Namespace, notebook.Name)
 		ssCopy := ss.DeepCopy()
-		ssCopy.Spec.Template.Spec = notebook.Spec.Template.Spec
+		ssCopy.Spec.Template.Spec = *podSpec
+		ssCopy.Spec.Replicas = &replicas
 		if ss, err = h.statefulSets.Update(ssCopy); err != nil {
 			return ss, err
 		}
@@ -141,11 +181,42 @@ func (h *Handler) ensureStatefulSet(notebook *mlv1.Notebook) (*v1.StatefulSet, e
 	return ss, nil
 }
 
-func getNoteBookStatefulSet(notebook *mlv1.Notebook) *v1.StatefulSet {
-	replicas := int32(1)
-	if metav1.HasAnnotation(notebook.ObjectMeta, constant.AnnotationResourceStopped) {
-		replicas = 0
+// injectPodSpec injects the git init container and the Ray address of the notebook into the pod spec
+func (h *Handler) injectPodSpec(notebook *mlv1.Notebook, podSpec *corev1.PodSpec) error {
+	setGitInitContainer(notebook, podSpec)
+	return h.injectRayAddress(notebook, podSpec)
+}
+
+// injectRayAddress sets the RAY_ADDRESS env to the Ray client address of the referenced RayCluster,
+// so that ray.init() connects the cluster without any arguments. The address is removed along with the reference.
+func (h *Handler) injectRayAddress(notebook *mlv1.Notebook, podSpec *corev1.PodSpec) error {
+	removeRayAddressEnv(podSpec)
+	if notebook.Spec.RayClusterRef == nil {
+		return nil
+	}
+
+	namespace, name := getRayClusterRef(notebook)
+	cluster, err := h.rayClusterCache.Get(namespace, name)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	setRayAddressEnv(podSpec, getRayAddress(namespace, name, cluster))
+	return nil
+}
+
+func isNotebookStopped(notebook *mlv1.Notebook) bool {
+	return metav1.HasAnnotation(notebook.ObjectMeta, constant.AnnotationResourceStopped)
+}
+
+func getNotebookReplicas(notebook *mlv1.Notebook) int32 {
+	if isNotebookStopped(notebook) {
+		return 0
 	}
+	return 1
+}
+
+func getNoteBookStatefulSet(notebook *mlv1.Notebook) *v1.StatefulSet {
+	replicas := getNotebookReplicas(notebook)
 
 	ss := &v1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
@@ -184,16 +255,30 @@ func getNoteBookStatefulSet(notebook *mlv1.Notebook) *v1.StatefulSet {
 	// copy all the notebook annotations to the pod.
 	a := &ss.Spec.Template.ObjectMeta.Annotations
 	for k, v := range notebook.ObjectMeta.Annotations {
-		if !strings.Contains(k, "kubectl") && !strings.Contains(k, "notebook") {
+		if !strings.Contains(k, "kubectl") && !strings.Contains(k, "notebook") && k != constant.AnnotationResourceStopped {
 			(*a)[k] = v
 		}
 	}
 
 	podSpec := &ss.Spec.Template.Spec
+	// the notebook assigned to a queue is scheduled by volcano, the pod group of the pod is created in the queue
+	if queueName := notebook.Labels[constant.LabelVolcanoQueueName]; queueName != "" {
+		(*a)[constant.AnnotationVolcanoQueueName] = queueName
+		if podSpec.SchedulerName == "" {
+			podSpec.SchedulerName = constant.VolcanoSchedulerName
+		}
+	}
 	container := &podSpec.Containers[0]
 	container.Name = notebook.Name
 	if container.WorkingDir == "" {
-		container.WorkingDir = "/home/jovyan"
+		container.WorkingDir = DefaultWorkingDir
+	}
+	// serve the jupyter server under the proxy prefix of the API server
+	if isJupyterNotebook(notebook) && GetNotebookBasePath(notebook) == "" {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  PrefixEnvVar,
+			Value: GetNotebookProxyPrefix(notebook.Namespace, notebook.Name),
+		})
 	}
 	if container.Ports == nil {
 		container.Ports = []corev1.ContainerPort{
@@ -221,7 +306,7 @@ func getNoteBookStatefulSet(notebook *mlv1.Notebook) *v1.StatefulSet {
 }
 
 func (h *Handler) generateService(notebook *mlv1.Notebook) error {
-	svcName := fmt.Sprintf("%s-notebook", notebook.Name)
+	svcName := getNotebookServiceName(notebook)
 	svc, err := h.serviceCache.Get(notebook.Namespace, svcName)
 	if err != nil && !errors.IsNotFound(err) {
 		return err
@@ -283,87 +368,43 @@ func getService(notebook *mlv1.Notebook, svcName string) *corev1.Service {
 	return svc
 }
 
-func getNotebookPodLabel(notebook *mlv1.Notebook) map[string]string {
-	return map[string]string{
-		"statefulset":     notebook.Name,
-		notebookNameLabel: notebook.Name,
-	}
+func getNotebookServiceName(notebook *mlv1.Notebook) string {
+	return fmt.Sprintf("%s-notebook", notebook.Name)
 }
 
-func (h *Handler) updateNotebookStatus(notebook *mlv1.Notebook, ss *v1.StatefulSet) error {
-	toUpdateStatus := false
-	pod, err := h.podCache.Get(ss.Namespace, fmt.Sprintf("%s-0", ss.Name))
-	if err != nil {
-		if errors.IsNotFound(err) {
-			logrus.Infof("Notebook pod not found: %s, skipp updating and waiting for reconcile", err)
-			return nil
-		}
-		return err
-	}
+// GetNotebookServerURL returns the in-cluster URL of the notebook service
+func GetNotebookServerURL(notebook *mlv1.Notebook) string {
+	return fmt.Sprintf("http://%s.%s.svc:%d", getNotebookServiceName(notebook), notebook.Namespace, DefaultServingPort)
+}
 
-	if reflect.DeepEqual(pod.Status, corev1.PodStatus{}) {
-		logrus.Debugln("Empty pod status, won't update notebook status")
-		return nil
-	}
+// GetNotebookProxyPrefix returns the path prefix of the notebook served by the API server proxy
+func GetNotebookProxyPrefix(namespace, name string) string {
+	return fmt.Sprintf("/notebooks/%s/%s", namespace, name)
+}
 
-	nbCopy := notebook.DeepCopy()
-	if ss.Status.ReadyReplicas != nbCopy.Status.ReadyReplicas {
-		toUpdateStatus = true
-	}
-	status := mlv1.NotebookStatus{
-		Conditions:    make([]mgmtv1.Condition, 0),
-		ReadyReplicas: ss.Status.ReadyReplicas,
-		State:         corev1.ContainerState{},
+// GetNotebookBasePath returns the base path of the notebook server which is set by the NB_PREFIX env,
+// the notebook server is served at the root path if it is not set
+func GetNotebookBasePath(notebook *mlv1.Notebook) string {
+	containers := notebook.Spec.Template.Spec.Containers
+	if len(containers) == 0 {
+		return ""
 	}
-
-	// Update status of the CR using the ContainerState of
-	// the container that has the same name as the CR.
-	// If no container of same name is found, the state of the CR is not updated.
-	logrus.Debugln("Calculating Notebook's  containerState")
-	notebookContainerFound := false
-	for i := range pod.Status.ContainerStatuses {
-		if !strings.Contains(pod.Status.ContainerStatuses[i].Name, notebook.Name) {
-			continue
-		}
-
-		if pod.Status.ContainerStatuses[i].State == notebook.Status.State {
-			continue
+	for _, env := range containers[0].Env {
+		if env.Name == PrefixEnvVar {
+			return strings.TrimSuffix(env.Value, "/")
 		}
-
-		// Update Notebook CR's status.ContainerState
-		cs := pod.Status.ContainerStatuses[i].State
-		logrus.Debugf("Updating notebook cr state: %s", cs)
-
-		status.State = cs
-		notebookContainerFound = true
-		break
-	}
-
-	if !notebookContainerFound {
-		logrus.Debugf("Could not find notebook container %s, Will not update notebook's status.state", notebook.Name)
-	}
-
-	// Mirroring pod condition
-	notebookConditions := make([]mgmtv1.Condition, 0)
-	for i := range pod.Status.Conditions {
-		condition := PodCondToNotebookCond(pod.Status.Conditions[i])
-		notebookConditions = append(notebookConditions, condition)
-	}
-	// update status
-	status.Conditions = notebookConditions
-
-	if !reflect.DeepEqual(status.Conditions, notebookConditions) {
-		toUpdateStatus = true
 	}
+	return ""
+}
 
-	if !reflect.DeepEqual(status.State, nbCopy.Status.State) {
-		toUpdateStatus = true
-	}
+func isJupyterNotebook(notebook *mlv1.Notebook) bool {
+	notebookType := notebook.Labels[constant.LabelNotebookType]
+	return notebookType == "" || notebookType == string(mlv1.NotebookTypeJupyter)
+}
 
-	if toUpdateStatus {
-		nbCopy.Status = status
-		_, err = h.notebooks.UpdateStatus(nbCopy)
+func getNotebookPodLabel(notebook *mlv1.Notebook) map[string]string {
+	return map[string]string{
+		"statefulset":     notebook.Name,
+		notebookNameLabel: notebook.Name,
 	}
-
-	return err
 }
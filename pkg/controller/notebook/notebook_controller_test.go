@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
@@ -12,6 +13,7 @@ import (
 	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
 	"github.com/oneblock-ai/oneblock/pkg/generated/clientset/versioned/fake"
 	"github.com/oneblock-ai/oneblock/pkg/server/config"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
 	"github.com/oneblock-ai/oneblock/pkg/utils/fakeclients"
 )
 
@@ -116,3 +118,56 @@ func TestHandler_OnNotebookChanged(t *testing.T) {
 		assert.Equal(t, tc.expected, actual, "case %q", tc.name)
 	}
 }
+
+func TestHandler_StopNotebook(t *testing.T) {
+	notebook := &mlv1.Notebook{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "nb-stop",
+		},
+		Spec: mlv1.NotebookSpec{
+			Template: mlv1.NotebookTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "nb-stop",
+							Image: "busybox",
+						},
+					},
+				},
+			},
+		},
+	}
+	fakeClient := fake.NewSimpleClientset(notebook)
+	k8sClient := k8sfake.NewSimpleClientset()
+	h := &Handler{
+		scheme:           config.Scheme,
+		notebooks:        fakeclients.NotebookClient(fakeClient.MlV1().Notebooks),
+		statefulSets:     fakeclients.StatefulSetClient(k8sClient.AppsV1().StatefulSets),
+		statefulSetCache: fakeclients.StatefulSetCache(k8sClient.AppsV1().StatefulSets),
+		services:         fakeclients.ServiceClient(k8sClient.CoreV1().Services),
+		serviceCache:     fakeclients.ServiceCache(k8sClient.CoreV1().Services),
+		podCache:         fakeclients.PodCache(k8sClient.CoreV1().Pods),
+	}
+
+	_, err := h.OnChanged("default/nb-stop", notebook)
+	require.NoError(t, err)
+	ss, err := h.statefulSetCache.Get("default", "nb-stop")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), *ss.Spec.Replicas)
+
+	// the replicas are reconciled after the notebook is stopped
+	stopped, err := h.notebooks.Get("default", "nb-stop", metav1.GetOptions{})
+	require.NoError(t, err)
+	stopped.Annotations = map[string]string{constant.AnnotationResourceStopped: "2024-01-01T00:00:00Z"}
+	_, err = h.OnChanged("default/nb-stop", stopped)
+	require.NoError(t, err)
+	ss, err = h.statefulSetCache.Get("default", "nb-stop")
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), *ss.Spec.Replicas)
+	assert.NotContains(t, ss.Spec.Template.Annotations, constant.AnnotationResourceStopped)
+
+	nb, err := h.notebooks.Get("default", "nb-stop", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, mlv1.NotebookPhaseStopped, nb.Status.Phase)
+}
@@ -33,43 +33,35 @@ func (h *Handler) ReconcileNotebookPodOwners(_, _ string, obj runtime.Object) ([
 	return nil, nil
 }
 
-// PodCondToNotebookCond Note: this is referred to kubeflow notebook controller
+// podCondToNotebookCond converts the pod condition to the notebook condition, the timestamps of the previous condition
+// are kept if the pod condition doesn't carry them and is unchanged, so that the status doesn't churn on every sync.
+// Note: this is referred to kubeflow notebook controller
 // https://github.com/kubeflow/kubeflow/tree/master/components/notebook-controller
-func PodCondToNotebookCond(pCond corev1.PodCondition) mgmtv1.Condition {
-	condition := mgmtv1.Condition{}
-
-	if len(pCond.Type) > 0 {
-		condition.Type = cond.Cond(pCond.Type)
-	}
-
-	if len(pCond.Status) > 0 {
-		condition.Status = metav1.ConditionStatus(pCond.Status)
-	}
-
-	if len(pCond.Message) > 0 {
-		condition.Message = pCond.Message
-	}
-
-	if len(pCond.Reason) > 0 {
-		condition.Reason = pCond.Reason
+func podCondToNotebookCond(pCond corev1.PodCondition, previous *mgmtv1.Condition, now time.Time) mgmtv1.Condition {
+	condition := mgmtv1.Condition{
+		Type:    cond.Cond(pCond.Type),
+		Status:  metav1.ConditionStatus(pCond.Status),
+		Reason:  pCond.Reason,
+		Message: pCond.Message,
 	}
 
-	// check if pCond.LastProbeTime is null. If so initialize
-	// the field with metav1.Now()
-	check := pCond.LastProbeTime.Time.Equal(time.Time{})
-	if !check {
-		condition.LastUpdateTime = pCond.LastProbeTime.Format(time.RFC3339)
-	} else {
-		condition.LastUpdateTime = metav1.Now().UTC().Format(time.RFC3339)
+	switch {
+	case !pCond.LastTransitionTime.IsZero():
+		condition.LastTransitionTime = pCond.LastTransitionTime.UTC().Format(time.RFC3339)
+	case previous != nil && previous.Status == condition.Status:
+		condition.LastTransitionTime = previous.LastTransitionTime
+	default:
+		condition.LastTransitionTime = now.UTC().Format(time.RFC3339)
 	}
 
-	// check if pCond.LastTransitionTime is null. If so initialize
-	// the field with metav1.Now()
-	check = pCond.LastTransitionTime.Time.Equal(time.Time{})
-	if !check {
-		condition.LastTransitionTime = pCond.LastTransitionTime.Format(time.RFC3339)
-	} else {
-		condition.LastTransitionTime = metav1.Now().UTC().Format(time.RFC3339)
+	switch {
+	case !pCond.LastProbeTime.IsZero():
+		condition.LastUpdateTime = pCond.LastProbeTime.UTC().Format(time.RFC3339)
+	case previous != nil && previous.Status == condition.Status && previous.Reason == condition.Reason &&
+		previous.Message == condition.Message:
+		condition.LastUpdateTime = previous.LastUpdateTime
+	default:
+		condition.LastUpdateTime = now.UTC().Format(time.RFC3339)
 	}
 
 	return condition
@@ -0,0 +1,332 @@
+package notebook
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/rancher/wrangler/v2/pkg/apply"
+	"github.com/rancher/wrangler/v2/pkg/relatedresource"
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	networkingclient "k8s.io/client-go/kubernetes/typed/networking/v1"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+	ctlraycluster "github.com/oneblock-ai/oneblock/pkg/controller/raycluster"
+	ctlmlv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ml.oneblock.ai/v1"
+	ctlrayv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ray.io/v1"
+	"github.com/oneblock-ai/oneblock/pkg/settings"
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+)
+
+const (
+	// RayAddressEnvVar is the env read by ray.init() to connect the Ray cluster
+	RayAddressEnvVar = "RAY_ADDRESS"
+	rayClientPort    = int32(10001)
+
+	rayClusterLabel     = "ray.io/cluster"
+	rayNodeTypeLabel    = "ray.io/node-type"
+	rayClientApplySetID = "notebook-ray-client"
+
+	notebookControllerSyncRayCluster    = "notebook.syncRayCluster"
+	notebookControllerRayClusterCleanup = "notebook.rayClusterCleanup"
+	notebookControllerWatchRayClusters  = "notebook.watchRayClusters"
+)
+
+var (
+	// rayAddressRegexp matches the Ray client address of the cluster head service injected by the controller
+	rayAddressRegexp = regexp.MustCompile(fmt.Sprintf(`^ray://[^./]+\.[^./]+\.svc:%d$`, rayClientPort))
+)
+
+// rayClusterHandler checks the connection of the notebooks to the referenced RayClusters and allows the Ray client
+// traffic between them if the pods are isolated by the network policies
+type rayClusterHandler struct {
+	ctx             context.Context
+	notebooks       ctlmlv1.NotebookController
+	notebookCache   ctlmlv1.NotebookCache
+	rayClusterCache ctlrayv1.RayClusterCache
+	networkPolicies networkingclient.NetworkPoliciesGetter
+	apply           apply.Apply
+}
+
+func registerRayClusterHandler(ctx context.Context, notebooks ctlmlv1.NotebookController, rayClusters ctlrayv1.RayClusterController,
+	networkPolicies networkingclient.NetworkPoliciesGetter, apply apply.Apply) {
+	h := &rayClusterHandler{
+		ctx:             ctx,
+		notebooks:       notebooks,
+		notebookCache:   notebooks.Cache(),
+		rayClusterCache: rayClusters.Cache(),
+		networkPolicies: networkPolicies,
+		apply:           apply,
+	}
+
+	notebooks.OnChange(ctx, notebookControllerSyncRayCluster, h.OnChanged)
+	notebooks.OnRemove(ctx, notebookControllerRayClusterCleanup, h.OnRemove)
+	relatedresource.Watch(ctx, notebookControllerWatchRayClusters, h.ReconcileRayClusterNotebooks, notebooks, rayClusters)
+}
+
+func (h *rayClusterHandler) OnChanged(_ string, notebook *mlv1.Notebook) (*mlv1.Notebook, error) {
+	if notebook == nil || notebook.DeletionTimestamp != nil {
+		return notebook, nil
+	}
+
+	if notebook.Spec.RayClusterRef == nil {
+		if notebook.Status.RayCluster == nil {
+			return notebook, nil
+		}
+		// remove the network policies and the status once the reference is removed
+		if err := h.applyNetworkPolicies(notebook); err != nil {
+			return notebook, err
+		}
+		nbCpy := notebook.DeepCopy()
+		nbCpy.Status.RayCluster = nil
+		return h.notebooks.UpdateStatus(nbCpy)
+	}
+
+	namespace, name := getRayClusterRef(notebook)
+	status := &mlv1.NotebookRayClusterStatus{
+		ExpectedRayVersion: utils.GetRayVersion(settings.RayClusterImage.Get()),
+	}
+	cluster, err := h.rayClusterCache.Get(namespace, name)
+	if err != nil && !errors.IsNotFound(err) {
+		return notebook, err
+	}
+
+	if cluster == nil {
+		status.Address = getRayAddress(namespace, name, nil)
+		status.Message = fmt.Sprintf("RayCluster %s/%s is not found", namespace, name)
+	} else {
+		status.Address = getRayAddress(namespace, name, cluster)
+		status.RayVersion = getRayClusterVersion(cluster)
+		status.VersionMatched = status.RayVersion != "" && status.RayVersion == status.ExpectedRayVersion
+
+		if err := h.applyNetworkPolicies(notebook, getRayClientNetworkPolicies(notebook, cluster)...); err != nil {
+			return notebook, err
+		}
+
+		// the reachability is reported from the RayCluster status, the Ray client server can't be probed from the
+		// controller since the ingress of the cluster head may only be allowed from the notebooks
+		var messages []string
+		if cluster.Status.State != rayv1.Ready || cluster.Status.Head.ServiceIP == "" {
+			messages = append(messages, fmt.Sprintf("RayCluster %s/%s is not ready", namespace, name))
+		} else {
+			status.Reachable = true
+		}
+		if !status.VersionMatched {
+			messages = append(messages, fmt.Sprintf("Ray version %q of the cluster doesn't match the expected version %q",
+				status.RayVersion, status.ExpectedRayVersion))
+		}
+		status.Message = strings.Join(messages, "; ")
+	}
+
+	if reflect.DeepEqual(notebook.Status.RayCluster, status) {
+		return notebook, nil
+	}
+	nbCpy := notebook.DeepCopy()
+	nbCpy.Status.RayCluster = status
+	return h.notebooks.UpdateStatus(nbCpy)
+}
+
+func (h *rayClusterHandler) OnRemove(_ string, notebook *mlv1.Notebook) (*mlv1.Notebook, error) {
+	if notebook == nil || notebook.Spec.RayClusterRef == nil {
+		return notebook, nil
+	}
+	return notebook, h.applyNetworkPolicies(notebook)
+}
+
+// ReconcileRayClusterNotebooks enqueues the notebooks referring to the changed RayCluster
+func (h *rayClusterHandler) ReconcileRayClusterNotebooks(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
+	if _, ok := obj.(*rayv1.RayCluster); !ok {
+		return nil, nil
+	}
+
+	notebooks, err := h.notebookCache.List(metav1.NamespaceAll, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]relatedresource.Key, 0)
+	for _, notebook := range notebooks {
+		if notebook.Spec.RayClusterRef == nil {
+			continue
+		}
+		if ns, n := getRayClusterRef(notebook); ns == namespace && n == name {
+			keys = append(keys, relatedresource.Key{Namespace: notebook.Namespace, Name: notebook.Name})
+		}
+	}
+	return keys, nil
+}
+
+// applyNetworkPolicies applies the Ray client network policies of the notebook, the policies are removed if none is given.
+// The owner references are not set since the policy of the RayCluster may be in a different namespace.
+func (h *rayClusterHandler) applyNetworkPolicies(notebook *mlv1.Notebook, policies ...runtime.Object) error {
+	var objs []runtime.Object
+	for _, obj := range policies {
+		policy := obj.(*networkingv1.NetworkPolicy)
+		policyType := policy.Spec.PolicyTypes[0]
+		isolated, err := h.isIsolated(policy.Namespace, policy.Spec.PodSelector.MatchLabels, policyType)
+		if err != nil {
+			return err
+		}
+		// the traffic is allowed by default if the pods aren't selected by any of the policies
+		if isolated {
+			objs = append(objs, policy)
+		}
+	}
+
+	return h.apply.
+		WithOwner(notebook).
+		WithSetID(rayClientApplySetID).
+		ApplyObjects(objs...)
+}
+
+// isIsolated returns true if the pods of the labels are selected by the network policies of the policy type
+func (h *rayClusterHandler) isIsolated(namespace string, podLabels map[string]string, policyType networkingv1.PolicyType) (bool, error) {
+	policies, err := h.networkPolicies.NetworkPolicies(namespace).List(h.ctx, metav1.ListOptions{})
+	if err != nil {
+		return false, err
+	}
+
+	for _, policy := range policies.Items {
+		if _, ok := policy.Labels[constant.LabelNotebookRayClient]; ok {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+		if err != nil || !selector.Matches(labels.Set(podLabels)) {
+			continue
+		}
+		// the policy type is Ingress if it is not specified
+		if len(policy.Spec.PolicyTypes) == 0 && policyType == networkingv1.PolicyTypeIngress {
+			return true, nil
+		}
+		for _, t := range policy.Spec.PolicyTypes {
+			if t == policyType {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// getRayClientNetworkPolicies returns the policies allowing the Ray client traffic from the notebook pod to the cluster head
+func getRayClientNetworkPolicies(notebook *mlv1.Notebook, cluster *rayv1.RayCluster) []runtime.Object {
+	name := fmt.Sprintf("%s-%s-ray-client", notebook.Namespace, notebook.Name)
+	policyLabels := map[string]string{
+		constant.LabelNotebookRayClient: notebook.Name,
+	}
+	port := intstr.FromInt32(rayClientPort)
+	protocol := corev1.ProtocolTCP
+	ports := []networkingv1.NetworkPolicyPort{{Protocol: &protocol, Port: &port}}
+	notebookPeer := networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{corev1.LabelMetadataName: notebook.Namespace},
+		},
+		PodSelector: &metav1.LabelSelector{MatchLabels: getNotebookPodLabel(notebook)},
+	}
+	headLabels := map[string]string{
+		rayClusterLabel:  cluster.Name,
+		rayNodeTypeLabel: string(rayv1.HeadNode),
+	}
+	headPeer := networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{corev1.LabelMetadataName: cluster.Namespace},
+		},
+		PodSelector: &metav1.LabelSelector{MatchLabels: headLabels},
+	}
+
+	return []runtime.Object{
+		&networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name + "-ingress",
+				Namespace: cluster.Namespace,
+				Labels:    policyLabels,
+			},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{MatchLabels: headLabels},
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+				Ingress: []networkingv1.NetworkPolicyIngressRule{
+					{From: []networkingv1.NetworkPolicyPeer{notebookPeer}, Ports: ports},
+				},
+			},
+		},
+		&networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name + "-egress",
+				Namespace: notebook.Namespace,
+				Labels:    policyLabels,
+			},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{MatchLabels: getNotebookPodLabel(notebook)},
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+				Egress: []networkingv1.NetworkPolicyEgressRule{
+					{To: []networkingv1.NetworkPolicyPeer{headPeer}, Ports: ports},
+				},
+			},
+		},
+	}
+}
+
+func getRayClusterRef(notebook *mlv1.Notebook) (string, string) {
+	ref := notebook.Spec.RayClusterRef
+	if ref.Namespace == "" {
+		return notebook.Namespace, ref.Name
+	}
+	return ref.Namespace, ref.Name
+}
+
+// getRayAddress returns the Ray client address of the cluster head service, the service name of KubeRay is
+// <cluster>-head-svc unless it is overridden by the head service of the cluster
+func getRayAddress(namespace, name string, cluster *rayv1.RayCluster) string {
+	svcName := fmt.Sprintf("%s-%s-svc", name, rayv1.HeadNode)
+	if cluster != nil {
+		svcName = ctlraycluster.GetHeadServiceName(cluster)
+	}
+	return fmt.Sprintf("ray://%s.%s.svc:%d", svcName, namespace, rayClientPort)
+}
+
+// setRayAddressEnv sets the RAY_ADDRESS env of the notebook container
+func setRayAddressEnv(podSpec *corev1.PodSpec, address string) {
+	container := &podSpec.Containers[0]
+	for i := range container.Env {
+		if container.Env[i].Name == RayAddressEnvVar {
+			container.Env[i].Value = address
+			container.Env[i].ValueFrom = nil
+			return
+		}
+	}
+	container.Env = append(container.Env, corev1.EnvVar{Name: RayAddressEnvVar, Value: address})
+}
+
+// removeRayAddressEnv removes the RAY_ADDRESS env injected by the controller from the notebook container,
+// the env set to other addresses by the user is kept
+func removeRayAddressEnv(podSpec *corev1.PodSpec) {
+	if len(podSpec.Containers) == 0 {
+		return
+	}
+	container := &podSpec.Containers[0]
+	for i, e := range container.Env {
+		if e.Name == RayAddressEnvVar && e.ValueFrom == nil && rayAddressRegexp.MatchString(e.Value) {
+			container.Env = append(container.Env[:i:i], container.Env[i+1:]...)
+			return
+		}
+	}
+}
+
+func getRayClusterVersion(cluster *rayv1.RayCluster) string {
+	if cluster.Spec.RayVersion != "" {
+		return cluster.Spec.RayVersion
+	}
+	containers := cluster.Spec.HeadGroupSpec.Template.Spec.Containers
+	if len(containers) == 0 {
+		return ""
+	}
+	return utils.GetRayVersion(containers[0].Image)
+}
@@ -0,0 +1,54 @@
+package notebook
+
+import (
+	"testing"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_setRayAddressEnv(t *testing.T) {
+	cluster := &rayv1.RayCluster{
+		Spec: rayv1.RayClusterSpec{
+			HeadGroupSpec: rayv1.HeadGroupSpec{
+				HeadService: &corev1.Service{},
+			},
+		},
+	}
+	assert.Equal(t, "ray://default-cluster-head-svc.oneblock-public.svc:10001",
+		getRayAddress("oneblock-public", "default-cluster", nil))
+	assert.Equal(t, "ray://default-cluster-head-svc.oneblock-public.svc:10001",
+		getRayAddress("oneblock-public", "default-cluster", cluster))
+
+	cluster.Spec.HeadGroupSpec.HeadService.Name = "ray-head"
+	address := getRayAddress("default", "my-cluster", cluster)
+	assert.Equal(t, "ray://ray-head.default.svc:10001", address)
+
+	podSpec := &corev1.PodSpec{
+		Containers: []corev1.Container{
+			{Name: "nb", Env: []corev1.EnvVar{{Name: RayAddressEnvVar, Value: "auto"}}},
+		},
+	}
+	setRayAddressEnv(podSpec, address)
+	setRayAddressEnv(podSpec, address)
+	assert.Equal(t, []corev1.EnvVar{{Name: RayAddressEnvVar, Value: address}}, podSpec.Containers[0].Env)
+}
+
+func Test_removeRayAddressEnv(t *testing.T) {
+	podSpec := &corev1.PodSpec{
+		Containers: []corev1.Container{
+			{Name: "nb", Env: []corev1.EnvVar{
+				{Name: "FOO", Value: "bar"},
+				{Name: RayAddressEnvVar, Value: "ray://default-cluster-head-svc.oneblock-public.svc:10001"},
+			}},
+		},
+	}
+	removeRayAddressEnv(podSpec)
+	assert.Equal(t, []corev1.EnvVar{{Name: "FOO", Value: "bar"}}, podSpec.Containers[0].Env)
+
+	// the address set by the user is kept
+	podSpec.Containers[0].Env = []corev1.EnvVar{{Name: RayAddressEnvVar, Value: "ray://ray.example.com:10001"}}
+	removeRayAddressEnv(podSpec)
+	assert.Len(t, podSpec.Containers[0].Env, 1)
+}
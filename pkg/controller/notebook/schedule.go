@@ -0,0 +1,160 @@
+package notebook
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+	ctlmlv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ml.oneblock.ai/v1"
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+)
+
+const (
+	// maxScheduleCatchUp limits the missed scheduled times to be applied, e.g., while the controller is down
+	maxScheduleCatchUp = 7 * 24 * time.Hour
+
+	EventReasonScheduledStart = "ScheduledStart"
+	EventReasonScheduledStop  = "ScheduledStop"
+)
+
+// scheduler starts and stops the notebooks at the times of their cron schedules by toggling the stopped annotation
+type scheduler struct {
+	notebooks    ctlmlv1.NotebookClient
+	recorder     record.EventRecorder
+	enqueueAfter func(namespace, name string, duration time.Duration)
+	now          func() time.Time
+}
+
+func newScheduler(notebooks ctlmlv1.NotebookController, recorder record.EventRecorder) *scheduler {
+	return &scheduler{
+		notebooks:    notebooks,
+		recorder:     recorder,
+		enqueueAfter: notebooks.EnqueueAfter,
+		now:          time.Now,
+	}
+}
+
+func (s *scheduler) OnChanged(_ string, notebook *mlv1.Notebook) (*mlv1.Notebook, error) {
+	if notebook == nil || notebook.DeletionTimestamp != nil {
+		return notebook, nil
+	}
+
+	if notebook.Spec.Schedule == nil {
+		if notebook.Status.Schedule == nil {
+			return notebook, nil
+		}
+		nbCpy := notebook.DeepCopy()
+		nbCpy.Status.Schedule = nil
+		return s.notebooks.UpdateStatus(nbCpy)
+	}
+
+	window, err := utils.ParseScheduleWindow(*notebook.Spec.Schedule)
+	if err != nil {
+		// the schedule is validated by the webhook, skip it if the timezone database is changed since then
+		logrus.Warnf("Skipping the invalid schedule of notebook %s/%s: %v", notebook.Namespace, notebook.Name, err)
+		return notebook, nil
+	}
+
+	now := s.now()
+	status, stopped, scheduled := getScheduledState(notebook, window, now)
+
+	if scheduled && stopped != isNotebookStopped(notebook) {
+		nbCpy := notebook.DeepCopy()
+		if stopped {
+			logrus.Infof("Stopping notebook %s/%s by the schedule", notebook.Namespace, notebook.Name)
+			if nbCpy.Annotations == nil {
+				nbCpy.Annotations = make(map[string]string, 1)
+			}
+			nbCpy.Annotations[constant.AnnotationResourceStopped] = now.UTC().Format(time.RFC3339)
+		} else {
+			logrus.Infof("Starting notebook %s/%s by the schedule", notebook.Namespace, notebook.Name)
+			delete(nbCpy.Annotations, constant.AnnotationResourceStopped)
+		}
+		updated, err := s.notebooks.Update(nbCpy)
+		if err != nil {
+			return notebook, err
+		}
+		s.recordScheduled(updated, stopped, status.LastScheduleTime.Time)
+		notebook = updated
+	}
+
+	if next := getNextScheduleTime(status); !next.IsZero() {
+		s.enqueueAfter(notebook.Namespace, notebook.Name, next.Sub(now))
+	}
+
+	if equality.Semantic.DeepEqual(status, notebook.Status.Schedule) {
+		return notebook, nil
+	}
+	nbCpy := notebook.DeepCopy()
+	nbCpy.Status.Schedule = status
+	return s.notebooks.UpdateStatus(nbCpy)
+}
+
+func (s *scheduler) recordScheduled(notebook *mlv1.Notebook, stopped bool, scheduleTime time.Time) {
+	if stopped {
+		s.recorder.Eventf(notebook, corev1.EventTypeNormal, EventReasonScheduledStop,
+			"Notebook is stopped by the schedule at %s", scheduleTime.UTC().Format(time.RFC3339))
+		return
+	}
+	s.recorder.Eventf(notebook, corev1.EventTypeNormal, EventReasonScheduledStart,
+		"Notebook is started by the schedule at %s", scheduleTime.UTC().Format(time.RFC3339))
+}
+
+// getScheduledState returns the schedule status at the given time and whether the notebook should be stopped
+// by the latest scheduled time passed since the last one, scheduled is false if there is no such time.
+// The times before the schedule is set are not applied, the stop wins if the start and stop are at the same time.
+func getScheduledState(notebook *mlv1.Notebook, window *utils.ScheduleWindow,
+	now time.Time) (status *mlv1.NotebookScheduleStatus, stopped, scheduled bool) {
+	status = &mlv1.NotebookScheduleStatus{}
+	if notebook.Status.Schedule != nil {
+		status = notebook.Status.Schedule.DeepCopy()
+	}
+	if status.LastScheduleTime == nil {
+		status.LastScheduleTime = &metav1.Time{Time: now.Truncate(time.Second)}
+	}
+
+	since := status.LastScheduleTime.Time
+	if earliest := now.Add(-maxScheduleCatchUp); since.Before(earliest) {
+		since = earliest
+	}
+	lastStart, lastStop := window.Last(since, now)
+	switch {
+	case !lastStop.IsZero() && !lastStop.Before(lastStart):
+		status.LastScheduleTime = &metav1.Time{Time: lastStop}
+		stopped, scheduled = true, true
+	case !lastStart.IsZero():
+		status.LastScheduleTime = &metav1.Time{Time: lastStart}
+		stopped, scheduled = false, true
+	}
+
+	status.NextStartTime = getNextTime(window.Start, now)
+	status.NextStopTime = getNextTime(window.Stop, now)
+	return status, stopped, scheduled
+}
+
+func getNextTime(schedule *utils.CronSchedule, now time.Time) *metav1.Time {
+	if schedule == nil {
+		return nil
+	}
+	next := schedule.Next(now)
+	if next.IsZero() {
+		return nil
+	}
+	return &metav1.Time{Time: next}
+}
+
+func getNextScheduleTime(status *mlv1.NotebookScheduleStatus) time.Time {
+	var next time.Time
+	for _, t := range []*metav1.Time{status.NextStartTime, status.NextStopTime} {
+		if t != nil && (next.IsZero() || t.Time.Before(next)) {
+			next = t.Time
+		}
+	}
+	return next
+}
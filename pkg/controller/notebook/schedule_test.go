@@ -0,0 +1,108 @@
+package notebook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+	"github.com/oneblock-ai/oneblock/pkg/generated/clientset/versioned/fake"
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+	"github.com/oneblock-ai/oneblock/pkg/utils/fakeclients"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	location, err := time.LoadLocation("Asia/Shanghai")
+	require.NoError(t, err)
+	// Friday
+	now := time.Date(2024, 1, 5, 21, 30, 0, 0, location)
+
+	var testCases = []struct {
+		expression string
+		next       time.Time
+	}{
+		{expression: "0 8 * * 1-5", next: time.Date(2024, 1, 8, 8, 0, 0, 0, location)},
+		{expression: "0 20 * * mon-fri", next: time.Date(2024, 1, 8, 20, 0, 0, 0, location)},
+		{expression: "*/15 * * * *", next: time.Date(2024, 1, 5, 21, 45, 0, 0, location)},
+		{expression: "0 9 1,15 * *", next: time.Date(2024, 1, 15, 9, 0, 0, 0, location)},
+		// either the day-of-month or the day-of-week matches if both are restricted
+		{expression: "0 9 15 * 6", next: time.Date(2024, 1, 6, 9, 0, 0, 0, location)},
+		{expression: "0 0 * * sun", next: time.Date(2024, 1, 7, 0, 0, 0, 0, location)},
+		{expression: "@monthly", next: time.Date(2024, 2, 1, 0, 0, 0, 0, location)},
+	}
+	for _, tc := range testCases {
+		schedule, err := utils.ParseCronSchedule(tc.expression, "Asia/Shanghai")
+		require.NoError(t, err, tc.expression)
+		assert.True(t, tc.next.Equal(schedule.Next(now)), "%s: expected %s, got %s", tc.expression, tc.next, schedule.Next(now))
+	}
+
+	for _, expression := range []string{"0 8 * *", "60 8 * * *", "0 8 * * 1-8", "0 8 * * fri-mon", "*/0 * * * *",
+		"@every 1h"} {
+		_, err := utils.ParseCronSchedule(expression, "")
+		assert.Error(t, err, expression)
+	}
+	_, err = utils.ParseCronSchedule("0 8 * * *", "Mars/Olympus")
+	assert.Error(t, err)
+}
+
+func TestScheduler_OnChanged(t *testing.T) {
+	notebook := &mlv1.Notebook{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nb"},
+		Spec: mlv1.NotebookSpec{
+			Schedule: &mlv1.ScheduleWindow{Start: "0 8 * * 1-5", Stop: "0 20 * * 1-5", Timezone: "UTC"},
+		},
+	}
+	fakeClient := fake.NewSimpleClientset(notebook)
+	var enqueued time.Duration
+	now := time.Date(2024, 1, 5, 19, 0, 0, 0, time.UTC)
+	s := &scheduler{
+		notebooks: fakeclients.NotebookClient(fakeClient.MlV1().Notebooks),
+		recorder:  record.NewFakeRecorder(10),
+		enqueueAfter: func(_, _ string, d time.Duration) {
+			enqueued = d
+		},
+		now: func() time.Time { return now },
+	}
+
+	// the schedule is initialized without toggling the notebook
+	notebook, err := s.OnChanged("", notebook)
+	require.NoError(t, err)
+	assert.False(t, isNotebookStopped(notebook))
+	assert.Equal(t, time.Hour, enqueued)
+	require.NotNil(t, notebook.Status.Schedule)
+	assert.True(t, notebook.Status.Schedule.NextStopTime.Equal(&metav1.Time{Time: time.Date(2024, 1, 5, 20, 0, 0, 0, time.UTC)}))
+	assert.True(t, notebook.Status.Schedule.NextStartTime.Equal(&metav1.Time{Time: time.Date(2024, 1, 8, 8, 0, 0, 0, time.UTC)}))
+
+	// stopped after the stop time
+	now = time.Date(2024, 1, 5, 20, 0, 1, 0, time.UTC)
+	notebook, err = s.OnChanged("", notebook)
+	require.NoError(t, err)
+	assert.True(t, isNotebookStopped(notebook))
+	assert.True(t, notebook.Status.Schedule.LastScheduleTime.Equal(&metav1.Time{Time: time.Date(2024, 1, 5, 20, 0, 0, 0, time.UTC)}))
+
+	// the manual start is kept until the next scheduled time
+	delete(notebook.Annotations, constant.AnnotationResourceStopped)
+	now = time.Date(2024, 1, 6, 10, 0, 0, 0, time.UTC)
+	notebook, err = s.OnChanged("", notebook)
+	require.NoError(t, err)
+	assert.False(t, isNotebookStopped(notebook))
+
+	// only the latest of the missed scheduled times is applied
+	notebook.Annotations = map[string]string{constant.AnnotationResourceStopped: "true"}
+	now = time.Date(2024, 1, 8, 12, 0, 0, 0, time.UTC)
+	notebook, err = s.OnChanged("", notebook)
+	require.NoError(t, err)
+	assert.False(t, isNotebookStopped(notebook))
+	assert.Equal(t, 8*time.Hour, enqueued)
+
+	// the status is removed with the schedule
+	notebook.Spec.Schedule = nil
+	notebook, err = s.OnChanged("", notebook)
+	require.NoError(t, err)
+	assert.Nil(t, notebook.Status.Schedule)
+}
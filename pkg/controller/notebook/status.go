@@ -0,0 +1,206 @@
+package notebook
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	mgmtv1 "github.com/oneblock-ai/oneblock/pkg/apis/management.oneblock.ai/v1"
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+)
+
+const (
+	EventReasonImagePullFailed = "ImagePullFailed"
+	EventReasonOOMKilled       = "OOMKilled"
+
+	reasonOOMKilled         = "OOMKilled"
+	reasonContainerCreating = "ContainerCreating"
+	reasonStopping          = "Stopping"
+)
+
+// containerFailureReasons are the waiting reasons of the containers which can't be recovered without user actions
+var containerFailureReasons = map[string]bool{
+	"ErrImagePull":               true,
+	"ImagePullBackOff":           true,
+	"InvalidImageName":           true,
+	"ErrImageNeverPull":          true,
+	"CrashLoopBackOff":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"RunContainerError":          true,
+}
+
+var imagePullFailureReasons = map[string]bool{
+	"ErrImagePull":      true,
+	"ImagePullBackOff":  true,
+	"InvalidImageName":  true,
+	"ErrImageNeverPull": true,
+}
+
+func (h *Handler) updateNotebookStatus(notebook *mlv1.Notebook, ss *v1.StatefulSet) error {
+	pod, err := h.getNotebookPod(notebook)
+	if err != nil {
+		return err
+	}
+
+	status := notebook.Status.DeepCopy()
+	status.ReadyReplicas = ss.Status.ReadyReplicas
+	if pod == nil {
+		status.Conditions = make([]mgmtv1.Condition, 0)
+		status.State = corev1.ContainerState{}
+	} else {
+		status.Conditions = getNotebookConditions(pod, notebook.Status.Conditions, time.Now())
+		if cs := getNotebookContainerStatus(notebook, pod); cs != nil {
+			status.State = cs.State
+		}
+		status.Git = getGitStatus(notebook, pod)
+	}
+	status.Phase, status.Reason, status.Message = getNotebookPhase(notebook, ss, pod)
+
+	if reflect.DeepEqual(*status, notebook.Status) {
+		return nil
+	}
+
+	// record the failures once the notebook turns into them
+	if status.Phase == mlv1.NotebookPhaseFailed &&
+		(notebook.Status.Phase != mlv1.NotebookPhaseFailed || notebook.Status.Reason != status.Reason) {
+		h.recordFailure(notebook, status)
+	}
+
+	nbCopy := notebook.DeepCopy()
+	nbCopy.Status = *status
+	_, err = h.notebooks.UpdateStatus(nbCopy)
+	return err
+}
+
+func (h *Handler) recordFailure(notebook *mlv1.Notebook, status *mlv1.NotebookStatus) {
+	switch {
+	case imagePullFailureReasons[status.Reason]:
+		h.recorder.Eventf(notebook, corev1.EventTypeWarning, EventReasonImagePullFailed,
+			"Failed to pull the notebook image: %s", status.Message)
+	case status.Reason == reasonOOMKilled:
+		h.recorder.Eventf(notebook, corev1.EventTypeWarning, EventReasonOOMKilled,
+			"Notebook is killed since it runs out of memory: %s", status.Message)
+	}
+}
+
+// getNotebookPod returns the pod of the notebook, the pods being deleted are skipped unless there are no other pods,
+// it returns nil if there are no pods
+func (h *Handler) getNotebookPod(notebook *mlv1.Notebook) (*corev1.Pod, error) {
+	pods, err := h.podCache.List(notebook.Namespace, labels.SelectorFromSet(getNotebookPodLabel(notebook)))
+	if err != nil {
+		return nil, err
+	}
+
+	var pod *corev1.Pod
+	for _, p := range pods {
+		if pod == nil || (pod.DeletionTimestamp != nil && p.DeletionTimestamp == nil) ||
+			(p.DeletionTimestamp == nil && p.CreationTimestamp.After(pod.CreationTimestamp.Time)) {
+			pod = p
+		}
+	}
+	return pod, nil
+}
+
+// getNotebookContainerStatus returns the status of the notebook container which has the notebook name,
+// the other containers of the pod, e.g., the injected sidecars, are ignored
+func getNotebookContainerStatus(notebook *mlv1.Notebook, pod *corev1.Pod) *corev1.ContainerStatus {
+	for i, cs := range pod.Status.ContainerStatuses {
+		if cs.Name == notebook.Name {
+			return &pod.Status.ContainerStatuses[i]
+		}
+	}
+	return nil
+}
+
+// getNotebookPhase returns the phase, reason and message of the notebook
+func getNotebookPhase(notebook *mlv1.Notebook, ss *v1.StatefulSet, pod *corev1.Pod) (mlv1.NotebookPhase, string, string) {
+	if isNotebookStopped(notebook) {
+		// the notebook is stopping until all its pods are removed
+		if ss.Status.Replicas == 0 && pod == nil {
+			return mlv1.NotebookPhaseStopped, "", ""
+		}
+		return mlv1.NotebookPhasePending, reasonStopping, "Waiting for the notebook pod to be removed"
+	}
+	if pod == nil {
+		return mlv1.NotebookPhasePending, "", ""
+	}
+
+	if pod.Status.Phase == corev1.PodFailed {
+		reason := pod.Status.Reason
+		if reason == "" {
+			reason = string(corev1.PodFailed)
+		}
+		return mlv1.NotebookPhaseFailed, reason, pod.Status.Message
+	}
+
+	// the notebook can't be started if any of the init containers or the sidecars fails
+	statuses := make([]corev1.ContainerStatus, 0, len(pod.Status.InitContainerStatuses)+len(pod.Status.ContainerStatuses))
+	statuses = append(statuses, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		if reason, message := getContainerFailure(cs); reason != "" {
+			return mlv1.NotebookPhaseFailed, reason, fmt.Sprintf("container %s: %s", cs.Name, message)
+		}
+	}
+
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionFalse {
+			return mlv1.NotebookPhasePending, c.Reason, c.Message
+		}
+	}
+
+	// the container is created after its image is pulled, the image ID is unknown until then
+	for _, cs := range statuses {
+		if cs.State.Waiting != nil && cs.State.Waiting.Reason == reasonContainerCreating && cs.ImageID == "" {
+			return mlv1.NotebookPhasePulling, reasonContainerCreating, fmt.Sprintf("Pulling image %s", cs.Image)
+		}
+	}
+
+	cs := getNotebookContainerStatus(notebook, pod)
+	switch {
+	case cs == nil:
+		return mlv1.NotebookPhasePending, "", ""
+	case cs.State.Running != nil && cs.Ready:
+		return mlv1.NotebookPhaseRunning, "", ""
+	case cs.State.Waiting != nil:
+		return mlv1.NotebookPhasePending, cs.State.Waiting.Reason, cs.State.Waiting.Message
+	default:
+		return mlv1.NotebookPhasePending, "", ""
+	}
+}
+
+// getContainerFailure returns the failure reason and message of the container, the reason is empty if it isn't failed
+func getContainerFailure(cs corev1.ContainerStatus) (string, string) {
+	if t := cs.State.Terminated; t != nil && t.Reason == reasonOOMKilled {
+		return reasonOOMKilled, fmt.Sprintf("terminated with exit code %d", t.ExitCode)
+	}
+
+	w := cs.State.Waiting
+	if w == nil || !containerFailureReasons[w.Reason] {
+		return "", ""
+	}
+	if last := cs.LastTerminationState.Terminated; last != nil && last.Reason == reasonOOMKilled {
+		return reasonOOMKilled, fmt.Sprintf("restarted %d times, last terminated with exit code %d", cs.RestartCount, last.ExitCode)
+	}
+	return w.Reason, w.Message
+}
+
+func getNotebookConditions(pod *corev1.Pod, previous []mgmtv1.Condition, now time.Time) []mgmtv1.Condition {
+	conditions := make([]mgmtv1.Condition, 0, len(pod.Status.Conditions))
+	for _, pCond := range pod.Status.Conditions {
+		var prev *mgmtv1.Condition
+		for i := range previous {
+			if string(previous[i].Type) == string(pCond.Type) {
+				prev = &previous[i]
+				break
+			}
+		}
+		conditions = append(conditions, podCondToNotebookCond(pCond, prev, now))
+	}
+	return conditions
+}
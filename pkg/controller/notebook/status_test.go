@@ -0,0 +1,137 @@
+package notebook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	mgmtv1 "github.com/oneblock-ai/oneblock/pkg/apis/management.oneblock.ai/v1"
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+	"github.com/oneblock-ai/oneblock/pkg/generated/clientset/versioned/fake"
+	"github.com/oneblock-ai/oneblock/pkg/utils/fakeclients"
+)
+
+func Test_getNotebookPhase(t *testing.T) {
+	notebook := &mlv1.Notebook{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nb"}}
+	ss := &appsv1.StatefulSet{Status: appsv1.StatefulSetStatus{Replicas: 1}}
+
+	var testCases = []struct {
+		name     string
+		statuses []corev1.ContainerStatus
+		phase    mlv1.NotebookPhase
+		reason   string
+	}{
+		{
+			name: "pulling image",
+			statuses: []corev1.ContainerStatus{
+				{Name: "nb", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}},
+			},
+			phase:  mlv1.NotebookPhasePulling,
+			reason: "ContainerCreating",
+		},
+		{
+			name: "image pull failure of the sidecar",
+			statuses: []corev1.ContainerStatus{
+				{Name: "istio-proxy", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}}},
+				{Name: "nb", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}, Ready: true},
+			},
+			phase:  mlv1.NotebookPhaseFailed,
+			reason: "ImagePullBackOff",
+		},
+		{
+			name: "out of memory",
+			statuses: []corev1.ContainerStatus{
+				{
+					Name:  "nb",
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+					LastTerminationState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137},
+					},
+				},
+			},
+			phase:  mlv1.NotebookPhaseFailed,
+			reason: "OOMKilled",
+		},
+		{
+			name: "running with a sidecar not ready",
+			statuses: []corev1.ContainerStatus{
+				{Name: "nb-sidecar", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}, ImageID: "sidecar"},
+				{Name: "nb", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}, Ready: true, ImageID: "nb"},
+			},
+			phase: mlv1.NotebookPhaseRunning,
+		},
+	}
+
+	for _, tc := range testCases {
+		pod := &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: tc.statuses}}
+		phase, reason, _ := getNotebookPhase(notebook, ss, pod)
+		assert.Equal(t, tc.phase, phase, "case %q", tc.name)
+		assert.Equal(t, tc.reason, reason, "case %q", tc.name)
+	}
+}
+
+func TestHandler_updateNotebookStatus(t *testing.T) {
+	notebook := &mlv1.Notebook{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nb"}}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nb-0", Labels: getNotebookPodLabel(notebook)},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionTrue}},
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "nb", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ErrImagePull"}}},
+			},
+		},
+	}
+	fakeClient := fake.NewSimpleClientset(notebook)
+	k8sClient := k8sfake.NewSimpleClientset(pod)
+	recorder := record.NewFakeRecorder(10)
+	h := &Handler{
+		notebooks: fakeclients.NotebookClient(fakeClient.MlV1().Notebooks),
+		podCache:  fakeclients.PodCache(k8sClient.CoreV1().Pods),
+		recorder:  recorder,
+	}
+	ss := &appsv1.StatefulSet{Status: appsv1.StatefulSetStatus{Replicas: 1}}
+
+	require.NoError(t, h.updateNotebookStatus(notebook, ss))
+	updated, err := h.notebooks.Get("default", "nb", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, mlv1.NotebookPhaseFailed, updated.Status.Phase)
+	assert.Equal(t, "ErrImagePull", updated.Status.Reason)
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, EventReasonImagePullFailed)
+
+	// the condition timestamps are kept and no event is recorded again if nothing changes
+	require.Len(t, updated.Status.Conditions, 1)
+	lastUpdateTime := updated.Status.Conditions[0].LastUpdateTime
+	time.Sleep(time.Second)
+	require.NoError(t, h.updateNotebookStatus(updated, ss))
+	latest, err := h.notebooks.Get("default", "nb", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, updated.ResourceVersion, latest.ResourceVersion)
+	assert.Equal(t, lastUpdateTime, latest.Status.Conditions[0].LastUpdateTime)
+	assert.Empty(t, recorder.Events)
+}
+
+func Test_podCondToNotebookCond(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	previous := &mgmtv1.Condition{
+		Type:               "Ready",
+		Status:             metav1.ConditionFalse,
+		LastUpdateTime:     "2024-01-01T09:00:00Z",
+		LastTransitionTime: "2024-01-01T09:00:00Z",
+	}
+
+	condition := podCondToNotebookCond(corev1.PodCondition{Type: corev1.PodReady, Status: corev1.ConditionFalse}, previous, now)
+	assert.Equal(t, "2024-01-01T09:00:00Z", condition.LastUpdateTime)
+	assert.Equal(t, "2024-01-01T09:00:00Z", condition.LastTransitionTime)
+
+	condition = podCondToNotebookCond(corev1.PodCondition{Type: corev1.PodReady, Status: corev1.ConditionTrue}, previous, now)
+	assert.Equal(t, "2024-01-01T10:00:00Z", condition.LastUpdateTime)
+	assert.Equal(t, "2024-01-01T10:00:00Z", condition.LastTransitionTime)
+}
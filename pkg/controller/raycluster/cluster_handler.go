@@ -5,18 +5,18 @@ import (
 
 	ctlcorev1 "github.com/rancher/wrangler/v2/pkg/generated/controllers/core/v1"
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	ctlkuberayv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ray.io/v1"
 	"github.com/oneblock-ai/oneblock/pkg/server/config"
-	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
 )
 
 const (
 	kubeRayControllerSyncCluster = "rayCluster.syncCluster"
 	kubeRayControllerOnDelete    = "rayCluster.onDelete"
 	kubeRayControllerCreatePVC   = "rayCluster.createPVCFromAnnotation"
+	kubeRayControllerIdleSuspend = "rayCluster.idleSuspend"
+
+	idleSuspenderAgentName = "oneblock-raycluster-idle-suspender"
 )
 
 // handler reconcile the user's clusterRole and clusterRoleBinding
@@ -29,7 +29,7 @@ type handler struct {
 	secretsCache ctlcorev1.SecretCache
 	pvcs         ctlcorev1.PersistentVolumeClaimClient
 	pvcCache     ctlcorev1.PersistentVolumeClaimCache
-	configmap    ctlcorev1.ConfigMapClient
+	namespaces   ctlcorev1.NamespaceController
 }
 
 func Register(ctx context.Context, mgmt *config.Management) error {
@@ -37,7 +37,7 @@ func Register(ctx context.Context, mgmt *config.Management) error {
 	services := mgmt.CoreFactory.Core().V1().Service()
 	secrets := mgmt.CoreFactory.Core().V1().Secret()
 	pvcs := mgmt.CoreFactory.Core().V1().PersistentVolumeClaim()
-	configmaps := mgmt.CoreFactory.Core().V1().ConfigMap()
+	namespaces := mgmt.CoreFactory.Core().V1().Namespace()
 
 	h := &handler{
 		releaseName:  mgmt.ReleaseName,
@@ -48,12 +48,15 @@ func Register(ctx context.Context, mgmt *config.Management) error {
 		secretsCache: secrets.Cache(),
 		pvcs:         pvcs,
 		pvcCache:     pvcs.Cache(),
-		configmap:    configmaps,
+		namespaces:   namespaces,
 	}
 
 	clusters.OnChange(ctx, kubeRayControllerSyncCluster, h.OnChanged)
 	clusters.OnChange(ctx, kubeRayControllerCreatePVC, h.createPVCFromAnnotation)
 	clusters.OnRemove(ctx, kubeRayControllerOnDelete, h.OnDelete)
+
+	suspender := newIdleSuspender(clusters, mgmt.NewRecorder(idleSuspenderAgentName))
+	clusters.OnChange(ctx, kubeRayControllerIdleSuspend, suspender.OnChanged)
 	return nil
 }
 
@@ -68,31 +71,14 @@ func (h *handler) OnChanged(_ string, cluster *rayv1.RayCluster) (*rayv1.RayClus
 	return nil, nil
 }
 
+// OnDelete triggers the garbage collection of the cluster namespace, the model ConfigMaps and synced secrets may be
+// shared by the other clusters of the namespace and are removed once none of them is left, the namespace is
+// enqueued again once the cluster is removed after the redis clean up job is finished
 func (h *handler) OnDelete(_ string, cluster *rayv1.RayCluster) (*rayv1.RayCluster, error) {
 	if cluster == nil || cluster.DeletionTimestamp == nil {
 		return nil, nil
 	}
 
-	// clean up the mounted resources
-	modelTemplateVersionName := cluster.Annotations[constant.AnnoModelTemplateVersionName]
-	if modelTemplateVersionName == "" {
-		return nil, nil
-	}
-
-	// wait for the redis clean up job finished first since it will mount all volumes and configmaps to it
-	for _, f := range cluster.Finalizers {
-		if f == constant.RayRedisCleanUpFinalizer {
-			h.rayClusters.Enqueue(cluster.Namespace, cluster.Name)
-			return cluster, nil
-		}
-	}
-
-	if modelTemplateVersionName != "" {
-		err := h.configmap.Delete(cluster.Namespace, modelTemplateVersionName, &metav1.DeleteOptions{})
-		if err != nil && !errors.IsNotFound(err) {
-			return cluster, err
-		}
-	}
-
-	return nil, nil
+	h.namespaces.Enqueue(cluster.Namespace)
+	return cluster, nil
 }
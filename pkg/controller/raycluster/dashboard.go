@@ -0,0 +1,40 @@
+package raycluster
+
+import (
+	"fmt"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+const (
+	DefaultDashboardPort = int32(8265)
+	dashboardPortName    = "dashboard"
+)
+
+// GetHeadServiceName returns the head service name of the cluster, the service name of KubeRay is <cluster>-head-svc
+// unless it is overridden by the head service of the cluster
+func GetHeadServiceName(cluster *rayv1.RayCluster) string {
+	if svc := cluster.Spec.HeadGroupSpec.HeadService; svc != nil && svc.Name != "" {
+		return svc.Name
+	}
+	return fmt.Sprintf("%s-%s-svc", cluster.Name, rayv1.HeadNode)
+}
+
+// GetDashboardURL returns the in-cluster URL of the Ray dashboard, the jobs API and the logs are served by it as well
+func GetDashboardURL(cluster *rayv1.RayCluster) string {
+	return fmt.Sprintf("http://%s.%s.svc:%d", GetHeadServiceName(cluster), cluster.Namespace, getDashboardPort(cluster))
+}
+
+// getDashboardPort returns the dashboard port of the head container which is exposed by the head service
+func getDashboardPort(cluster *rayv1.RayCluster) int32 {
+	containers := cluster.Spec.HeadGroupSpec.Template.Spec.Containers
+	if len(containers) == 0 {
+		return DefaultDashboardPort
+	}
+	for _, port := range containers[0].Ports {
+		if port.Name == dashboardPortName {
+			return port.ContainerPort
+		}
+	}
+	return DefaultDashboardPort
+}
@@ -0,0 +1,270 @@
+package raycluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+
+	ctlkuberayv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ray.io/v1"
+	"github.com/oneblock-ai/oneblock/pkg/settings"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+)
+
+const (
+	idleCheckPeriod    = time.Minute
+	idleRequestTimeout = 10 * time.Second
+	// activityRecordPeriod is the interval to refresh the last activity of the busy clusters, the clusters may be
+	// suspended earlier by at most the period
+	activityRecordPeriod = 10 * time.Minute
+
+	EventReasonIdleSuspended = "IdleSuspended"
+)
+
+// busyJobStatuses are the statuses of the Ray jobs which are not finished
+var busyJobStatuses = map[string]bool{
+	"PENDING": true,
+	"RUNNING": true,
+}
+
+// idleSuspender suspends the interactive clusters which have no running jobs, actors or tasks for the idle time,
+// the pods of the suspended cluster are removed by KubeRay while the GCS state is kept in Redis if the GCS fault
+// tolerance is enabled, and the cluster is resumed by clearing the suspension.
+// The clusters of the RayServices and RayJobs are skipped since their lifecycle is managed by KubeRay, and the
+// suspension is disabled by default, the clusters of the public namespace are only suspended if they opt in by
+// the idle time annotation.
+// The last time the cluster is found busy is recorded in its annotation to survive the restart of the controller.
+type idleSuspender struct {
+	httpClient  *http.Client
+	rayClusters ctlkuberayv1.RayClusterController
+	recorder    record.EventRecorder
+	// dashboardURL returns the base URL of the Ray dashboard
+	dashboardURL func(cluster *rayv1.RayCluster) string
+	now          func() time.Time
+}
+
+type rayJob struct {
+	Status string `json:"status"`
+}
+
+// rayStateResponse is the response of the Ray state API, e.g., /api/v0/actors
+type rayStateResponse struct {
+	Result bool   `json:"result"`
+	Msg    string `json:"msg"`
+	Data   struct {
+		Result struct {
+			Result []json.RawMessage `json:"result"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+func newIdleSuspender(rayClusters ctlkuberayv1.RayClusterController, recorder record.EventRecorder) *idleSuspender {
+	return &idleSuspender{
+		httpClient:   &http.Client{Timeout: idleRequestTimeout},
+		rayClusters:  rayClusters,
+		recorder:     recorder,
+		dashboardURL: GetDashboardURL,
+		now:          time.Now,
+	}
+}
+
+func (s *idleSuspender) OnChanged(_ string, cluster *rayv1.RayCluster) (*rayv1.RayCluster, error) {
+	if cluster == nil || cluster.DeletionTimestamp != nil {
+		return cluster, nil
+	}
+
+	if IsSuspended(cluster) {
+		return s.syncSuspendedSince(cluster)
+	}
+	if _, ok := cluster.Annotations[constant.AnnotationRayClusterSuspendedAt]; ok {
+		// the cluster is resumed, the idle time is counted again once it is ready
+		clusterCpy := cluster.DeepCopy()
+		delete(clusterCpy.Annotations, constant.AnnotationRayClusterSuspendedAt)
+		return s.rayClusters.Update(clusterCpy)
+	}
+
+	idleTime := getIdleSuspendTime(cluster)
+	if idleTime <= 0 || isOwnedByRayWorkload(cluster) {
+		return s.removeLastActivity(cluster)
+	}
+
+	// check the activity of the cluster periodically
+	s.rayClusters.EnqueueAfter(cluster.Namespace, cluster.Name, idleCheckPeriod)
+	if cluster.Status.State != rayv1.Ready {
+		return cluster, nil
+	}
+
+	busy, err := s.isBusy(cluster)
+	if err != nil {
+		logrus.Warnf("Failed to get the activity of RayCluster %s/%s: %v", cluster.Namespace, cluster.Name, err)
+		return cluster, nil
+	}
+
+	now := s.now()
+	lastActivity, ok := getLastActivity(cluster)
+	if busy || !ok {
+		if ok && now.Sub(lastActivity) < activityRecordPeriod {
+			return cluster, nil
+		}
+		clusterCpy := cluster.DeepCopy()
+		setAnnotationTime(clusterCpy, constant.AnnotationRayClusterActiveAt, now)
+		return s.rayClusters.Update(clusterCpy)
+	}
+
+	idle := now.Sub(lastActivity)
+	if idle < idleTime {
+		return cluster, nil
+	}
+
+	logrus.Infof("Suspending RayCluster %s/%s which is idle for %s", cluster.Namespace, cluster.Name, idle.Round(time.Second))
+	clusterCpy := cluster.DeepCopy()
+	clusterCpy.Spec.Suspend = pointer.Bool(true)
+	setAnnotationTime(clusterCpy, constant.AnnotationRayClusterSuspendedAt, now)
+	delete(clusterCpy.Annotations, constant.AnnotationRayClusterActiveAt)
+	updated, err := s.rayClusters.Update(clusterCpy)
+	if err != nil {
+		return cluster, err
+	}
+
+	s.recorder.Eventf(updated, corev1.EventTypeNormal, EventReasonIdleSuspended,
+		"RayCluster is suspended since it has been idle from %s", lastActivity.UTC().Format(time.RFC3339))
+	return updated, nil
+}
+
+// syncSuspendedSince records the suspended time of the clusters suspended by the users, the last activity is
+// removed so that the idle time is counted from the resumption
+func (s *idleSuspender) syncSuspendedSince(cluster *rayv1.RayCluster) (*rayv1.RayCluster, error) {
+	_, suspendedAt := cluster.Annotations[constant.AnnotationRayClusterSuspendedAt]
+	_, activeAt := cluster.Annotations[constant.AnnotationRayClusterActiveAt]
+	if suspendedAt && !activeAt {
+		return cluster, nil
+	}
+	clusterCpy := cluster.DeepCopy()
+	if !suspendedAt {
+		setAnnotationTime(clusterCpy, constant.AnnotationRayClusterSuspendedAt, s.now())
+	}
+	delete(clusterCpy.Annotations, constant.AnnotationRayClusterActiveAt)
+	return s.rayClusters.Update(clusterCpy)
+}
+
+func (s *idleSuspender) removeLastActivity(cluster *rayv1.RayCluster) (*rayv1.RayCluster, error) {
+	if _, ok := cluster.Annotations[constant.AnnotationRayClusterActiveAt]; !ok {
+		return cluster, nil
+	}
+	clusterCpy := cluster.DeepCopy()
+	delete(clusterCpy.Annotations, constant.AnnotationRayClusterActiveAt)
+	return s.rayClusters.Update(clusterCpy)
+}
+
+// getLastActivity returns the last time the cluster is found busy, it returns false if it is not recorded
+func getLastActivity(cluster *rayv1.RayCluster) (time.Time, bool) {
+	value, ok := cluster.Annotations[constant.AnnotationRayClusterActiveAt]
+	if !ok {
+		return time.Time{}, false
+	}
+	lastActivity, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		logrus.Warnf("Invalid last activity %s of RayCluster %s/%s, counting the idle time from now",
+			value, cluster.Namespace, cluster.Name)
+		return time.Time{}, false
+	}
+	return lastActivity, true
+}
+
+func setAnnotationTime(cluster *rayv1.RayCluster, key string, t time.Time) {
+	if cluster.Annotations == nil {
+		cluster.Annotations = make(map[string]string, 1)
+	}
+	cluster.Annotations[key] = t.UTC().Format(time.RFC3339)
+}
+
+// IsSuspended checks whether the cluster is suspended, the pods are removed by KubeRay once it's suspended
+func IsSuspended(cluster *rayv1.RayCluster) bool {
+	return cluster.Spec.Suspend != nil && *cluster.Spec.Suspend
+}
+
+// getIdleSuspendTime returns the idle time of the cluster to be suspended, the annotation of the cluster
+// overrides the setting, a non-positive value disables the suspension. The setting doesn't apply to the clusters
+// of the public namespace since they are shared by the notebooks of all the users.
+func getIdleSuspendTime(cluster *rayv1.RayCluster) time.Duration {
+	minutes := settings.RayClusterIdleTime.GetInt()
+	if cluster.Namespace == constant.PublicNamespaceName {
+		minutes = 0
+	}
+	if value, ok := cluster.Annotations[constant.AnnotationRayClusterIdleTime]; ok {
+		m, err := strconv.Atoi(value)
+		if err != nil {
+			logrus.Warnf("Invalid idle suspend time %s of RayCluster %s/%s, using the default %d minutes",
+				value, cluster.Namespace, cluster.Name, minutes)
+		} else {
+			minutes = m
+		}
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// isOwnedByRayWorkload checks whether the cluster is created by KubeRay for a RayService or RayJob
+func isOwnedByRayWorkload(cluster *rayv1.RayCluster) bool {
+	for _, owner := range cluster.OwnerReferences {
+		gv, err := schema.ParseGroupVersion(owner.APIVersion)
+		if err == nil && gv.Group == rayv1.SchemeGroupVersion.Group {
+			return true
+		}
+	}
+	return false
+}
+
+// isBusy checks whether the cluster has any unfinished jobs, alive actors or running tasks by the dashboard API
+func (s *idleSuspender) isBusy(cluster *rayv1.RayCluster) (bool, error) {
+	baseURL := s.dashboardURL(cluster)
+
+	jobs := make([]rayJob, 0)
+	if err := s.getJSON(baseURL+"/api/jobs/", &jobs); err != nil {
+		return false, err
+	}
+	for _, job := range jobs {
+		if busyJobStatuses[job.Status] {
+			return true, nil
+		}
+	}
+
+	for _, state := range []struct{ resource, value string }{
+		{resource: "actors", value: "ALIVE"},
+		{resource: "tasks", value: "RUNNING"},
+	} {
+		resp := &rayStateResponse{}
+		url := fmt.Sprintf("%s/api/v0/%s?filter_keys=state&filter_predicates=%%3D&filter_values=%s&limit=1",
+			baseURL, state.resource, state.value)
+		if err := s.getJSON(url, resp); err != nil {
+			return false, err
+		}
+		if !resp.Result {
+			return false, fmt.Errorf("failed to list the %s: %s", state.resource, resp.Msg)
+		}
+		if len(resp.Data.Result.Result) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *idleSuspender) getJSON(url string, obj interface{}) error {
+	resp, err := s.httpClient.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d of %s", resp.StatusCode, url)
+	}
+	return json.NewDecoder(resp.Body).Decode(obj)
+}
@@ -0,0 +1,102 @@
+package raycluster
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+)
+
+func TestIdleSuspender_isBusy(t *testing.T) {
+	jobs := `[{"job_id":"01000000","status":"SUCCEEDED"}]`
+	actors := `{"result":true,"msg":"","data":{"result":{"total":0,"result":[]}}}`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/api/jobs/":
+			_, _ = rw.Write([]byte(jobs))
+		case "/api/v0/actors":
+			_, _ = rw.Write([]byte(actors))
+		case "/api/v0/tasks":
+			_, _ = rw.Write([]byte(`{"result":true,"msg":"","data":{"result":{"total":0,"result":[]}}}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	s := &idleSuspender{
+		httpClient: server.Client(),
+		dashboardURL: func(_ *rayv1.RayCluster) string {
+			return server.URL
+		},
+	}
+	cluster := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "oneblock-public", Name: "default-cluster"}}
+
+	busy, err := s.isBusy(cluster)
+	require.NoError(t, err)
+	assert.False(t, busy)
+
+	// the cluster is busy while there are alive actors
+	actors = `{"result":true,"msg":"","data":{"result":{"total":1,"result":[{"actor_id":"a1","state":"ALIVE"}]}}}`
+	busy, err = s.isBusy(cluster)
+	require.NoError(t, err)
+	assert.True(t, busy)
+
+	jobs = `[{"job_id":"02000000","status":"RUNNING"}]`
+	actors = `{"result":false,"msg":"failed to list actors","data":{}}`
+	busy, err = s.isBusy(cluster)
+	require.NoError(t, err)
+	assert.True(t, busy)
+
+	jobs = `[]`
+	_, err = s.isBusy(cluster)
+	assert.Error(t, err)
+}
+
+func Test_getIdleSuspendTime(t *testing.T) {
+	cluster := &rayv1.RayCluster{}
+	assert.Zero(t, getIdleSuspendTime(cluster))
+
+	cluster.Annotations = map[string]string{constant.AnnotationRayClusterIdleTime: "30"}
+	assert.Equal(t, 30*time.Minute, getIdleSuspendTime(cluster))
+
+	// the clusters of the public namespace are only suspended if they opt in
+	public := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Namespace: constant.PublicNamespaceName}}
+	assert.Zero(t, getIdleSuspendTime(public))
+	public.Annotations = map[string]string{constant.AnnotationRayClusterIdleTime: "60"}
+	assert.Equal(t, time.Hour, getIdleSuspendTime(public))
+
+	cluster.Annotations[constant.AnnotationRayClusterIdleTime] = "0"
+	assert.Zero(t, getIdleSuspendTime(cluster))
+}
+
+func Test_isOwnedByRayWorkload(t *testing.T) {
+	cluster := &rayv1.RayCluster{}
+	assert.False(t, isOwnedByRayWorkload(cluster))
+
+	cluster.OwnerReferences = []metav1.OwnerReference{{APIVersion: rayv1.SchemeGroupVersion.String(), Kind: "RayService", Name: "llm"}}
+	assert.True(t, isOwnedByRayWorkload(cluster))
+}
+
+func Test_getLastActivity(t *testing.T) {
+	cluster := &rayv1.RayCluster{}
+	_, ok := getLastActivity(cluster)
+	assert.False(t, ok)
+
+	now := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+	setAnnotationTime(cluster, constant.AnnotationRayClusterActiveAt, now)
+	lastActivity, ok := getLastActivity(cluster)
+	assert.True(t, ok)
+	assert.True(t, now.Equal(lastActivity))
+
+	cluster.Annotations[constant.AnnotationRayClusterActiveAt] = "yesterday"
+	_, ok = getLastActivity(cluster)
+	assert.False(t, ok)
+}
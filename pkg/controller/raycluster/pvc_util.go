@@ -23,6 +23,11 @@ func (h *handler) createPVCFromAnnotation(_ string, cluster *rayv1.RayCluster) (
 		return nil, nil
 	}
 
+	// the annotation is copied from the RayJob, its volumes are created by the RayJob controller
+	if isOwnedByRayJob(cluster) {
+		return nil, nil
+	}
+
 	var pvcs []*corev1.PersistentVolumeClaim
 	if err := json.Unmarshal([]byte(pvcTemplates), &pvcs); err != nil {
 		return nil, err
@@ -69,3 +74,12 @@ func (h *handler) createPVCFromAnnotation(_ string, cluster *rayv1.RayCluster) (
 	}
 	return nil, nil
 }
+
+func isOwnedByRayJob(cluster *rayv1.RayCluster) bool {
+	for _, owner := range cluster.OwnerReferences {
+		if owner.Kind == constant.RayJobKind {
+			return true
+		}
+	}
+	return false
+}
@@ -9,13 +9,14 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/oneblock-ai/oneblock/pkg/utils"
 	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
 )
 
 func (h *handler) syncGCSRedisSecretToNamespace(releaseName string, rayCluster *rayv1.RayCluster) error {
 	// get redis secret for GCS config
 	// if the secret is not ready, reconcile it
-	redisSecret, err := h.secretsCache.Get(constant.SystemNamespaceName, fmt.Sprintf("%s-redis", releaseName))
+	redisSecret, err := h.secretsCache.Get(constant.SystemNamespaceName, GetGCSRedisSecretName(releaseName))
 	if err != nil {
 		return fmt.Errorf("failed to get system dedis redisSecret: %v", err)
 	}
@@ -28,18 +29,17 @@ func (h *handler) syncGCSRedisSecretToNamespace(releaseName string, rayCluster *
 			if _, err := h.secrets.Create(newSecret); err != nil {
 				return fmt.Errorf("failed to sync redis secret in ns %s: %v", rayCluster.Namespace, err)
 			}
-
+			return nil
 		}
 		return err
 	}
 
-	// sync GCS redis secret to the cluster namespace
-	if !reflect.DeepEqual(redisSecret.Data, nsSecret.Data) {
-		secretCpy := nsSecret.DeepCopy()
-		secretCpy.Data = map[string][]byte{
-			constant.RedisSecretKeyName: redisSecret.Data[constant.RedisSecretKeyName],
-		}
-
+	// sync GCS redis secret to the cluster namespace, the secrets synced by the previous versions are adopted
+	// to be rotated and garbage-collected
+	secretCpy := nsSecret.DeepCopy()
+	secretCpy.Data = GetSyncedSecretData(redisSecret)
+	utils.SetSyncedSecretSource(secretCpy, redisSecret)
+	if !reflect.DeepEqual(nsSecret, secretCpy) {
 		if _, err = h.secrets.Update(secretCpy); err != nil {
 			return fmt.Errorf("failed to update secret %s: %v", GetNameSpacedGCSSecretName(rayCluster.Namespace), err)
 		}
@@ -47,6 +47,10 @@ func (h *handler) syncGCSRedisSecretToNamespace(releaseName string, rayCluster *
 	return nil
 }
 
+func GetGCSRedisSecretName(releaseName string) string {
+	return fmt.Sprintf("%s-redis", releaseName)
+}
+
 func GetGCSRedisSVCDomain(releaseName string) string {
 	return fmt.Sprintf("redis://%s-redis-master.%s.svc.cluster.local:6379", releaseName, constant.SystemNamespaceName)
 }
@@ -56,14 +60,21 @@ func GetNameSpacedGCSSecretName(namespace string) string {
 }
 
 func GetSyncedSecret(redisSecret *corev1.Secret, cluster *rayv1.RayCluster) *corev1.Secret {
-	return &corev1.Secret{
+	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      GetNameSpacedGCSSecretName(cluster.Namespace),
 			Namespace: cluster.Namespace,
 		},
-		Data: map[string][]byte{
-			constant.RedisSecretKeyName: redisSecret.Data[constant.RedisSecretKeyName],
-		},
+		Data: GetSyncedSecretData(redisSecret),
+	}
+	utils.SetSyncedSecretSource(secret, redisSecret)
+	return secret
+}
+
+// GetSyncedSecretData returns the data of the synced GCS redis secret, only the redis password is synced
+func GetSyncedSecretData(redisSecret *corev1.Secret) map[string][]byte {
+	return map[string][]byte{
+		constant.RedisSecretKeyName: redisSecret.Data[constant.RedisSecretKeyName],
 	}
 }
 
@@ -0,0 +1,55 @@
+package rayjob
+
+import (
+	"context"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/oneblock-ai/oneblock/pkg/server/config"
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+)
+
+const (
+	rayJobControllerCreatePVC = "rayJob.createPVCFromAnnotation"
+)
+
+// handler creates the volumes of the RayJob, they are owned by the job instead of the cluster created by KubeRay,
+// so that the volumes are kept after the cluster is shut down once the job finishes
+type handler struct {
+	pvcHandler *utils.PVCHandler
+}
+
+func Register(ctx context.Context, mgmt *config.Management) error {
+	jobs := mgmt.KubeRayFactory.Ray().V1().RayJob()
+	pvcs := mgmt.CoreFactory.Core().V1().PersistentVolumeClaim()
+
+	h := &handler{
+		pvcHandler: utils.NewPVCHandler(pvcs, pvcs.Cache()),
+	}
+
+	jobs.OnChange(ctx, rayJobControllerCreatePVC, h.createPVCFromAnnotation)
+	return nil
+}
+
+func (h *handler) createPVCFromAnnotation(_ string, job *rayv1.RayJob) (*rayv1.RayJob, error) {
+	if job == nil || job.DeletionTimestamp != nil {
+		return nil, nil
+	}
+
+	pvcTemplates, ok := job.Annotations[constant.AnnotationVolumeClaimTemplates]
+	if !ok || pvcTemplates == "" {
+		return nil, nil
+	}
+
+	ownerRefs := []metav1.OwnerReference{
+		{
+			APIVersion: rayv1.SchemeGroupVersion.String(),
+			Kind:       constant.RayJobKind,
+			Name:       job.Name,
+			UID:        job.UID,
+		},
+	}
+	return nil, h.pvcHandler.CreatePVCFromAnnotation(pvcTemplates, job.Namespace, ownerRefs)
+}
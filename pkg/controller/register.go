@@ -5,6 +5,7 @@ import (
 
 	"github.com/rancher/wrangler/v2/pkg/leader"
 
+	"github.com/oneblock-ai/oneblock/pkg/controller/accelerator"
 	obAuth "github.com/oneblock-ai/oneblock/pkg/controller/auth"
 	"github.com/oneblock-ai/oneblock/pkg/controller/dataset"
 	"github.com/oneblock-ai/oneblock/pkg/controller/gpu"
@@ -12,7 +13,9 @@ import (
 	"github.com/oneblock-ai/oneblock/pkg/controller/modeltemplate"
 	"github.com/oneblock-ai/oneblock/pkg/controller/notebook"
 	"github.com/oneblock-ai/oneblock/pkg/controller/raycluster"
+	"github.com/oneblock-ai/oneblock/pkg/controller/rayjob"
 	"github.com/oneblock-ai/oneblock/pkg/controller/setting"
+	"github.com/oneblock-ai/oneblock/pkg/controller/syncedresource"
 	"github.com/oneblock-ai/oneblock/pkg/controller/user"
 	"github.com/oneblock-ai/oneblock/pkg/indexeres"
 	"github.com/oneblock-ai/oneblock/pkg/server/config"
@@ -28,7 +31,10 @@ var registerFuncs = []registerFunc{
 	dataset.Register,
 	user.Register,
 	raycluster.Register,
+	rayjob.Register,
+	syncedresource.Register,
 	gpu.Register,
+	accelerator.Register,
 	notebook.Register,
 	modeltemplate.VersionRegister,
 	modeltemplate.TemplateRegister,
@@ -12,6 +12,7 @@ import (
 	ctlmgmtv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/management.oneblock.ai/v1"
 	"github.com/oneblock-ai/oneblock/pkg/server/config"
 	"github.com/oneblock-ai/oneblock/pkg/settings"
+	"github.com/oneblock-ai/oneblock/pkg/utils"
 )
 
 type handler struct {
@@ -20,13 +21,17 @@ type handler struct {
 	fallback     map[string]string
 }
 
-func Register(_ context.Context, mgmt *config.Management) error {
+const settingControllerLoadAcceleratorTypes = "setting.loadAcceleratorTypes"
+
+func Register(ctx context.Context, mgmt *config.Management) error {
+	settingController := mgmt.OneBlockMgmtFactory.Management().V1().Setting()
 	sp := &handler{
-		settings:     mgmt.OneBlockMgmtFactory.Management().V1().Setting(),
-		settingCache: mgmt.OneBlockMgmtFactory.Management().V1().Setting().Cache(),
+		settings:     settingController,
+		settingCache: settingController.Cache(),
 		fallback:     map[string]string{},
 	}
 
+	settingController.OnChange(ctx, settingControllerLoadAcceleratorTypes, utils.LoadAcceleratorTypesSetting)
 	return settings.SetProvider(sp)
 }
 
@@ -0,0 +1,236 @@
+package syncedresource
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	ctlcorev1 "github.com/rancher/wrangler/v2/pkg/generated/controllers/core/v1"
+	"github.com/rancher/wrangler/v2/pkg/relatedresource"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/oneblock-ai/oneblock/pkg/controller/raycluster"
+	ctlmlv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ml.oneblock.ai/v1"
+	ctlrayv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ray.io/v1"
+	"github.com/oneblock-ai/oneblock/pkg/indexeres"
+	"github.com/oneblock-ai/oneblock/pkg/server/config"
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+)
+
+const (
+	syncedResourceControllerCleanup        = "syncedResource.cleanupNamespace"
+	syncedResourceControllerWatchWorkloads = "syncedResource.watchWorkloads"
+	syncedResourceControllerRotateSecrets  = "syncedResource.rotateSecrets"
+)
+
+// handler garbage-collects the resources synced to the namespaces of the workloads, i.e., the GCS redis secret,
+// the HF secrets and the model ConfigMaps, they are shared by the workloads of the namespace and have no owners,
+// so they are removed once the last workload referencing them is gone. The synced secrets are also rotated
+// once their source secrets change.
+type handler struct {
+	secrets         ctlcorev1.SecretClient
+	secretCache     ctlcorev1.SecretCache
+	configmaps      ctlcorev1.ConfigMapClient
+	configmapCache  ctlcorev1.ConfigMapCache
+	rayClusterCache ctlrayv1.RayClusterCache
+	rayServiceCache ctlrayv1.RayServiceCache
+	mlServiceCache  ctlmlv1.MLServiceCache
+	serveAppCache   ctlmlv1.ServeApplicationCache
+}
+
+func Register(ctx context.Context, mgmt *config.Management) error {
+	namespaces := mgmt.CoreFactory.Core().V1().Namespace()
+	secrets := mgmt.CoreFactory.Core().V1().Secret()
+	configmaps := mgmt.CoreFactory.Core().V1().ConfigMap()
+	rayClusters := mgmt.KubeRayFactory.Ray().V1().RayCluster()
+	rayServices := mgmt.KubeRayFactory.Ray().V1().RayService()
+	mlServices := mgmt.OneBlockMLFactory.Ml().V1().MLService()
+	serveApps := mgmt.OneBlockMLFactory.Ml().V1().ServeApplication()
+
+	h := &handler{
+		secrets:         secrets,
+		secretCache:     secrets.Cache(),
+		configmaps:      configmaps,
+		configmapCache:  configmaps.Cache(),
+		rayClusterCache: rayClusters.Cache(),
+		rayServiceCache: rayServices.Cache(),
+		mlServiceCache:  mlServices.Cache(),
+		serveAppCache:   serveApps.Cache(),
+	}
+
+	namespaces.OnChange(ctx, syncedResourceControllerCleanup, h.OnNamespaceChanged)
+	relatedresource.WatchClusterScoped(ctx, syncedResourceControllerWatchWorkloads, enqueueWorkloadNamespace, namespaces,
+		rayClusters, rayServices, mlServices, serveApps)
+	secrets.OnChange(ctx, syncedResourceControllerRotateSecrets, h.rotateSyncedSecrets)
+	return nil
+}
+
+// enqueueWorkloadNamespace enqueues the namespace of the workload once it's changed or removed
+func enqueueWorkloadNamespace(namespace, _ string, _ runtime.Object) ([]relatedresource.Key, error) {
+	if namespace == "" {
+		return nil, nil
+	}
+	return []relatedresource.Key{{Name: namespace}}, nil
+}
+
+// OnNamespaceChanged removes the synced secrets once there are no workloads left in the namespace, and the model
+// ConfigMaps once there are no workloads referencing their models, the workloads being deleted are counted since
+// they may still use them, e.g., the redis clean up job of the RayCluster
+func (h *handler) OnNamespaceChanged(_ string, namespace *corev1.Namespace) (*corev1.Namespace, error) {
+	if namespace == nil || namespace.DeletionTimestamp != nil {
+		return namespace, nil
+	}
+
+	refs, err := h.getNamespaceReferences(namespace.Name)
+	if err != nil {
+		return namespace, err
+	}
+
+	if err = h.cleanupSyncedSecrets(namespace.Name, refs); err != nil {
+		return namespace, err
+	}
+	return namespace, h.cleanupModelConfigMaps(namespace.Name, refs)
+}
+
+// namespaceReferences are the synced resources referenced by the workloads of a namespace
+type namespaceReferences struct {
+	workloads int
+	models    map[string]bool
+}
+
+func (h *handler) getNamespaceReferences(namespace string) (*namespaceReferences, error) {
+	refs := &namespaceReferences{
+		models: make(map[string]bool),
+	}
+
+	clusters, err := h.rayClusterCache.List(namespace, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, cluster := range clusters {
+		refs.add(cluster.Annotations[constant.AnnoModelTemplateVersionName])
+	}
+
+	rayServices, err := h.rayServiceCache.List(namespace, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, rayService := range rayServices {
+		refs.add(rayService.Annotations[constant.AnnoModelTemplateVersionName])
+	}
+
+	mlServices, err := h.mlServiceCache.List(namespace, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, mlService := range mlServices {
+		if ref := mlService.Spec.ModelTemplateVersionRef; ref != nil {
+			refs.add(ref.Name)
+		} else {
+			refs.add("")
+		}
+	}
+
+	serveApps, err := h.serveAppCache.List(namespace, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for range serveApps {
+		refs.add("")
+	}
+	return refs, nil
+}
+
+func (r *namespaceReferences) add(model string) {
+	r.workloads++
+	if model != "" {
+		r.models[model] = true
+	}
+}
+
+func (h *handler) cleanupSyncedSecrets(namespace string, refs *namespaceReferences) error {
+	if refs.workloads > 0 {
+		return nil
+	}
+
+	secrets, err := h.secretCache.List(namespace, labels.SelectorFromSet(map[string]string{
+		constant.LabelSyncedSecret: "true",
+	}))
+	if err != nil {
+		return err
+	}
+
+	for _, secret := range secrets {
+		// only the copies synced by oneblock are deleted, they are annotated with their sources
+		if utils.GetSyncedSecretSource(secret) == "" {
+			continue
+		}
+		logrus.Infof("Deleting synced secret %s/%s since there are no workloads left in the namespace", namespace, secret.Name)
+		if err = h.secrets.Delete(namespace, secret.Name, &metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete synced secret %s/%s: %w", namespace, secret.Name, err)
+		}
+	}
+	return nil
+}
+
+func (h *handler) cleanupModelConfigMaps(namespace string, refs *namespaceReferences) error {
+	selector, err := labels.Parse(constant.LabelModelTemplateVersionName)
+	if err != nil {
+		return err
+	}
+	configmaps, err := h.configmapCache.List(namespace, selector)
+	if err != nil {
+		return err
+	}
+
+	for _, cm := range configmaps {
+		if refs.models[cm.Labels[constant.LabelModelTemplateVersionName]] {
+			continue
+		}
+		logrus.Infof("Deleting model ConfigMap %s/%s since there are no workloads referencing it", namespace, cm.Name)
+		if err = h.configmaps.Delete(namespace, cm.Name, &metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete model ConfigMap %s/%s: %w", namespace, cm.Name, err)
+		}
+	}
+	return nil
+}
+
+// rotateSyncedSecrets updates the data of the synced copies once the source secret changes
+func (h *handler) rotateSyncedSecrets(_ string, secret *corev1.Secret) (*corev1.Secret, error) {
+	if secret == nil || secret.DeletionTimestamp != nil {
+		return secret, nil
+	}
+
+	copies, err := h.secretCache.GetByIndex(indexeres.SyncedSecretSourceIndex, utils.NewRef(secret.Namespace, secret.Name))
+	if err != nil {
+		return secret, err
+	}
+
+	for _, synced := range copies {
+		data := getSyncedSecretData(secret, synced)
+		if reflect.DeepEqual(data, synced.Data) {
+			continue
+		}
+		logrus.Infof("Rotating secret %s/%s synced from %s/%s", synced.Namespace, synced.Name, secret.Namespace, secret.Name)
+		syncedCpy := synced.DeepCopy()
+		syncedCpy.Data = data
+		if _, err = h.secrets.Update(syncedCpy); err != nil {
+			return secret, fmt.Errorf("failed to rotate secret %s/%s: %w", synced.Namespace, synced.Name, err)
+		}
+	}
+	return secret, nil
+}
+
+// getSyncedSecretData returns the data of the synced copy, only the password of the GCS redis secret is synced
+func getSyncedSecretData(source, synced *corev1.Secret) map[string][]byte {
+	if synced.Name == raycluster.GetNameSpacedGCSSecretName(synced.Namespace) {
+		return raycluster.GetSyncedSecretData(source)
+	}
+	return source.Data
+}
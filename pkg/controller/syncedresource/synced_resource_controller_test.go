@@ -0,0 +1,122 @@
+package syncedresource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/oneblock-ai/oneblock/pkg/controller/raycluster"
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+	"github.com/oneblock-ai/oneblock/pkg/utils/fakeclients"
+)
+
+func newSyncedSecret(namespace, name string, source *corev1.Secret) *corev1.Secret {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	utils.SetSyncedSecretSource(secret, source)
+	return secret
+}
+
+func newModelConfigMap(namespace, model string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      model,
+			Labels:    map[string]string{constant.LabelModelTemplateVersionName: model},
+		},
+	}
+}
+
+func newTestHandler(objects ...runtime.Object) (*handler, *k8sfake.Clientset) {
+	clientSet := k8sfake.NewSimpleClientset(objects...)
+	return &handler{
+		secrets:        fakeclients.SecretClient(clientSet.CoreV1().Secrets),
+		secretCache:    fakeclients.SecretCache(clientSet.CoreV1().Secrets),
+		configmaps:     fakeclients.ConfigMapClient(clientSet.CoreV1().ConfigMaps),
+		configmapCache: fakeclients.ConfigMapCache(clientSet.CoreV1().ConfigMaps),
+	}, clientSet
+}
+
+func TestHandler_cleanupSyncedSecrets(t *testing.T) {
+	hfSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "oneblock-public", Name: "hf-token"}}
+	userSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "user-secret"}}
+
+	var testCases = []struct {
+		name      string
+		workloads int
+		remaining []string
+	}{
+		{
+			name:      "secrets are kept while there are workloads",
+			workloads: 1,
+			remaining: []string{"hf-token", "user-secret"},
+		},
+		{
+			name:      "synced secrets are deleted without workloads",
+			workloads: 0,
+			remaining: []string{"user-secret"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, clientSet := newTestHandler(hfSecret, userSecret, newSyncedSecret("default", "hf-token", hfSecret))
+			refs := &namespaceReferences{workloads: tc.workloads, models: map[string]bool{}}
+			require.NoError(t, h.cleanupSyncedSecrets("default", refs))
+
+			secrets, err := clientSet.CoreV1().Secrets("default").List(context.TODO(), metav1.ListOptions{})
+			require.NoError(t, err)
+			names := make([]string, 0, len(secrets.Items))
+			for _, s := range secrets.Items {
+				names = append(names, s.Name)
+			}
+			assert.ElementsMatch(t, tc.remaining, names)
+
+			// the source secret is never deleted
+			_, err = clientSet.CoreV1().Secrets(hfSecret.Namespace).Get(context.TODO(), hfSecret.Name, metav1.GetOptions{})
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestHandler_cleanupModelConfigMaps(t *testing.T) {
+	unlabeled := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "user-config"}}
+	h, clientSet := newTestHandler(newModelConfigMap("default", "llama2-v1"), newModelConfigMap("default", "mistral-v1"), unlabeled)
+
+	refs := &namespaceReferences{workloads: 1, models: map[string]bool{"llama2-v1": true}}
+	require.NoError(t, h.cleanupModelConfigMaps("default", refs))
+
+	configmaps, err := clientSet.CoreV1().ConfigMaps("default").List(context.TODO(), metav1.ListOptions{})
+	require.NoError(t, err)
+	names := make([]string, 0, len(configmaps.Items))
+	for _, cm := range configmaps.Items {
+		names = append(names, cm.Name)
+	}
+	assert.ElementsMatch(t, []string{"llama2-v1", "user-config"}, names)
+}
+
+func Test_getSyncedSecretData(t *testing.T) {
+	redisSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: constant.SystemNamespaceName, Name: raycluster.GetGCSRedisSecretName("oneblock")},
+		Data: map[string][]byte{
+			constant.RedisSecretKeyName: []byte("rotated"),
+			"redis-username":            []byte("default"),
+		},
+	}
+	hfSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "oneblock-public", Name: "hf-token"},
+		Data:       map[string][]byte{"token": []byte("rotated"), "user": []byte("oneblock")},
+	}
+
+	redisCopy := newSyncedSecret("default", raycluster.GetNameSpacedGCSSecretName("default"), redisSecret)
+	assert.Equal(t, map[string][]byte{constant.RedisSecretKeyName: []byte("rotated")}, getSyncedSecretData(redisSecret, redisCopy))
+
+	hfCopy := newSyncedSecret("default", "hf-token", hfSecret)
+	assert.Equal(t, hfSecret.Data, getSyncedSecretData(hfSecret, hfCopy))
+}
@@ -16,5 +16,9 @@ func Init(ctx context.Context, mgmt *config.Management, name string) error {
 		return err
 	}
 
+	if err := addDefaultNotebookImages(mgmt); err != nil {
+		return err
+	}
+
 	return addDefaultPublicRayCluster(ctx, mgmt, name)
 }
@@ -0,0 +1,169 @@
+package data
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+	ctlmlv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ml.oneblock.ai/v1"
+	"github.com/oneblock-ai/oneblock/pkg/server/config"
+)
+
+const (
+	defaultImgVersion = "latest"
+
+	// legacyNotebookImagesSettingName is the removed setting of the notebook images replaced by the NotebookImages
+	legacyNotebookImagesSettingName = "default-notebook-images"
+)
+
+var invalidNameCharsRegexp = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// legacyNotebookImage is an image of the removed default-notebook-images setting
+type legacyNotebookImage struct {
+	ContainerImage string `json:"containerImage,omitempty"`
+	Description    string `json:"description,omitempty"`
+	Default        bool   `json:"default,omitempty"`
+}
+
+// addDefaultNotebookImages adds the built-in notebook images if they are not exist, the images are referred to
+// https://www.kubeflow.org/docs/components/notebooks/container-images/
+func addDefaultNotebookImages(mgmt *config.Management) error {
+	notebookImages := mgmt.OneBlockMLFactory.Ml().V1().NotebookImage()
+	if err := migrateNotebookImagesSetting(mgmt, notebookImages); err != nil {
+		return err
+	}
+
+	for _, image := range getDefaultNotebookImages() {
+		if err := createNotebookImage(notebookImages, image); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateNotebookImagesSetting migrates the customized images of the removed default-notebook-images setting to
+// the NotebookImages, the setting is deleted afterward so that it is only migrated once
+func migrateNotebookImagesSetting(mgmt *config.Management, notebookImages ctlmlv1.NotebookImageClient) error {
+	settings := mgmt.OneBlockMgmtFactory.Management().V1().Setting()
+	setting, err := settings.Get(legacyNotebookImagesSettingName, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	// only the customized value is migrated, the images of the setting default are the built-in NotebookImages
+	if setting.Value != "" {
+		legacyImages := make(map[string][]legacyNotebookImage)
+		if err := json.Unmarshal([]byte(setting.Value), &legacyImages); err != nil {
+			logrus.Warnf("Skipping the migration of the invalid %s setting: %v", legacyNotebookImagesSettingName, err)
+		}
+		for notebookType, images := range legacyImages {
+			switch mlv1.NotebookType(notebookType) {
+			case mlv1.NotebookTypeJupyter, mlv1.NotebookTypeCodeServer, mlv1.NotebookTypeRStudio:
+			default:
+				logrus.Warnf("Skipping the images of the unknown notebook type %s", notebookType)
+				continue
+			}
+			for _, image := range images {
+				if image.ContainerImage == "" {
+					continue
+				}
+				migrated := newMigratedNotebookImage(mlv1.NotebookType(notebookType), image)
+				if err := createNotebookImage(notebookImages, migrated); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	logrus.Infof("Migrated the %s setting to the notebook images", legacyNotebookImagesSettingName)
+	return settings.Delete(legacyNotebookImagesSettingName, &metav1.DeleteOptions{})
+}
+
+func newMigratedNotebookImage(notebookType mlv1.NotebookType, image legacyNotebookImage) *mlv1.NotebookImage {
+	// the name is derived from the image, e.g., oneblockai/jupyter-scipy:v1.0 => jupyter-scipy-v1-0
+	name := image.ContainerImage[strings.LastIndex(image.ContainerImage, "/")+1:]
+	name = strings.Trim(invalidNameCharsRegexp.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if len(name) > 63 {
+		name = strings.TrimRight(name[:63], "-")
+	}
+
+	return &mlv1.NotebookImage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: mlv1.NotebookImageSpec{
+			Type:        notebookType,
+			Image:       image.ContainerImage,
+			Description: image.Description,
+			Default:     image.Default,
+		},
+	}
+}
+
+// createNotebookImage creates the image if neither the name nor the image of the notebook type exists, the image is
+// not the default if there is already a default image of the notebook type
+func createNotebookImage(notebookImages ctlmlv1.NotebookImageClient, image *mlv1.NotebookImage) error {
+	images, err := notebookImages.List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, existing := range images.Items {
+		if existing.Spec.Type != image.Spec.Type {
+			continue
+		}
+		if existing.Spec.Image == image.Spec.Image {
+			return nil
+		}
+		if existing.Name != image.Name && existing.Spec.Default {
+			image.Spec.Default = false
+		}
+	}
+
+	if _, err := notebookImages.Create(image); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return nil
+		}
+		return err
+	}
+	logrus.Debugf("Added notebook image %s", image.Name)
+	return nil
+}
+
+func getDefaultNotebookImages() []*mlv1.NotebookImage {
+	return []*mlv1.NotebookImage{
+		newNotebookImage("jupyter-scipy", mlv1.NotebookTypeJupyter, "JupyterLab + SciPy", false, true),
+		newNotebookImage("jupyter-pytorch", mlv1.NotebookTypeJupyter, "JupyterLab + PyTorch", false, false),
+		newNotebookImage("jupyter-pytorch-full", mlv1.NotebookTypeJupyter, "JupyterLab + PyTorch + Common Packages", false, false),
+		newNotebookImage("jupyter-pytorch-cuda", mlv1.NotebookTypeJupyter, "JupyterLab + PyTorch + CUDA", true, false),
+		newNotebookImage("jupyter-pytorch-cuda-full", mlv1.NotebookTypeJupyter, "JupyterLab + PyTorch + CUDA + Common Packages", true, false),
+		newNotebookImage("jupyter-tensorflow", mlv1.NotebookTypeJupyter, "JupyterLab + TensorFlow", false, false),
+		newNotebookImage("jupyter-tensorflow-full", mlv1.NotebookTypeJupyter, "JupyterLab + TensorFlow + Common Packages", false, false),
+		newNotebookImage("jupyter-tensorflow-cuda", mlv1.NotebookTypeJupyter, "JupyterLab + TensorFlow + CUDA", true, false),
+		newNotebookImage("jupyter-tensorflow-cuda-full", mlv1.NotebookTypeJupyter, "JupyterLab + TensorFlow + CUDA + Common Packages", true, false),
+		newNotebookImage("codeserver-python", mlv1.NotebookTypeCodeServer, "Visual Studio Code + Conda Python", false, true),
+		newNotebookImage("rstudio-tidyverse", mlv1.NotebookTypeRStudio, "RStudio + Tidyverse", false, true),
+	}
+}
+
+func newNotebookImage(name string, notebookType mlv1.NotebookType, description string, gpu, isDefault bool) *mlv1.NotebookImage {
+	return &mlv1.NotebookImage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: mlv1.NotebookImageSpec{
+			Type:         notebookType,
+			Image:        "oneblockai/" + name + ":" + defaultImgVersion,
+			Description:  description,
+			GPUSupported: gpu,
+			Default:      isDefault,
+		},
+	}
+}
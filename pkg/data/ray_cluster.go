@@ -87,9 +87,9 @@ func getDefaultRayCluster(releaseName string) (*rayv1.RayCluster, error) {
 		constant.AnnotationVolumeClaimTemplates: string(pvcAnno),
 	}
 
+	// the queue bound to the namespace is assigned by the webhook
 	labels := map[string]string{
 		constant.LabelRaySchedulerName: constant.VolcanoSchedulerName,
-		constant.LabelVolcanoQueueName: constant.DefaultQueueName,
 	}
 
 	rayStartParams := map[string]string{
@@ -36,7 +36,7 @@ func getQueueObject() *schedulingv1.Queue {
 	return &schedulingv1.Queue{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        constant.DefaultQueueName,
-			Annotations: GetDefaultQueueAnnotations("all"),
+			Annotations: GetDefaultQueueAnnotations(constant.AllNamespaces),
 		},
 		Spec: schedulingv1.QueueSpec{
 			Weight:      1,
@@ -28,6 +28,10 @@ type FakeManagementV1 struct {
 	*testing.Fake
 }
 
+func (c *FakeManagementV1) AcceleratorInventories() v1.AcceleratorInventoryInterface {
+	return &FakeAcceleratorInventories{c}
+}
+
 func (c *FakeManagementV1) Settings() v1.SettingInterface {
 	return &FakeSettings{c}
 }
@@ -18,6 +18,8 @@ limitations under the License.
 
 package v1
 
+type AcceleratorInventoryExpansion interface{}
+
 type SettingExpansion interface{}
 
 type UserExpansion interface{}
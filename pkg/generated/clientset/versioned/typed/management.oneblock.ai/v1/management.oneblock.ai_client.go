@@ -28,6 +28,7 @@ import (
 
 type ManagementV1Interface interface {
 	RESTClient() rest.Interface
+	AcceleratorInventoriesGetter
 	SettingsGetter
 	UsersGetter
 }
@@ -37,6 +38,10 @@ type ManagementV1Client struct {
 	restClient rest.Interface
 }
 
+func (c *ManagementV1Client) AcceleratorInventories() AcceleratorInventoryInterface {
+	return newAcceleratorInventories(c)
+}
+
 func (c *ManagementV1Client) Settings() SettingInterface {
 	return newSettings(c)
 }
@@ -44,10 +44,22 @@ func (c *FakeMlV1) ModelTemplateVersions(namespace string) v1.ModelTemplateVersi
 	return &FakeModelTemplateVersions{c, namespace}
 }
 
+func (c *FakeMlV1) NotebookImages() v1.NotebookImageInterface {
+	return &FakeNotebookImages{c}
+}
+
+func (c *FakeMlV1) NotebookProfiles() v1.NotebookProfileInterface {
+	return &FakeNotebookProfiles{c}
+}
+
 func (c *FakeMlV1) Notebooks(namespace string) v1.NotebookInterface {
 	return &FakeNotebooks{c, namespace}
 }
 
+func (c *FakeMlV1) ServeApplications(namespace string) v1.ServeApplicationInterface {
+	return &FakeServeApplications{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeMlV1) RESTClient() rest.Interface {
@@ -27,3 +27,9 @@ type ModelTemplateExpansion interface{}
 type ModelTemplateVersionExpansion interface{}
 
 type NotebookExpansion interface{}
+
+type NotebookImageExpansion interface{}
+
+type NotebookProfileExpansion interface{}
+
+type ServeApplicationExpansion interface{}
@@ -32,7 +32,10 @@ type MlV1Interface interface {
 	MLServicesGetter
 	ModelTemplatesGetter
 	ModelTemplateVersionsGetter
+	NotebookImagesGetter
+	NotebookProfilesGetter
 	NotebooksGetter
+	ServeApplicationsGetter
 }
 
 // MlV1Client is used to interact with features provided by the ml.oneblock.ai group.
@@ -56,10 +59,22 @@ func (c *MlV1Client) ModelTemplateVersions(namespace string) ModelTemplateVersio
 	return newModelTemplateVersions(c, namespace)
 }
 
+func (c *MlV1Client) NotebookImages() NotebookImageInterface {
+	return newNotebookImages(c)
+}
+
+func (c *MlV1Client) NotebookProfiles() NotebookProfileInterface {
+	return newNotebookProfiles(c)
+}
+
 func (c *MlV1Client) Notebooks(namespace string) NotebookInterface {
 	return newNotebooks(c, namespace)
 }
 
+func (c *MlV1Client) ServeApplications(namespace string) ServeApplicationInterface {
+	return newServeApplications(c, namespace)
+}
+
 // NewForConfig creates a new MlV1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
@@ -31,6 +31,7 @@ func init() {
 }
 
 type Interface interface {
+	AcceleratorInventory() AcceleratorInventoryController
 	Setting() SettingController
 	User() UserController
 }
@@ -45,6 +46,10 @@ type version struct {
 	controllerFactory controller.SharedControllerFactory
 }
 
+func (v *version) AcceleratorInventory() AcceleratorInventoryController {
+	return generic.NewNonNamespacedController[*v1.AcceleratorInventory, *v1.AcceleratorInventoryList](schema.GroupVersionKind{Group: "management.oneblock.ai", Version: "v1", Kind: "AcceleratorInventory"}, "acceleratorinventories", v.controllerFactory)
+}
+
 func (v *version) Setting() SettingController {
 	return generic.NewNonNamespacedController[*v1.Setting, *v1.SettingList](schema.GroupVersionKind{Group: "management.oneblock.ai", Version: "v1", Kind: "Setting"}, "settings", v.controllerFactory)
 }
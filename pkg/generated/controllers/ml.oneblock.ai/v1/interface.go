@@ -36,6 +36,9 @@ type Interface interface {
 	ModelTemplate() ModelTemplateController
 	ModelTemplateVersion() ModelTemplateVersionController
 	Notebook() NotebookController
+	NotebookImage() NotebookImageController
+	NotebookProfile() NotebookProfileController
+	ServeApplication() ServeApplicationController
 }
 
 func New(controllerFactory controller.SharedControllerFactory) Interface {
@@ -67,3 +70,15 @@ func (v *version) ModelTemplateVersion() ModelTemplateVersionController {
 func (v *version) Notebook() NotebookController {
 	return generic.NewController[*v1.Notebook, *v1.NotebookList](schema.GroupVersionKind{Group: "ml.oneblock.ai", Version: "v1", Kind: "Notebook"}, "notebooks", true, v.controllerFactory)
 }
+
+func (v *version) NotebookImage() NotebookImageController {
+	return generic.NewNonNamespacedController[*v1.NotebookImage, *v1.NotebookImageList](schema.GroupVersionKind{Group: "ml.oneblock.ai", Version: "v1", Kind: "NotebookImage"}, "notebookimages", v.controllerFactory)
+}
+
+func (v *version) NotebookProfile() NotebookProfileController {
+	return generic.NewNonNamespacedController[*v1.NotebookProfile, *v1.NotebookProfileList](schema.GroupVersionKind{Group: "ml.oneblock.ai", Version: "v1", Kind: "NotebookProfile"}, "notebookprofiles", v.controllerFactory)
+}
+
+func (v *version) ServeApplication() ServeApplicationController {
+	return generic.NewController[*v1.ServeApplication, *v1.ServeApplicationList](schema.GroupVersionKind{Group: "ml.oneblock.ai", Version: "v1", Kind: "ServeApplication"}, "serveapplications", true, v.controllerFactory)
+}
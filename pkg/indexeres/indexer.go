@@ -3,15 +3,24 @@ package indexeres
 import (
 	"context"
 
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	scheduling "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 
 	mgmtv1 "github.com/oneblock-ai/oneblock/pkg/apis/management.oneblock.ai/v1"
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
 	"github.com/oneblock-ai/oneblock/pkg/server/config"
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
 )
 
 const (
 	UserNameIndex               = "management.oneblock.ai/user-username-index"
 	ClusterRoleBindingNameIndex = "management.oneblock.ai/crb-by-role-and-subject-index"
+	SyncedSecretSourceIndex     = "oneblock.ai/secret-by-synced-source-index"
+	QueueNameIndex              = "scheduling.volcano.sh/by-queue-name-index"
+	PodGroupPodIndex            = "scheduling.volcano.sh/pod-by-pod-group-index"
 )
 
 func Register(_ context.Context, mgmt *config.Management) error {
@@ -19,9 +28,23 @@ func Register(_ context.Context, mgmt *config.Management) error {
 	userInformer.AddIndexer(UserNameIndex, indexUserByUsername)
 	crbInformer := mgmt.RbacFactory.Rbac().V1().ClusterRoleBinding().Cache()
 	crbInformer.AddIndexer(ClusterRoleBindingNameIndex, rbByRoleAndSubject)
+	secretInformer := mgmt.CoreFactory.Core().V1().Secret().Cache()
+	secretInformer.AddIndexer(SyncedSecretSourceIndex, secretBySyncedSource)
 	return nil
 }
 
+// RegisterQueueIndexers registers the indexers of the PodGroups and workloads of a queue, they are aggregated into the
+// queue usage by the API server. It's called by the API setup rather than the controllers which are only registered
+// on the leader.
+func RegisterQueueIndexers(mgmt *config.Management) {
+	mgmt.SchedulingFactory.Scheduling().V1beta1().PodGroup().Cache().AddIndexer(QueueNameIndex, podGroupByQueue)
+	mgmt.KubeRayFactory.Ray().V1().RayCluster().Cache().AddIndexer(QueueNameIndex, rayClusterByQueue)
+	mgmt.KubeRayFactory.Ray().V1().RayJob().Cache().AddIndexer(QueueNameIndex, rayJobByQueue)
+	mgmt.KubeRayFactory.Ray().V1().RayService().Cache().AddIndexer(QueueNameIndex, rayServiceByQueue)
+	mgmt.OneBlockMLFactory.Ml().V1().Notebook().Cache().AddIndexer(QueueNameIndex, notebookByQueue)
+	mgmt.CoreFactory.Core().V1().Pod().Cache().AddIndexer(PodGroupPodIndex, podByPodGroup)
+}
+
 func indexUserByUsername(obj *mgmtv1.User) ([]string, error) {
 	return []string{obj.Username}, nil
 }
@@ -34,6 +57,49 @@ func rbByRoleAndSubject(obj *rbacv1.ClusterRoleBinding) ([]string, error) {
 	return keys, nil
 }
 
+func secretBySyncedSource(obj *corev1.Secret) ([]string, error) {
+	source := utils.GetSyncedSecretSource(obj)
+	if source == "" {
+		return nil, nil
+	}
+	return []string{source}, nil
+}
+
+func podGroupByQueue(obj *scheduling.PodGroup) ([]string, error) {
+	return []string{obj.Spec.Queue}, nil
+}
+
+func rayClusterByQueue(obj *rayv1.RayCluster) ([]string, error) {
+	return getQueueLabel(obj.Labels), nil
+}
+
+func rayJobByQueue(obj *rayv1.RayJob) ([]string, error) {
+	return getQueueLabel(obj.Labels), nil
+}
+
+func rayServiceByQueue(obj *rayv1.RayService) ([]string, error) {
+	return getQueueLabel(obj.Labels), nil
+}
+
+func notebookByQueue(obj *mlv1.Notebook) ([]string, error) {
+	return getQueueLabel(obj.Labels), nil
+}
+
+func getQueueLabel(labels map[string]string) []string {
+	if queueName := labels[constant.LabelVolcanoQueueName]; queueName != "" {
+		return []string{queueName}
+	}
+	return nil
+}
+
+// podByPodGroup indexes the pods by the namespaced name of their PodGroups
+func podByPodGroup(obj *corev1.Pod) ([]string, error) {
+	if groupName := obj.Annotations[scheduling.KubeGroupNameAnnotationKey]; groupName != "" {
+		return []string{utils.NewRef(obj.Namespace, groupName)}, nil
+	}
+	return nil, nil
+}
+
 func GetCrbKey(roleName string, subject rbacv1.Subject) string {
 	return roleName + "." + subject.Kind + "." + subject.Name
 }
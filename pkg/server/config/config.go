@@ -11,9 +11,12 @@ import (
 	rbacv1 "github.com/rancher/wrangler/v2/pkg/generated/controllers/rbac"
 	"github.com/rancher/wrangler/v2/pkg/generic"
 	"github.com/rancher/wrangler/v2/pkg/start"
+	k8scorev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 
 	"github.com/oneblock-ai/oneblock/pkg/auth"
 	obmgmtv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/management.oneblock.ai"
@@ -139,6 +142,13 @@ func SetupManagement(ctx context.Context, restConfig *rest.Config, namespace, re
 	return mgmt, nil
 }
 
+// NewRecorder returns an event recorder of the component, the recorded events are sent to the api-server
+func (m *Management) NewRecorder(component string) record.EventRecorder {
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: m.ClientSet.CoreV1().Events("")})
+	return broadcaster.NewRecorder(m.Scheme, k8scorev1.EventSource{Component: component})
+}
+
 func (m *Management) Start(threadiness int) error {
 	return start.All(m.ctx, threadiness, m.starters...)
 }
@@ -8,7 +8,9 @@ import (
 	"github.com/oneblock-ai/steve/v2/pkg/ui"
 
 	"github.com/oneblock-ai/oneblock/pkg/api/auth"
+	"github.com/oneblock-ai/oneblock/pkg/api/notebook"
 	"github.com/oneblock-ai/oneblock/pkg/api/publicui"
+	"github.com/oneblock-ai/oneblock/pkg/api/raycluster"
 	"github.com/oneblock-ai/oneblock/pkg/server/config"
 	"github.com/oneblock-ai/oneblock/pkg/settings"
 )
@@ -52,9 +54,24 @@ func (r *Router) Routes() http.Handler {
 	authHandler := auth.NewAuthHandler(r.mgmt)
 	m.Path("/v1-public/auth").Handler(authHandler)
 
-	publicHandler := publicui.NewPublicHandler()
+	publicHandler := publicui.NewPublicHandler(r.mgmt)
 	m.Path("/v1-public/ui").Handler(publicHandler)
 
+	// proxy the notebooks through the API server, the requests are authenticated by the session token
+	authMiddleware := auth.NewMiddleware(r.mgmt)
+	notebookProxy := authMiddleware.AuthMiddleware(notebook.NewProxyHandler(r.mgmt))
+	m.Path("/notebooks/{namespace}/{name}").HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		http.Redirect(rw, req, req.URL.Path+"/", http.StatusFound)
+	})
+	m.PathPrefix("/notebooks/{namespace}/{name}/").Handler(notebookProxy)
+
+	// proxy the Ray dashboards, the relative paths of the dashboard UI require the trailing slash
+	rayDashboardProxy := authMiddleware.AuthMiddleware(raycluster.NewProxyHandler(r.mgmt))
+	m.Path("/ray/{namespace}/{name}").HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		http.Redirect(rw, req, req.URL.Path+"/", http.StatusFound)
+	})
+	m.PathPrefix("/ray/{namespace}/{name}/").Handler(rayDashboardProxy)
+
 	return m
 }
 
@@ -0,0 +1,30 @@
+package settings
+
+// defaultAcceleratorTypes is the built-in accelerator type registry, the types are matched by the words of the
+// product name patterns, the longest pattern wins and the earlier type wins the tie. The memory is per device.
+const defaultAcceleratorTypes = `[
+  {"type": "V100", "resourceName": "nvidia.com/gpu", "rayResourceName": "GPU", "patterns": ["V100"], "memory": "16Gi"},
+  {"type": "P100", "resourceName": "nvidia.com/gpu", "rayResourceName": "GPU", "patterns": ["P100"], "memory": "16Gi"},
+  {"type": "T4", "resourceName": "nvidia.com/gpu", "rayResourceName": "GPU", "patterns": ["T4"], "memory": "16Gi"},
+  {"type": "P4", "resourceName": "nvidia.com/gpu", "rayResourceName": "GPU", "patterns": ["P4"], "memory": "8Gi"},
+  {"type": "K80", "resourceName": "nvidia.com/gpu", "rayResourceName": "GPU", "patterns": ["K80"], "memory": "12Gi"},
+  {"type": "A10G", "resourceName": "nvidia.com/gpu", "rayResourceName": "GPU", "patterns": ["A10G"], "memory": "24Gi"},
+  {"type": "L4", "resourceName": "nvidia.com/gpu", "rayResourceName": "GPU", "patterns": ["L4"], "memory": "24Gi"},
+  {"type": "A100", "resourceName": "nvidia.com/gpu", "rayResourceName": "GPU", "patterns": ["A100"], "memory": "40Gi"},
+  {"type": "A100-40G", "resourceName": "nvidia.com/gpu", "rayResourceName": "GPU", "patterns": ["A100-40G", "A100-40GB", "A100-SXM4-40GB", "A100-PCIE-40GB"], "memory": "40Gi"},
+  {"type": "A100-80G", "resourceName": "nvidia.com/gpu", "rayResourceName": "GPU", "patterns": ["A100-80G", "A100-80GB", "A100-SXM4-80GB", "A100-PCIE-80GB"], "memory": "80Gi"},
+  {"type": "Intel-GPU-Max-1550", "resourceName": "gpu.intel.com/i915", "rayResourceName": "GPU", "patterns": ["GPU-Max-1550"], "memory": "128Gi"},
+  {"type": "Intel-GPU-Max-1100", "resourceName": "gpu.intel.com/i915", "rayResourceName": "GPU", "patterns": ["GPU-Max-1100"], "memory": "48Gi"},
+  {"type": "Intel-GAUDI", "resourceName": "habana.ai/gaudi", "rayResourceName": "HPU", "patterns": ["Gaudi"], "memory": "96Gi"},
+  {"type": "AMD-Instinct-MI100", "resourceName": "amd.com/gpu", "rayResourceName": "GPU", "patterns": ["MI100"], "memory": "32Gi"},
+  {"type": "AMD-Instinct-MI250X", "resourceName": "amd.com/gpu", "rayResourceName": "GPU", "patterns": ["MI250X"], "memory": "128Gi"},
+  {"type": "AMD-Instinct-MI250X-MI250", "resourceName": "amd.com/gpu", "rayResourceName": "GPU", "patterns": ["MI250"], "memory": "128Gi"},
+  {"type": "AMD-Instinct-MI210", "resourceName": "amd.com/gpu", "rayResourceName": "GPU", "patterns": ["MI210"], "memory": "64Gi"},
+  {"type": "AMD-Instinct-MI300X-OAM", "resourceName": "amd.com/gpu", "rayResourceName": "GPU", "patterns": ["MI300X"], "memory": "192Gi"},
+  {"type": "AMD-Radeon-R9-200-HD-7900", "resourceName": "amd.com/gpu", "rayResourceName": "GPU", "patterns": ["R9-200"], "memory": "3Gi"},
+  {"type": "AMD-Radeon-HD-7900", "resourceName": "amd.com/gpu", "rayResourceName": "GPU", "patterns": ["HD-7900"], "memory": "3Gi"},
+  {"type": "aws-neuron-core", "resourceName": "aws.amazon.com/neuroncore", "rayResourceName": "neuron_cores", "patterns": ["neuron-core", "Inferentia", "Trainium"], "memory": "16Gi"},
+  {"type": "TPU-V2", "resourceName": "google.com/tpu", "rayResourceName": "TPU", "patterns": ["TPU-V2"], "memory": "8Gi"},
+  {"type": "TPU-V3", "resourceName": "google.com/tpu", "rayResourceName": "TPU", "patterns": ["TPU-V3"], "memory": "16Gi"},
+  {"type": "TPU-V4", "resourceName": "google.com/tpu", "rayResourceName": "TPU", "patterns": ["TPU-V4"], "memory": "32Gi"}
+]`
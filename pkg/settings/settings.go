@@ -28,16 +28,28 @@ var (
 	UISource               = NewSetting(UISourceSettingName, "auto") // Options are 'auto', 'external' or 'bundled'
 	RayClusterImage        = NewSetting(DefaultRayClusterImage, "anyscale/ray:2.9.3")
 	RayLLMImage            = NewSetting(DefaultRayLLMImage, "anyscale/ray-llm:0.5.0")
-	NotebookDefaultImages  = NewSetting(DefaultNotebookImagesSettingName, setDefaultNotebookImages())
+	VLLMImage              = NewSetting(DefaultVLLMImage, "vllm/vllm-openai:v0.4.0")
+	RestrictNotebookImages = NewSetting(RestrictNotebookImagesSettingName, "false") // restrict the notebooks to the approved NotebookImages
+	NotebookCullIdleTime   = NewSetting(NotebookCullIdleTimeSettingName, "0")       // in minutes, 0 disables the idle notebook culling
+	NotebookGitImage       = NewSetting(NotebookGitImageSettingName, "alpine/git:2.43.0")
+	RayClusterIdleTime     = NewSetting(RayClusterIdleTimeSettingName, "0") // in minutes, 0 disables the idle RayCluster suspension
+	AcceleratorTypes       = NewSetting(AcceleratorTypesSettingName, defaultAcceleratorTypes)
+	VLLMQueueDepthMetric   = NewSetting(VLLMQueueDepthMetricSettingName, "vllm_num_requests_waiting") // the custom pod metric of the vllm:num_requests_waiting gauge
 )
 
 const (
-	UIPlSettingName                  = "ui-pl"
-	UISourceSettingName              = "ui-source"
-	DefaultNotebookImagesSettingName = "default-notebook-images"
-	DefaultRayClusterImage           = "default-ray-cluster-image"
-	DefaultRayLLMImage               = "default-ray-llm-image"
-	FirstLoginSettingName            = "first-login"
+	UIPlSettingName                   = "ui-pl"
+	UISourceSettingName               = "ui-source"
+	DefaultRayClusterImage            = "default-ray-cluster-image"
+	DefaultRayLLMImage                = "default-ray-llm-image"
+	DefaultVLLMImage                  = "default-vllm-image"
+	FirstLoginSettingName             = "first-login"
+	NotebookCullIdleTimeSettingName   = "notebook-cull-idle-time"
+	NotebookGitImageSettingName       = "notebook-git-image"
+	RestrictNotebookImagesSettingName = "restrict-notebook-images"
+	RayClusterIdleTimeSettingName     = "ray-cluster-idle-suspend-time"
+	AcceleratorTypesSettingName       = "accelerator-types"
+	VLLMQueueDepthMetricSettingName   = "vllm-queue-depth-metric"
 )
 
 func init() {
@@ -1,7 +1,23 @@
 package utils
 
-import "strings"
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
 
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	mgmtv1 "github.com/oneblock-ai/oneblock/pkg/apis/management.oneblock.ai/v1"
+	"github.com/oneblock-ai/oneblock/pkg/settings"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+)
+
+// the built-in accelerator types of the default registry
 const (
 	NvidiaTeslaV100      = "V100"
 	NvidiaTeslaP100      = "P100"
@@ -29,57 +45,233 @@ const (
 	NvidiaA10080g        = "A100-80G"
 )
 
-// GetAcceleratorTypeByProductName returns the accelerator type by label if the value contains the accelerator type.
-func GetAcceleratorTypeByProductName(gpuProductName string) string {
-	if strings.Contains(gpuProductName, NvidiaTeslaV100) {
-		return NvidiaTeslaV100
-	} else if strings.Contains(gpuProductName, NvidiaTeslaP100) {
-		return NvidiaTeslaP100
-	} else if strings.Contains(gpuProductName, NvidiaTeslaT4) {
-		return NvidiaTeslaT4
-	} else if strings.Contains(gpuProductName, NvidiaTeslaP4) {
-		return NvidiaTeslaP4
-	} else if strings.Contains(gpuProductName, NvidiaTeslaK80) {
-		return NvidiaTeslaK80
-	} else if strings.Contains(gpuProductName, NvidiaTeslaA10g) {
-		return NvidiaTeslaA10g
-	} else if strings.Contains(gpuProductName, NvidiaL4) {
-		return NvidiaL4
-	} else if strings.Contains(gpuProductName, NvidiaA100) {
-		return NvidiaA100
-	} else if strings.Contains(gpuProductName, IntelMax1550) {
-		return IntelMax1550
-	} else if strings.Contains(gpuProductName, IntelMax1100) {
-		return IntelMax1100
-	} else if strings.Contains(gpuProductName, IntelGaudi) {
-		return IntelGaudi
-	} else if strings.Contains(gpuProductName, AmdInstinctMi100) {
-		return AmdInstinctMi100
-	} else if strings.Contains(gpuProductName, AmdInstinctMi250x) {
-		return AmdInstinctMi250x
-	} else if strings.Contains(gpuProductName, AmdInstinctMi250) {
-		return AmdInstinctMi250
-	} else if strings.Contains(gpuProductName, AmdInstinctMi210) {
-		return AmdInstinctMi210
-	} else if strings.Contains(gpuProductName, AmdInstinctMi300x) {
-		return AmdInstinctMi300x
-	} else if strings.Contains(gpuProductName, AmdRadeonR9200Hd7900) {
-		return AmdRadeonR9200Hd7900
-	} else if strings.Contains(gpuProductName, AmdRadeonHd7900) {
-		return AmdRadeonHd7900
-	} else if strings.Contains(gpuProductName, AwsNeuronCore) {
-		return AwsNeuronCore
-	} else if strings.Contains(gpuProductName, GoogleTpuV2) {
-		return GoogleTpuV2
-	} else if strings.Contains(gpuProductName, GoogleTpuV3) {
-		return GoogleTpuV3
-	} else if strings.Contains(gpuProductName, GoogleTpuV4) {
-		return GoogleTpuV4
-	} else if strings.Contains(gpuProductName, NvidiaA10040g) {
-		return NvidiaA10040g
-	} else if strings.Contains(gpuProductName, NvidiaA10080g) {
-		return NvidiaA10080g
+const (
+	ResourceNvidiaGPU  corev1.ResourceName = "nvidia.com/gpu"
+	ResourceIntelGPU   corev1.ResourceName = "gpu.intel.com/i915"
+	ResourceIntelGaudi corev1.ResourceName = "habana.ai/gaudi"
+	ResourceAmdGPU     corev1.ResourceName = "amd.com/gpu"
+	ResourceAwsNeuron  corev1.ResourceName = "aws.amazon.com/neuroncore"
+	ResourceGoogleTpu  corev1.ResourceName = "google.com/tpu"
+)
+
+// AcceleratorType is an entry of the accelerator type registry loaded from the accelerator-types setting
+type AcceleratorType struct {
+	// Type is the accelerator type used by the node label and the Ray accelerator_type resource, e.g., A100-80G
+	Type string `json:"type"`
+	// ResourceName is the extended resource name of the device plugin, e.g., nvidia.com/gpu
+	ResourceName corev1.ResourceName `json:"resourceName"`
+	// RayResourceName is the resource name of the accelerator in Ray, e.g., GPU
+	RayResourceName string `json:"rayResourceName,omitempty"`
+	// Patterns are matched with the product names, a pattern matches if its words appear consecutively in the
+	// product name regardless of the case and separators
+	Patterns []string `json:"patterns"`
+	// Memory is the memory size of a device, e.g., 80Gi
+	Memory string `json:"memory,omitempty"`
+}
+
+var (
+	registryLock sync.RWMutex
+	registry     = mustParseAcceleratorTypes(settings.AcceleratorTypes.Default)
+)
+
+func mustParseAcceleratorTypes(value string) []AcceleratorType {
+	types, err := ParseAcceleratorTypes(value)
+	if err != nil {
+		panic(fmt.Sprintf("invalid default accelerator types: %v", err))
+	}
+	return types
+}
+
+// ParseAcceleratorTypes parses and validates the accelerator type registry of the setting
+func ParseAcceleratorTypes(value string) ([]AcceleratorType, error) {
+	types := make([]AcceleratorType, 0)
+	if err := json.Unmarshal([]byte(value), &types); err != nil {
+		return nil, fmt.Errorf("failed to parse the accelerator types: %w", err)
+	}
+
+	seen := make(map[string]bool, len(types))
+	for i, t := range types {
+		switch {
+		case t.Type == "":
+			return nil, fmt.Errorf("type of accelerator types[%d] is required", i)
+		case seen[t.Type]:
+			return nil, fmt.Errorf("accelerator type %s is duplicated", t.Type)
+		case t.ResourceName == "":
+			return nil, fmt.Errorf("resourceName of accelerator type %s is required", t.Type)
+		case len(t.Patterns) == 0:
+			return nil, fmt.Errorf("patterns of accelerator type %s are required", t.Type)
+		}
+		for _, pattern := range t.Patterns {
+			if len(splitWords(pattern)) == 0 {
+				return nil, fmt.Errorf("pattern %q of accelerator type %s has no words", pattern, t.Type)
+			}
+		}
+		if t.Memory != "" {
+			if _, err := resource.ParseQuantity(t.Memory); err != nil {
+				return nil, fmt.Errorf("invalid memory %s of accelerator type %s: %w", t.Memory, t.Type, err)
+			}
+		}
+		seen[t.Type] = true
+	}
+	return types, nil
+}
+
+// LoadAcceleratorTypes replaces the accelerator type registry with the value of the setting, the default registry
+// is loaded if the value is empty
+func LoadAcceleratorTypes(value string) error {
+	if value == "" {
+		value = settings.AcceleratorTypes.Default
+	}
+	types, err := ParseAcceleratorTypes(value)
+	if err != nil {
+		return err
+	}
+
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	registry = types
+	logrus.Debugf("Loaded %d accelerator types", len(types))
+	return nil
+}
+
+// LoadAcceleratorTypesSetting is the Setting OnChange handler reloading the accelerator type registry once the
+// setting changes, the invalid value is rejected by the webhook, the registry is kept if the value fails to be
+// loaded anyway
+func LoadAcceleratorTypesSetting(_ string, setting *mgmtv1.Setting) (*mgmtv1.Setting, error) {
+	if setting == nil || setting.DeletionTimestamp != nil || setting.Name != settings.AcceleratorTypesSettingName {
+		return setting, nil
+	}
+
+	value := setting.Value
+	if value == "" {
+		value = setting.Default
+	}
+	if err := LoadAcceleratorTypes(value); err != nil {
+		logrus.Errorf("Failed to load the accelerator types of setting %s: %v", setting.Name, err)
+	}
+	return setting, nil
+}
+
+// GetAcceleratorRegistry returns a copy of the accelerator type registry in order
+func GetAcceleratorRegistry() []AcceleratorType {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	types := make([]AcceleratorType, len(registry))
+	copy(types, registry)
+	return types
+}
+
+func getAcceleratorType(acceleratorType string) *AcceleratorType {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	for i := range registry {
+		if registry[i].Type == acceleratorType {
+			t := registry[i]
+			return &t
+		}
 	}
+	return nil
+}
 
+// GetAcceleratorResourceName returns the extended resource name of the accelerator type,
+// it returns an empty name if the accelerator type is unknown.
+func GetAcceleratorResourceName(acceleratorType string) corev1.ResourceName {
+	if t := getAcceleratorType(acceleratorType); t != nil {
+		return t.ResourceName
+	}
 	return ""
 }
+
+// GetAcceleratorTypes returns the sorted accelerator types whose resource names are known
+func GetAcceleratorTypes() []string {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	types := make([]string, 0, len(registry))
+	for _, t := range registry {
+		types = append(types, t.Type)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// IsAcceleratorResource checks whether the resource is an extended resource of the accelerator device plugins
+func IsAcceleratorResource(name corev1.ResourceName) bool {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	for _, t := range registry {
+		if t.ResourceName == name {
+			return true
+		}
+	}
+	return false
+}
+
+// GetAcceleratorNodeSelector returns the node selector to schedule the pods to the nodes of the accelerator type,
+// the nodes are labeled with the accelerator type.
+func GetAcceleratorNodeSelector(acceleratorType string) map[string]string {
+	return map[string]string{
+		constant.LabelAcceleratorType: acceleratorType,
+	}
+}
+
+// MergeNodeSelector merges the selector into the node selector, the node selector is created if it's nil
+func MergeNodeSelector(nodeSelector, selector map[string]string) map[string]string {
+	if len(selector) == 0 {
+		return nodeSelector
+	}
+	if nodeSelector == nil {
+		nodeSelector = make(map[string]string, len(selector))
+	}
+	for k, v := range selector {
+		nodeSelector[k] = v
+	}
+	return nodeSelector
+}
+
+// GetAcceleratorTypeByProductName returns the accelerator type whose pattern matches the product name, the pattern
+// with the most words wins, e.g., NVIDIA-A100-SXM4-80GB is A100-80G rather than A100, and the earlier type of the
+// registry wins the tie. It returns an empty type if none of the patterns matches.
+func GetAcceleratorTypeByProductName(gpuProductName string) string {
+	words := splitWords(gpuProductName)
+
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	var matched string
+	var matchedWords, matchedLength int
+	for _, t := range registry {
+		for _, pattern := range t.Patterns {
+			patternWords := splitWords(pattern)
+			length := len(strings.Join(patternWords, ""))
+			if len(patternWords) < matchedWords || (len(patternWords) == matchedWords && length <= matchedLength) {
+				continue
+			}
+			if containsWords(words, patternWords) {
+				matched, matchedWords, matchedLength = t.Type, len(patternWords), length
+			}
+		}
+	}
+	return matched
+}
+
+func splitWords(s string) []string {
+	return strings.FieldsFunc(strings.ToUpper(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// containsWords checks whether the sub words appear consecutively in the words
+func containsWords(words, sub []string) bool {
+	for i := 0; i+len(sub) <= len(words); i++ {
+		matched := true
+		for j := range sub {
+			if words[i+j] != sub[j] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
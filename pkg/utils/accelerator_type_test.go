@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mgmtv1 "github.com/oneblock-ai/oneblock/pkg/apis/management.oneblock.ai/v1"
+	"github.com/oneblock-ai/oneblock/pkg/settings"
+)
+
+func TestParseAcceleratorTypes(t *testing.T) {
+	types, err := ParseAcceleratorTypes(settings.AcceleratorTypes.Default)
+	require.NoError(t, err)
+	assert.NotEmpty(t, types)
+
+	types, err = ParseAcceleratorTypes(`[{"type": "H100", "resourceName": "nvidia.com/gpu", "rayResourceName": "GPU",
+		"patterns": ["H100", "H100-80GB-HBM3"], "memory": "80Gi"}]`)
+	require.NoError(t, err)
+	if assert.Len(t, types, 1) {
+		assert.Equal(t, "H100", types[0].Type)
+		assert.Equal(t, ResourceNvidiaGPU, types[0].ResourceName)
+		assert.Equal(t, []string{"H100", "H100-80GB-HBM3"}, types[0].Patterns)
+	}
+
+	var testCases = []struct {
+		name  string
+		value string
+	}{
+		{name: "invalid json", value: `{"type": "H100"}`},
+		{name: "missing type", value: `[{"resourceName": "nvidia.com/gpu", "patterns": ["H100"]}]`},
+		{name: "duplicated type", value: `[{"type": "H100", "resourceName": "nvidia.com/gpu", "patterns": ["H100"]},
+			{"type": "H100", "resourceName": "nvidia.com/gpu", "patterns": ["H100-80GB"]}]`},
+		{name: "missing resource name", value: `[{"type": "H100", "patterns": ["H100"]}]`},
+		{name: "missing patterns", value: `[{"type": "H100", "resourceName": "nvidia.com/gpu"}]`},
+		{name: "pattern without words", value: `[{"type": "H100", "resourceName": "nvidia.com/gpu", "patterns": ["--"]}]`},
+		{name: "invalid memory", value: `[{"type": "H100", "resourceName": "nvidia.com/gpu", "patterns": ["H100"],
+			"memory": "80GB"}]`},
+	}
+	for _, tc := range testCases {
+		_, err := ParseAcceleratorTypes(tc.value)
+		assert.Error(t, err, tc.name)
+	}
+}
+
+func TestGetAcceleratorTypeByProductName(t *testing.T) {
+	var testCases = []struct {
+		productName     string
+		acceleratorType string
+	}{
+		{productName: "Tesla-T4", acceleratorType: NvidiaTeslaT4},
+		{productName: "NVIDIA-A10G", acceleratorType: NvidiaTeslaA10g},
+		{productName: "NVIDIA-A100-PCIE", acceleratorType: NvidiaA100},
+		// the pattern with the most words wins
+		{productName: "NVIDIA-A100-SXM4-80GB", acceleratorType: NvidiaA10080g},
+		{productName: "NVIDIA A100 40GB PCIe", acceleratorType: NvidiaA10040g},
+		{productName: "nvidia-a100-sxm4-80gb", acceleratorType: NvidiaA10080g},
+		{productName: "NVIDIA-GeForce-RTX-4090", acceleratorType: ""},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.acceleratorType, GetAcceleratorTypeByProductName(tc.productName), tc.productName)
+	}
+}
+
+func TestGetAcceleratorTypeByProductName_TieBreak(t *testing.T) {
+	t.Cleanup(func() {
+		require.NoError(t, LoadAcceleratorTypes(""))
+	})
+	require.NoError(t, LoadAcceleratorTypes(`[
+		{"type": "first", "resourceName": "example.com/gpu", "patterns": ["GPU-X"]},
+		{"type": "second", "resourceName": "example.com/gpu", "patterns": ["GPU-X"]},
+		{"type": "shorter", "resourceName": "example.com/gpu", "patterns": ["GPU-Y"]},
+		{"type": "longer", "resourceName": "example.com/gpu", "patterns": ["GPU-YY"]}
+	]`))
+
+	// the earlier type of the registry wins if the patterns have the same words
+	assert.Equal(t, "first", GetAcceleratorTypeByProductName("Example-GPU-X"))
+	// the longer pattern wins if the patterns have the same number of words
+	assert.Equal(t, "longer", GetAcceleratorTypeByProductName("Example-GPU-Y-GPU-YY"))
+	assert.Equal(t, "shorter", GetAcceleratorTypeByProductName("Example-GPU-Y"))
+}
+
+func TestLoadAcceleratorTypesSetting(t *testing.T) {
+	t.Cleanup(func() {
+		require.NoError(t, LoadAcceleratorTypes(""))
+	})
+	setting := &mgmtv1.Setting{
+		ObjectMeta: metav1.ObjectMeta{Name: settings.AcceleratorTypesSettingName},
+		Default:    settings.AcceleratorTypes.Default,
+		Value:      `[{"type": "H100", "resourceName": "nvidia.com/gpu", "patterns": ["H100"]}]`,
+	}
+	_, err := LoadAcceleratorTypesSetting(setting.Name, setting)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"H100"}, GetAcceleratorTypes())
+
+	// the registry is kept if the value is invalid
+	setting.Value = `[{"type": "H200"}]`
+	_, err = LoadAcceleratorTypesSetting(setting.Name, setting)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"H100"}, GetAcceleratorTypes())
+
+	// the default registry is loaded once the value is reset
+	setting.Value = ""
+	_, err = LoadAcceleratorTypesSetting(setting.Name, setting)
+	require.NoError(t, err)
+	assert.Equal(t, ResourceNvidiaGPU, GetAcceleratorResourceName(NvidiaA10080g))
+}
@@ -12,22 +12,48 @@ const (
 	AnnotationVolumeClaimTemplates     = Prefix + "volumeClaimTemplates"
 	AnnotationClusterPolicyProviderKey = Prefix + "k8sProvider"
 	AnnoModelTemplateVersionName       = Prefix + "modelTemplateVersionName"
+	LabelModelTemplateVersionName      = Prefix + "modelTemplateVersionName"
+	LabelSyncedSecret                  = Prefix + "syncedSecret"
+	AnnotationSyncedSecretSource       = Prefix + "syncedSecretSource"
+
+	// notebook constant
+	LabelNotebookType              = MLPrefix + "notebook-type"
+	AnnotationNotebookCullIdleTime = MLPrefix + "notebook-cull-idle-time"
+	LabelNotebookRayClient         = MLPrefix + "notebook-ray-client"
+	LabelNotebookName              = MLPrefix + "notebook"
+	LabelNotebookSnapshot          = MLPrefix + "notebook-snapshot"
+
+	// accelerator constant
+	LabelAcceleratorType                = MLPrefix + "accelerator-type"
+	AnnotationDiscoveredAcceleratorType = MLPrefix + "discovered-accelerator-type"
+	LabelNvidiaGPUProduct               = "nvidia.com/gpu.product"
+	LabelIntelGPUPrefix                 = "gpu.intel.com/"
+	LabelAmdGPUProductName              = "amd.com/gpu.product-name"
 
 	// kubeRay constant
 	LabelRaySchedulerName           = "ray.io/scheduler-name"
 	AnnotationRayClusterEnableGCS   = MLPrefix + "rayClusterEnableGCS"
 	AnnotationRayClusterInitialized = MLPrefix + "rayClusterInitialized"
+	AnnotationRayClusterIdleTime    = MLPrefix + "rayClusterIdleSuspendTime"
+	AnnotationRayClusterSuspendedAt = MLPrefix + "rayClusterSuspendedSince"
+	AnnotationRayClusterActiveAt    = MLPrefix + "rayClusterLastActivity"
 	AnnotationRayFTEnabledKey       = "ray.io/ft-enabled"
 	RayRedisCleanUpFinalizer        = "ray.io/gcs-ft-redis-cleanup-finalizer"
 	RayServiceKind                  = "RayService"
+	RayJobKind                      = "RayJob"
+	LabelRayJobName                 = MLPrefix + "rayJob"
 
 	// Volcano constant
-	VolcanoSchedulerName  = "volcano"
-	LabelVolcanoQueueName = "volcano.sh/queue-name"
-	DefaultQueueName      = "oneblock-default"
+	VolcanoSchedulerName       = "volcano"
+	LabelVolcanoQueueName      = "volcano.sh/queue-name"
+	AnnotationVolcanoQueueName = "scheduling.volcano.sh/queue-name"
+	DefaultQueueName           = "oneblock-default"
+	AllNamespaces              = "all"
 
 	// model constant
-	LabelModelTemplateName = MLPrefix + "modelTemplate"
+	LabelModelTemplateName         = MLPrefix + "modelTemplate"
+	LabelMLServiceName             = MLPrefix + "mlService"
+	AnnotationModelTemplateVersion = MLPrefix + "modelTemplateVersion"
 
 	AnnotationDefaultSchedulingKey             = "scheduling.oneblock.ai/isDefaultQueue"
 	AnnotationSchedulingSupportedNamespacesKey = "scheduling.oneblock.ai/supportedNamespaces"
@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// cronParser parses the standard five fields "minute hour day-of-month month day-of-week" and the macros like @daily
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// CronSchedule is a parsed cron expression in a timezone
+type CronSchedule struct {
+	schedule *cron.SpecSchedule
+}
+
+// ParseCronSchedule parses the cron expression in the IANA timezone, the timezone defaults to UTC. Each field
+// supports the wildcard, lists, ranges and steps, e.g., "*/15 8-18 * * mon-fri", and the macros like @daily.
+func ParseCronSchedule(expression, timezone string) (*CronSchedule, error) {
+	location := time.UTC
+	if timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(timezone); err != nil {
+			return nil, fmt.Errorf("invalid timezone %s: %v", timezone, err)
+		}
+	}
+
+	schedule, err := cronParser.Parse(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %v", expression, err)
+	}
+	// the @every macro runs at a fixed interval from the time it's scheduled, it can't be evaluated statelessly
+	spec, ok := schedule.(*cron.SpecSchedule)
+	if !ok {
+		return nil, fmt.Errorf("invalid cron expression %q, the interval schedule is not supported", expression)
+	}
+	// the timezone of the schedule wins over the CRON_TZ prefix of the expression
+	spec.Location = location
+	return &CronSchedule{schedule: spec}, nil
+}
+
+// Next returns the first scheduled time after the given time, it returns the zero time if there is none
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	return s.schedule.Next(t)
+}
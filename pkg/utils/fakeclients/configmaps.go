@@ -0,0 +1,82 @@
+package fakeclients
+
+import (
+	"context"
+
+	"github.com/rancher/wrangler/v2/pkg/generic"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	typecorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+)
+
+type ConfigMapClient func(string) typecorev1.ConfigMapInterface
+
+func (n ConfigMapClient) Create(configMap *v1.ConfigMap) (*v1.ConfigMap, error) {
+	return n(configMap.Namespace).Create(context.TODO(), configMap, metav1.CreateOptions{})
+}
+
+func (n ConfigMapClient) Update(configMap *v1.ConfigMap) (*v1.ConfigMap, error) {
+	return n(configMap.Namespace).Update(context.TODO(), configMap, metav1.UpdateOptions{})
+}
+
+func (n ConfigMapClient) UpdateStatus(_ *v1.ConfigMap) (*v1.ConfigMap, error) {
+	panic("implement me")
+}
+
+func (n ConfigMapClient) Delete(namespace, name string, options *metav1.DeleteOptions) error {
+	return n(namespace).Delete(context.TODO(), name, *options)
+}
+
+func (n ConfigMapClient) Get(namespace, name string, options metav1.GetOptions) (*v1.ConfigMap, error) {
+	return n(namespace).Get(context.TODO(), name, options)
+}
+
+func (n ConfigMapClient) List(namespace string, opts metav1.ListOptions) (*v1.ConfigMapList, error) {
+	return n(namespace).List(context.TODO(), opts)
+}
+
+func (n ConfigMapClient) Watch(namespace string, opts metav1.ListOptions) (watch.Interface, error) {
+	return n(namespace).Watch(context.TODO(), opts)
+}
+
+func (n ConfigMapClient) Patch(namespace, name string, pt types.PatchType, data []byte, subresources ...string) (*v1.ConfigMap, error) {
+	return n(namespace).Patch(context.TODO(), name, pt, data, metav1.PatchOptions{}, subresources...)
+}
+
+func (n ConfigMapClient) WithImpersonation(_ rest.ImpersonationConfig) (generic.ClientInterface[*v1.ConfigMap, *v1.ConfigMapList], error) {
+	panic("implement me")
+}
+
+type ConfigMapCache func(string) typecorev1.ConfigMapInterface
+
+func (p ConfigMapCache) Get(namespace string, name string) (*v1.ConfigMap, error) {
+	return p(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+func (p ConfigMapCache) List(namespace string, selector labels.Selector) ([]*v1.ConfigMap, error) {
+	items, err := p(namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*v1.ConfigMap, 0, len(items.Items))
+	for _, item := range items.Items {
+		obj := item
+		result = append(result, &obj)
+	}
+	return result, nil
+}
+
+func (p ConfigMapCache) AddIndexer(_ string, _ generic.Indexer[*v1.ConfigMap]) { // #nosec G101
+	//TODO implement me
+	panic("implement me")
+}
+
+func (p ConfigMapCache) GetByIndex(_ string, _ string) ([]*v1.ConfigMap, error) {
+	//TODO implement me
+	panic("implement me")
+}
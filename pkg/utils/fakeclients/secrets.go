@@ -0,0 +1,82 @@
+package fakeclients
+
+import (
+	"context"
+
+	"github.com/rancher/wrangler/v2/pkg/generic"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	typecorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+)
+
+type SecretClient func(string) typecorev1.SecretInterface
+
+func (n SecretClient) Create(secret *v1.Secret) (*v1.Secret, error) {
+	return n(secret.Namespace).Create(context.TODO(), secret, metav1.CreateOptions{})
+}
+
+func (n SecretClient) Update(secret *v1.Secret) (*v1.Secret, error) {
+	return n(secret.Namespace).Update(context.TODO(), secret, metav1.UpdateOptions{})
+}
+
+func (n SecretClient) UpdateStatus(_ *v1.Secret) (*v1.Secret, error) {
+	panic("implement me")
+}
+
+func (n SecretClient) Delete(namespace, name string, options *metav1.DeleteOptions) error {
+	return n(namespace).Delete(context.TODO(), name, *options)
+}
+
+func (n SecretClient) Get(namespace, name string, options metav1.GetOptions) (*v1.Secret, error) {
+	return n(namespace).Get(context.TODO(), name, options)
+}
+
+func (n SecretClient) List(namespace string, opts metav1.ListOptions) (*v1.SecretList, error) {
+	return n(namespace).List(context.TODO(), opts)
+}
+
+func (n SecretClient) Watch(namespace string, opts metav1.ListOptions) (watch.Interface, error) {
+	return n(namespace).Watch(context.TODO(), opts)
+}
+
+func (n SecretClient) Patch(namespace, name string, pt types.PatchType, data []byte, subresources ...string) (*v1.Secret, error) {
+	return n(namespace).Patch(context.TODO(), name, pt, data, metav1.PatchOptions{}, subresources...)
+}
+
+func (n SecretClient) WithImpersonation(_ rest.ImpersonationConfig) (generic.ClientInterface[*v1.Secret, *v1.SecretList], error) {
+	panic("implement me")
+}
+
+type SecretCache func(string) typecorev1.SecretInterface
+
+func (p SecretCache) Get(namespace string, name string) (*v1.Secret, error) {
+	return p(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+func (p SecretCache) List(namespace string, selector labels.Selector) ([]*v1.Secret, error) {
+	items, err := p(namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*v1.Secret, 0, len(items.Items))
+	for _, item := range items.Items {
+		obj := item
+		result = append(result, &obj)
+	}
+	return result, nil
+}
+
+func (p SecretCache) AddIndexer(_ string, _ generic.Indexer[*v1.Secret]) { // #nosec G101
+	//TODO implement me
+	panic("implement me")
+}
+
+func (p SecretCache) GetByIndex(_ string, _ string) ([]*v1.Secret, error) {
+	//TODO implement me
+	panic("implement me")
+}
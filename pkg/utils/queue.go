@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+
+	"k8s.io/utils/strings/slices"
+	schedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+)
+
+// GetQueueNamespaces returns the namespaces supported by the queue, it returns nil if all the namespaces are supported
+func GetQueueNamespaces(queue *schedulingv1.Queue) []string {
+	value := strings.TrimSpace(queue.Annotations[constant.AnnotationSchedulingSupportedNamespacesKey])
+	if value == "" || value == constant.AllNamespaces {
+		return nil
+	}
+
+	namespaces := make([]string, 0)
+	for _, ns := range strings.Split(value, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces
+}
+
+// FormatQueueNamespaces returns the supported namespaces annotation value of the namespaces, all the namespaces are
+// supported if it's empty
+func FormatQueueNamespaces(namespaces []string) string {
+	if len(namespaces) == 0 || slices.Contains(namespaces, constant.AllNamespaces) {
+		return constant.AllNamespaces
+	}
+	sorted := make([]string, len(namespaces))
+	copy(sorted, namespaces)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+// IsQueueNamespaceAllowed checks whether the workloads of the namespace are allowed to be submitted to the queue
+func IsQueueNamespaceAllowed(queue *schedulingv1.Queue, namespace string) bool {
+	namespaces := GetQueueNamespaces(queue)
+	return namespaces == nil || slices.Contains(namespaces, namespace)
+}
+
+// IsDefaultQueue checks whether the queue is the default queue
+func IsDefaultQueue(queue *schedulingv1.Queue) bool {
+	return queue.Annotations[constant.AnnotationDefaultSchedulingKey] == "true"
+}
+
+// GetNamespaceQueue returns the name of the queue bound to the namespace, the queue listing the namespace explicitly
+// wins and the first one by name is used if there are several, it falls back to the default queue otherwise
+func GetNamespaceQueue(queues []*schedulingv1.Queue, namespace string) string {
+	sorted := make([]*schedulingv1.Queue, len(queues))
+	copy(sorted, queues)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	for _, queue := range sorted {
+		if slices.Contains(GetQueueNamespaces(queue), namespace) {
+			return queue.Name
+		}
+	}
+	for _, queue := range sorted {
+		if IsDefaultQueue(queue) && IsQueueNamespaceAllowed(queue, namespace) {
+			return queue.Name
+		}
+	}
+	return constant.DefaultQueueName
+}
@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	rayVersionRegexp = regexp.MustCompile(`^\d+\.\d+\.\d+`)
+	// rayRepositories are the repositories of the images tagged by the Ray versions, the tags of the other images,
+	// e.g., anyscale/ray-llm, are versioned by their own releases
+	rayRepositories = []string{"rayproject/ray", "rayproject/ray-ml", "anyscale/ray", "anyscale/ray-ml"}
+)
+
+// GetRayVersion returns the Ray version by the tag of the Ray image, e.g., 2.9.3 of rayproject/ray-ml:2.9.3-py310,
+// the image may be pulled from a mirror registry. It returns empty if the image isn't one of the known Ray images or
+// the tag isn't versioned, e.g., nightly
+func GetRayVersion(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	repository, tag := image, ""
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		repository, tag = image[:i], image[i+1:]
+	}
+	if !slices.ContainsFunc(rayRepositories, func(r string) bool {
+		return repository == r || strings.HasSuffix(repository, "/"+r)
+	}) {
+		return ""
+	}
+	return rayVersionRegexp.FindString(tag)
+}
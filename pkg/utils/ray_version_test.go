@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRayVersion(t *testing.T) {
+	var testCases = []struct {
+		image    string
+		expected string
+	}{
+		{image: "anyscale/ray:2.9.3", expected: "2.9.3"},
+		{image: "rayproject/ray:2.9.0-py310-gpu", expected: "2.9.0"},
+		{image: "rayproject/ray-ml:2.9.3-py310", expected: "2.9.3"},
+		{image: "registry.local:5000/rayproject/ray:2.10.0@sha256:abcd", expected: "2.10.0"},
+		{image: "registry.local:5000/rayproject/ray", expected: ""},
+		{image: "rayproject/ray:nightly", expected: ""},
+		// the tags of the other images aren't Ray versions
+		{image: "anyscale/ray-llm:2.2.0", expected: ""},
+		{image: "vllm/vllm-openai:0.4.0", expected: ""},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, GetRayVersion(tc.image), "image %q", tc.image)
+	}
+}
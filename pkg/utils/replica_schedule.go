@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+)
+
+// replicaScheduleLookBack limits how far back the start and stop times of a window are searched, a window opened
+// before that is considered closed
+const replicaScheduleLookBack = 7 * 24 * time.Hour
+
+// ValidateReplicaSchedules validates the windows and replicas of the schedules
+func ValidateReplicaSchedules(schedules []mlv1.ReplicaSchedule, maxReplicas int32) error {
+	for _, schedule := range schedules {
+		if schedule.Start == "" || schedule.Stop == "" {
+			return fmt.Errorf("both start and stop of schedule %s are required", schedule.Name)
+		}
+		if _, err := ParseScheduleWindow(schedule.ScheduleWindow); err != nil {
+			return fmt.Errorf("invalid schedule %s: %v", schedule.Name, err)
+		}
+		if schedule.MinReplicas > maxReplicas {
+			return fmt.Errorf("minReplicas %d of schedule %s can't be greater than maxReplicas %d",
+				schedule.MinReplicas, schedule.Name, maxReplicas)
+		}
+	}
+	return nil
+}
+
+// GetScheduledMinReplicas returns the min replicas raised by the open windows at the given time and the duration
+// until the next window opens or closes, the duration is zero if there are no schedules.
+func GetScheduledMinReplicas(minReplicas int32, schedules []mlv1.ReplicaSchedule, now time.Time) (int32, time.Duration, error) {
+	var next time.Duration
+	for _, schedule := range schedules {
+		window, err := ParseScheduleWindow(schedule.ScheduleWindow)
+		if err != nil {
+			return minReplicas, 0, fmt.Errorf("invalid schedule %s: %v", schedule.Name, err)
+		}
+
+		if window.IsOpen(now.Add(-replicaScheduleLookBack), now) && schedule.MinReplicas > minReplicas {
+			minReplicas = schedule.MinReplicas
+		}
+		if t := window.Next(now); !t.IsZero() {
+			if d := t.Sub(now); next == 0 || d < next {
+				next = d
+			}
+		}
+	}
+	return minReplicas, next, nil
+}
@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+)
+
+// ScheduleWindow is a parsed schedule window, either of the start and stop schedules is nil if it's not set
+type ScheduleWindow struct {
+	Start *CronSchedule
+	Stop  *CronSchedule
+}
+
+// ParseScheduleWindow parses the start and stop cron expressions of the window in its timezone
+func ParseScheduleWindow(window mlv1.ScheduleWindow) (*ScheduleWindow, error) {
+	if window.Start == "" && window.Stop == "" {
+		return nil, fmt.Errorf("either start or stop of the schedule is required")
+	}
+	if strings.TrimSpace(window.Start) == strings.TrimSpace(window.Stop) {
+		return nil, fmt.Errorf("start and stop of the schedule can't be the same %q", window.Start)
+	}
+
+	parsed := &ScheduleWindow{}
+	var err error
+	if window.Start != "" {
+		if parsed.Start, err = ParseCronSchedule(window.Start, window.Timezone); err != nil {
+			return nil, fmt.Errorf("invalid start of the schedule: %v", err)
+		}
+	}
+	if window.Stop != "" {
+		if parsed.Stop, err = ParseCronSchedule(window.Stop, window.Timezone); err != nil {
+			return nil, fmt.Errorf("invalid stop of the schedule: %v", err)
+		}
+	}
+	return parsed, nil
+}
+
+// Last returns the last start and stop times after since and not after now, either of them is the zero time if
+// there is none
+func (w *ScheduleWindow) Last(since, now time.Time) (start, stop time.Time) {
+	return getLastScheduleTime(w.Start, since, now), getLastScheduleTime(w.Stop, since, now)
+}
+
+// IsOpen returns whether the window has been opened since the given time and not closed after that, the stop
+// wins if the window is opened and closed at the same time
+func (w *ScheduleWindow) IsOpen(since, now time.Time) bool {
+	start, stop := w.Last(since, now)
+	return !start.IsZero() && start.After(stop)
+}
+
+// Next returns the first time after now the window opens or closes, it returns the zero time if there is none
+func (w *ScheduleWindow) Next(now time.Time) time.Time {
+	var next time.Time
+	for _, schedule := range []*CronSchedule{w.Start, w.Stop} {
+		if schedule == nil {
+			continue
+		}
+		if t := schedule.Next(now); !t.IsZero() && (next.IsZero() || t.Before(next)) {
+			next = t
+		}
+	}
+	return next
+}
+
+// getLastScheduleTime returns the last scheduled time after since and not after now, it steps back from now by a
+// doubling window instead of iterating all the scheduled times since the given time, e.g., the 10080 times of
+// "* * * * *" in a week
+func getLastScheduleTime(schedule *CronSchedule, since, now time.Time) time.Time {
+	var last time.Time
+	if schedule == nil || !now.After(since) {
+		return last
+	}
+
+	span := now.Sub(since)
+	for window := time.Minute; ; {
+		from := since
+		if window < span {
+			from = now.Add(-window)
+		}
+		for t := schedule.Next(from); !t.IsZero() && !t.After(now); t = schedule.Next(t) {
+			last = t
+		}
+		if !last.IsZero() || window >= span {
+			return last
+		}
+		// avoid overflowing the duration if since is far before now
+		if window > span/2 {
+			window = span
+		} else {
+			window *= 2
+		}
+	}
+}
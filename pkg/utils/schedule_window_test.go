@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+)
+
+func Test_getLastScheduleTime(t *testing.T) {
+	now := time.Date(2024, 3, 20, 10, 30, 15, 0, time.UTC)
+	since := now.Add(-7 * 24 * time.Hour)
+
+	var testCases = []struct {
+		expression string
+		since      time.Time
+		expected   time.Time
+	}{
+		{expression: "* * * * *", since: since, expected: time.Date(2024, 3, 20, 10, 30, 0, 0, time.UTC)},
+		{expression: "*/15 8-18 * * mon-fri", since: since, expected: time.Date(2024, 3, 20, 10, 30, 0, 0, time.UTC)},
+		{expression: "* 8 * * *", since: since, expected: time.Date(2024, 3, 20, 8, 59, 0, 0, time.UTC)},
+		{expression: "0 9 * * mon", since: since, expected: time.Date(2024, 3, 18, 9, 0, 0, 0, time.UTC)},
+		{expression: "@monthly", since: since, expected: time.Time{}},
+		{expression: "@monthly", since: time.Time{}, expected: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		// the scheduled time must be after since
+		{expression: "* * * * *", since: time.Date(2024, 3, 20, 10, 30, 0, 0, time.UTC), expected: time.Time{}},
+		{expression: "* * * * *", since: now, expected: time.Time{}},
+	}
+
+	for _, tc := range testCases {
+		schedule, err := ParseCronSchedule(tc.expression, "")
+		require.NoError(t, err, tc.expression)
+		assert.Equal(t, tc.expected, getLastScheduleTime(schedule, tc.since, now), tc.expression)
+	}
+
+	assert.True(t, getLastScheduleTime(nil, since, now).IsZero())
+}
+
+func TestScheduleWindow_IsOpen(t *testing.T) {
+	window, err := ParseScheduleWindow(mlv1.ScheduleWindow{Start: "0 8 * * *", Stop: "* 18-23 * * *"})
+	require.NoError(t, err)
+
+	since := time.Date(2024, 3, 13, 0, 0, 0, 0, time.UTC)
+	assert.True(t, window.IsOpen(since, time.Date(2024, 3, 20, 10, 0, 0, 0, time.UTC)))
+	assert.False(t, window.IsOpen(since, time.Date(2024, 3, 20, 19, 0, 0, 0, time.UTC)))
+	assert.False(t, window.IsOpen(since, time.Date(2024, 3, 20, 7, 0, 0, 0, time.UTC)))
+}
@@ -0,0 +1,28 @@
+package utils
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+)
+
+// SetSyncedSecretSource marks the secret as a copy of the source secret, the synced copies are rotated once the
+// source changes and garbage-collected once there are no workloads using them in their namespace
+func SetSyncedSecretSource(secret *corev1.Secret, source *corev1.Secret) {
+	if secret.Labels == nil {
+		secret.Labels = make(map[string]string, 1)
+	}
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string, 1)
+	}
+	secret.Labels[constant.LabelSyncedSecret] = "true"
+	secret.Annotations[constant.AnnotationSyncedSecretSource] = NewRef(source.Namespace, source.Name)
+}
+
+// GetSyncedSecretSource returns the namespaced name of the source secret, it's empty if the secret isn't a copy
+func GetSyncedSecretSource(secret *corev1.Secret) string {
+	if secret.Labels[constant.LabelSyncedSecret] != "true" {
+		return ""
+	}
+	return secret.Annotations[constant.AnnotationSyncedSecretSource]
+}
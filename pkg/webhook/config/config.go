@@ -9,7 +9,9 @@ import (
 	"k8s.io/client-go/rest"
 
 	obmgmtv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/management.oneblock.ai"
+	obmlv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ml.oneblock.ai"
 	kuberayv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ray.io"
+	schedulingv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/scheduling.volcano.sh"
 	"github.com/oneblock-ai/oneblock/pkg/server/config"
 )
 
@@ -19,7 +21,9 @@ type Management struct {
 	RestConfig  *rest.Config
 
 	OneBlockMgmtFactory *obmgmtv1.Factory
+	OneBlockMLFactory   *obmlv1.Factory
 	KubeRayFactory      *kuberayv1.Factory
+	SchedulingFactory   *schedulingv1.Factory
 	starters            []start.Starter
 }
 
@@ -45,6 +49,13 @@ func SetupManagement(ctx context.Context, restConfig *rest.Config, releaseName s
 	}
 	mgmt.starters = append(mgmt.starters, mgmt.OneBlockMgmtFactory)
 
+	oneblockML, err := obmlv1.NewFactoryFromConfigWithOptions(restConfig, factoryOpts)
+	if err != nil {
+		return nil, err
+	}
+	mgmt.OneBlockMLFactory = oneblockML
+	mgmt.starters = append(mgmt.starters, oneblockML)
+
 	kuberay, err := kuberayv1.NewFactoryFromConfigWithOptions(restConfig, factoryOpts)
 	if err != nil {
 		return nil, err
@@ -52,6 +63,13 @@ func SetupManagement(ctx context.Context, restConfig *rest.Config, releaseName s
 	mgmt.KubeRayFactory = kuberay
 	mgmt.starters = append(mgmt.starters, kuberay)
 
+	scheduling, err := schedulingv1.NewFactoryFromConfigWithOptions(restConfig, factoryOpts)
+	if err != nil {
+		return nil, err
+	}
+	mgmt.SchedulingFactory = scheduling
+	mgmt.starters = append(mgmt.starters, scheduling)
+
 	return mgmt, nil
 }
 
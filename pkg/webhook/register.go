@@ -8,10 +8,18 @@ import (
 	"github.com/oneblock-ai/webhook/pkg/server/admission"
 	"k8s.io/client-go/rest"
 
+	"github.com/oneblock-ai/oneblock/pkg/utils"
 	wconfig "github.com/oneblock-ai/oneblock/pkg/webhook/config"
+	"github.com/oneblock-ai/oneblock/pkg/webhook/resources/mlservice"
 	"github.com/oneblock-ai/oneblock/pkg/webhook/resources/modeltemplate"
 	"github.com/oneblock-ai/oneblock/pkg/webhook/resources/notebook"
+	"github.com/oneblock-ai/oneblock/pkg/webhook/resources/notebookimage"
+	"github.com/oneblock-ai/oneblock/pkg/webhook/resources/notebookprofile"
 	"github.com/oneblock-ai/oneblock/pkg/webhook/resources/raycluster"
+	"github.com/oneblock-ai/oneblock/pkg/webhook/resources/rayjob"
+	"github.com/oneblock-ai/oneblock/pkg/webhook/resources/rayservice"
+	"github.com/oneblock-ai/oneblock/pkg/webhook/resources/serveapplication"
+	settingwebhook "github.com/oneblock-ai/oneblock/pkg/webhook/resources/setting"
 	"github.com/oneblock-ai/oneblock/pkg/webhook/resources/user"
 )
 
@@ -19,14 +27,23 @@ func register(mgmt *wconfig.Management) (validators []admission.Validator, mutat
 	validators = []admission.Validator{
 		user.NewValidator(mgmt),
 		raycluster.NewValidator(mgmt),
-		notebook.NewValidator(),
-		modeltemplate.NewValidator(),
+		notebook.NewValidator(mgmt),
+		notebookimage.NewValidator(mgmt),
+		modeltemplate.NewValidator(mgmt),
+		notebookprofile.NewValidator(),
+		rayjob.NewValidator(mgmt),
+		rayservice.NewValidator(mgmt),
+		serveapplication.NewValidator(mgmt),
+		mlservice.NewValidator(mgmt),
+		settingwebhook.NewValidator(),
 	}
 
 	mutators = []admission.Mutator{
 		user.NewMutator(),
 		raycluster.NewMutator(mgmt),
-		notebook.NewMutator(),
+		notebook.NewMutator(mgmt),
+		rayjob.NewMutator(mgmt),
+		rayservice.NewMutator(mgmt),
 	}
 
 	return
@@ -44,6 +61,10 @@ func Register(ctx context.Context, restConfig *rest.Config, ws *ws.WebhookServer
 
 	validators, mutators := register(mgmt)
 
+	// the accelerator types are validated by the webhook, so the registry is reloaded once the setting changes
+	mgmt.OneBlockMgmtFactory.Management().V1().Setting().OnChange(ctx, "webhook.loadAcceleratorTypes",
+		utils.LoadAcceleratorTypesSetting)
+
 	if err := ws.RegisterValidators(validators...); err != nil {
 		return fmt.Errorf("register validators failed: %w", err)
 	}
@@ -0,0 +1,62 @@
+package mlservice
+
+import (
+	"fmt"
+
+	"github.com/oneblock-ai/webhook/pkg/server/admission"
+	admissionregv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+	ctlmlv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ml.oneblock.ai/v1"
+	ctlrayv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ray.io/v1"
+	"github.com/oneblock-ai/oneblock/pkg/webhook/config"
+)
+
+type validator struct {
+	admission.DefaultValidator
+	serveAppCache   ctlmlv1.ServeApplicationCache
+	rayServiceCache ctlrayv1.RayServiceCache
+}
+
+var _ admission.Validator = &validator{}
+
+func NewValidator(mgmt *config.Management) admission.Validator {
+	return &validator{
+		serveAppCache:   mgmt.OneBlockMLFactory.Ml().V1().ServeApplication().Cache(),
+		rayServiceCache: mgmt.KubeRayFactory.Ray().V1().RayService().Cache(),
+	}
+}
+
+// Create rejects the MLService whose name is taken by a ServeApplication or an existing RayService, the MLService is
+// served by a RayService of the same name which is never taken over from its owner
+func (v *validator) Create(_ *admission.Request, newObj runtime.Object) error {
+	mlService := newObj.(*mlv1.MLService)
+
+	if _, err := v.serveAppCache.Get(mlService.Namespace, mlService.Name); err == nil {
+		return fmt.Errorf("ServeApplication %s/%s of the same name already exists", mlService.Namespace, mlService.Name)
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	if _, err := v.rayServiceCache.Get(mlService.Namespace, mlService.Name); err == nil {
+		return fmt.Errorf("RayService %s/%s of the same name already exists", mlService.Namespace, mlService.Name)
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (v *validator) Resource() admission.Resource {
+	return admission.Resource{
+		Names:      []string{"mlservices"},
+		Scope:      admissionregv1.NamespacedScope,
+		APIGroup:   mlv1.SchemeGroupVersion.Group,
+		APIVersion: mlv1.SchemeGroupVersion.Version,
+		ObjectType: &mlv1.MLService{},
+		OperationTypes: []admissionregv1.OperationType{
+			admissionregv1.Create,
+		},
+	}
+}
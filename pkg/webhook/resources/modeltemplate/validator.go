@@ -2,34 +2,84 @@ package modeltemplate
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/oneblock-ai/webhook/pkg/server/admission"
 	admissionregv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+	ctlmlv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ml.oneblock.ai/v1"
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+	"github.com/oneblock-ai/oneblock/pkg/webhook/config"
 )
 
 type validator struct {
 	admission.DefaultValidator
+	templateVersionCache ctlmlv1.ModelTemplateVersionCache
 }
 
 var _ admission.Validator = &validator{}
 
-func NewValidator() admission.Validator {
-	return &validator{}
+func NewValidator(mgmt *config.Management) admission.Validator {
+	return &validator{
+		templateVersionCache: mgmt.OneBlockMLFactory.Ml().V1().ModelTemplateVersion().Cache(),
+	}
 }
 
 func (v *validator) Create(_ *admission.Request, newObj runtime.Object) error {
 	modelTemplateVersion := newObj.(*mlv1.ModelTemplateVersion)
 
-	return validateModelPathConfig(modelTemplateVersion)
+	if err := v.validateImportedVersion(modelTemplateVersion); err != nil {
+		return err
+	}
+	if err := validateModelPathConfig(modelTemplateVersion); err != nil {
+		return err
+	}
+	return validateDeploymentConfig(modelTemplateVersion)
 }
 
-func (v *validator) Update(_ *admission.Request, _, newObj runtime.Object) error {
+func (v *validator) Update(_ *admission.Request, oldObj, newObj runtime.Object) error {
+	oldVersion := oldObj.(*mlv1.ModelTemplateVersion)
 	modelTemplateVersion := newObj.(*mlv1.ModelTemplateVersion)
 
-	return validateModelPathConfig(modelTemplateVersion)
+	// the imported version number can only be set on create, it's removed once the version is assigned
+	if value, ok := modelTemplateVersion.Annotations[constant.AnnotationModelTemplateVersion]; ok &&
+		value != oldVersion.Annotations[constant.AnnotationModelTemplateVersion] {
+		return fmt.Errorf("annotation %s can't be changed", constant.AnnotationModelTemplateVersion)
+	}
+	if err := validateModelPathConfig(modelTemplateVersion); err != nil {
+		return err
+	}
+	return validateDeploymentConfig(modelTemplateVersion)
+}
+
+// validateImportedVersion rejects the imported version number which is invalid or used by another version of the template
+func (v *validator) validateImportedVersion(modelTmpVersion *mlv1.ModelTemplateVersion) error {
+	value, ok := modelTmpVersion.Annotations[constant.AnnotationModelTemplateVersion]
+	if !ok {
+		return nil
+	}
+	version, err := strconv.Atoi(value)
+	if err != nil || version <= 0 {
+		return fmt.Errorf("invalid imported version %s, must be a positive integer", value)
+	}
+
+	tpvs, err := v.templateVersionCache.List(modelTmpVersion.Namespace, labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, tpv := range tpvs {
+		if tpv.Spec.TemplateName != modelTmpVersion.Spec.TemplateName {
+			continue
+		}
+		if tpv.Status.Version == version || tpv.Annotations[constant.AnnotationModelTemplateVersion] == value {
+			return fmt.Errorf("version %d of template %s already exists", version, modelTmpVersion.Spec.TemplateName)
+		}
+	}
+	return nil
 }
 
 func validateModelPathConfig(modelTmpVersion *mlv1.ModelTemplateVersion) error {
@@ -39,6 +89,23 @@ func validateModelPathConfig(modelTmpVersion *mlv1.ModelTemplateVersion) error {
 	return nil
 }
 
+func validateDeploymentConfig(modelTmpVersion *mlv1.ModelTemplateVersion) error {
+	deploymentConfig := modelTmpVersion.Spec.DeploymentConfig
+	if deploymentConfig.MinReplicas > deploymentConfig.MaxReplicas {
+		return fmt.Errorf("minReplicas %d can't be greater than maxReplicas %d", deploymentConfig.MinReplicas,
+			deploymentConfig.MaxReplicas)
+	}
+
+	if deploymentConfig.SmoothingFactor != "" {
+		factor, err := strconv.ParseFloat(deploymentConfig.SmoothingFactor, 32)
+		if err != nil || factor <= 0 {
+			return fmt.Errorf("invalid smoothingFactor %s, must be a positive number", deploymentConfig.SmoothingFactor)
+		}
+	}
+
+	return utils.ValidateReplicaSchedules(deploymentConfig.Schedules, deploymentConfig.MaxReplicas)
+}
+
 func (v *validator) Resource() admission.Resource {
 	return admission.Resource{
 		Names:      []string{"modelTemplateVersions"},
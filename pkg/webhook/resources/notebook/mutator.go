@@ -1,50 +1,201 @@
 package notebook
 
 import (
+	"fmt"
+
 	"github.com/oneblock-ai/webhook/pkg/server/admission"
 	admissionregv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+	ctlmlv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ml.oneblock.ai/v1"
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+	"github.com/oneblock-ai/oneblock/pkg/webhook/config"
+	"github.com/oneblock-ai/oneblock/pkg/webhook/resources/queue"
 )
 
 type mutator struct {
 	admission.DefaultMutator
+	profileCache ctlmlv1.NotebookProfileCache
+	queueBinding *queue.Binding
 }
 
 var _ admission.Mutator = &mutator{}
 
-func NewMutator() admission.Mutator {
-	return &mutator{}
+func NewMutator(mgmt *config.Management) admission.Mutator {
+	return &mutator{
+		profileCache: mgmt.OneBlockMLFactory.Ml().V1().NotebookProfile().Cache(),
+		queueBinding: queue.NewBinding(mgmt),
+	}
 }
 
+// Create assigns the new notebook to the queue of its namespace, the existing notebooks aren't assigned on update
+// since the queue moves the notebook pod to the volcano scheduler
 func (m *mutator) Create(_ *admission.Request, newObj runtime.Object) (admission.Patch, error) {
 	notebook := newObj.(*mlv1.Notebook)
+	patches, err := m.queueBinding.PatchQueueLabels(notebook, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if notebook.Spec.ProfileRef != "" {
+		profile, err := m.profileCache.Get(notebook.Spec.ProfileRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get notebook profile %s: %w", notebook.Spec.ProfileRef, err)
+		}
+		return m.patchProfile(notebook, profile, patches)
+	}
+	return patchNotebook(notebook, patches), nil
+}
+
+// Update expands the profile only once the profile reference changes, so that the resources edited by the users are
+// kept, and the notebook referring to a deleted profile can still be stopped, started and deleted
+func (m *mutator) Update(_ *admission.Request, oldObj runtime.Object, newObj runtime.Object) (admission.Patch, error) {
+	oldNotebook := oldObj.(*mlv1.Notebook)
+	notebook := newObj.(*mlv1.Notebook)
 
-	patches := make([]admission.PatchOp, 0)
+	if notebook.Spec.ProfileRef != "" && notebook.Spec.ProfileRef != oldNotebook.Spec.ProfileRef {
+		profile, err := m.profileCache.Get(notebook.Spec.ProfileRef)
+		if err == nil {
+			return m.patchProfile(notebook, profile, nil)
+		} else if !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get notebook profile %s: %w", notebook.Spec.ProfileRef, err)
+		}
+	}
+	return patchNotebook(notebook, nil), nil
+}
 
-	rs := notebook.Spec.Template.Spec.Containers[0].Resources
+func (m *mutator) patchProfile(notebook *mlv1.Notebook, profile *mlv1.NotebookProfile, patches admission.Patch) (admission.Patch, error) {
+	podSpec, err := expandProfile(notebook, profile)
+	if err != nil {
+		return nil, err
+	}
+	return append(patches, admission.PatchOp{
+		Op:    admission.PatchOpReplace,
+		Path:  "/spec/template/spec",
+		Value: podSpec,
+	}), nil
+}
+
+// patchNotebook sets the limits of the notebook container to its requests if the limits are not set
+func patchNotebook(notebook *mlv1.Notebook, patches admission.Patch) admission.Patch {
+	containers := notebook.Spec.Template.Spec.Containers
+	if len(containers) == 0 {
+		return patches
+	}
+
+	rs := containers[0].Resources
 	if rs.Requests != nil && rs.Limits == nil {
 		op := patchResourceLimit(rs)
 		patches = append(patches, op)
 	}
 
-	return patches, nil
+	return patches
 }
 
-func (m *mutator) Update(_ *admission.Request, _ runtime.Object, newObj runtime.Object) (admission.Patch, error) {
-	notebook := newObj.(*mlv1.Notebook)
+// expandProfile returns the pod spec of the notebook expanded by the referenced profile, the resources of the profile
+// override the ones of the notebook container while the others are merged into the pod spec
+func expandProfile(notebook *mlv1.Notebook, profile *mlv1.NotebookProfile) (*corev1.PodSpec, error) {
+	podSpec := notebook.Spec.Template.Spec.DeepCopy()
+	if len(podSpec.Containers) == 0 {
+		return nil, fmt.Errorf("notebook %s/%s doesn't have any containers to apply the profile %s", notebook.Namespace,
+			notebook.Name, profile.Name)
+	}
+	container := &podSpec.Containers[0]
+	if container.Resources.Requests == nil {
+		container.Resources.Requests = corev1.ResourceList{}
+	}
+	if container.Resources.Limits == nil {
+		container.Resources.Limits = container.Resources.Requests.DeepCopy()
+	}
 
-	patches := make([]admission.PatchOp, 0)
+	spec := profile.Spec
+	if spec.CPU != nil {
+		setResource(&container.Resources, corev1.ResourceCPU, *spec.CPU)
+	}
+	if spec.Memory != nil {
+		setResource(&container.Resources, corev1.ResourceMemory, *spec.Memory)
+	}
+	if spec.GPU != nil {
+		resourceName := utils.GetAcceleratorResourceName(spec.GPU.Type)
+		if resourceName == "" {
+			return nil, fmt.Errorf("unknown GPU type %s of notebook profile %s", spec.GPU.Type, profile.Name)
+		}
+		setResource(&container.Resources, resourceName, *resource.NewQuantity(int64(spec.GPU.Count), resource.DecimalSI))
+		podSpec.NodeSelector = utils.MergeNodeSelector(podSpec.NodeSelector, utils.GetAcceleratorNodeSelector(spec.GPU.Type))
+	}
 
-	rs := notebook.Spec.Template.Spec.Containers[0].Resources
-	if rs.Requests != nil && rs.Limits == nil {
-		op := patchResourceLimit(rs)
-		patches = append(patches, op)
+	podSpec.NodeSelector = utils.MergeNodeSelector(podSpec.NodeSelector, spec.NodeSelector)
+	if podSpec.RuntimeClassName == nil && spec.RuntimeClassName != nil {
+		podSpec.RuntimeClassName = spec.RuntimeClassName
+	}
+
+	for _, toleration := range spec.Tolerations {
+		if !hasToleration(podSpec.Tolerations, toleration) {
+			podSpec.Tolerations = append(podSpec.Tolerations, toleration)
+		}
+	}
+	for _, volume := range spec.Volumes {
+		if !hasVolume(podSpec.Volumes, volume.Name) {
+			podSpec.Volumes = append(podSpec.Volumes, volume)
+		}
+	}
+	for _, mount := range spec.VolumeMounts {
+		if !hasVolumeMount(container.VolumeMounts, mount.MountPath) {
+			container.VolumeMounts = append(container.VolumeMounts, mount)
+		}
+	}
+	for _, env := range spec.Env {
+		if !hasEnv(container.Env, env.Name) {
+			container.Env = append(container.Env, env)
+		}
+	}
+
+	return podSpec, nil
+}
+
+func setResource(rs *corev1.ResourceRequirements, name corev1.ResourceName, quantity resource.Quantity) {
+	rs.Requests[name] = quantity
+	rs.Limits[name] = quantity
+}
+
+func hasToleration(tolerations []corev1.Toleration, toleration corev1.Toleration) bool {
+	for _, t := range tolerations {
+		if t.MatchToleration(&toleration) {
+			return true
+		}
 	}
+	return false
+}
 
-	return patches, nil
+func hasVolume(volumes []corev1.Volume, name string) bool {
+	for _, v := range volumes {
+		if v.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasVolumeMount(mounts []corev1.VolumeMount, mountPath string) bool {
+	for _, m := range mounts {
+		if m.MountPath == mountPath {
+			return true
+		}
+	}
+	return false
+}
+
+func hasEnv(envs []corev1.EnvVar, name string) bool {
+	for _, e := range envs {
+		if e.Name == name {
+			return true
+		}
+	}
+	return false
 }
 
 func patchResourceLimit(rs corev1.ResourceRequirements) admission.PatchOp {
@@ -1,35 +1,185 @@
 package notebook
 
 import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
 	"github.com/oneblock-ai/webhook/pkg/server/admission"
 	admissionregv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/strings/slices"
 
 	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+	ctlmgmtv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/management.oneblock.ai/v1"
+	ctlmlv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ml.oneblock.ai/v1"
+	"github.com/oneblock-ai/oneblock/pkg/settings"
 	"github.com/oneblock-ai/oneblock/pkg/utils"
 	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+	"github.com/oneblock-ai/oneblock/pkg/webhook/config"
+	"github.com/oneblock-ai/oneblock/pkg/webhook/resources/queue"
 )
 
 type validator struct {
 	admission.DefaultValidator
+	notebookImageCache ctlmlv1.NotebookImageCache
+	settingCache       ctlmgmtv1.SettingCache
+	queueBinding       *queue.Binding
 }
 
 var _ admission.Validator = &validator{}
 
-func NewValidator() admission.Validator {
-	return &validator{}
+func NewValidator(mgmt *config.Management) admission.Validator {
+	return &validator{
+		notebookImageCache: mgmt.OneBlockMLFactory.Ml().V1().NotebookImage().Cache(),
+		settingCache:       mgmt.OneBlockMgmtFactory.Management().V1().Setting().Cache(),
+		queueBinding:       queue.NewBinding(mgmt),
+	}
 }
 
 func (v *validator) Create(_ *admission.Request, newObj runtime.Object) error {
 	notebook := newObj.(*mlv1.Notebook)
 
-	return validateVolumeClaimTemplatesAnnotation(notebook)
+	if err := v.queueBinding.ValidateQueue(notebook); err != nil {
+		return err
+	}
+	if err := validateVolumeClaimTemplatesAnnotation(notebook); err != nil {
+		return err
+	}
+	if err := validateRayClusterRef(notebook); err != nil {
+		return err
+	}
+	if err := validateGitSource(notebook); err != nil {
+		return err
+	}
+	if err := validateSchedule(notebook); err != nil {
+		return err
+	}
+	return v.validateNotebookImage(notebook)
 }
 
-func (v *validator) Update(_ *admission.Request, _, newObj runtime.Object) error {
+func (v *validator) Update(_ *admission.Request, oldObj, newObj runtime.Object) error {
+	oldNotebook := oldObj.(*mlv1.Notebook)
 	notebook := newObj.(*mlv1.Notebook)
 
-	return validateVolumeClaimTemplatesAnnotation(notebook)
+	if err := v.queueBinding.ValidateQueueUpdate(oldNotebook, notebook); err != nil {
+		return err
+	}
+	if err := validateVolumeClaimTemplatesAnnotation(notebook); err != nil {
+		return err
+	}
+	if err := validateRayClusterRef(notebook); err != nil {
+		return err
+	}
+	if err := validateGitSource(notebook); err != nil {
+		return err
+	}
+	if err := validateSchedule(notebook); err != nil {
+		return err
+	}
+
+	// the existing notebooks can still be updated, e.g., stopped or started, unless the image is changed
+	if getNotebookImage(oldNotebook) == getNotebookImage(notebook) {
+		return nil
+	}
+	return v.validateNotebookImage(notebook)
+}
+
+// validateNotebookImage checks the notebook image is an approved NotebookImage of the namespace
+// if the restrict-notebook-images setting is enabled
+func (v *validator) validateNotebookImage(notebook *mlv1.Notebook) error {
+	restricted, err := v.isImageRestricted()
+	if err != nil || !restricted {
+		return err
+	}
+
+	image := getNotebookImage(notebook)
+	images, err := v.notebookImageCache.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, approved := range images {
+		if approved.Spec.Image != image {
+			continue
+		}
+		if len(approved.Spec.AllowedNamespaces) == 0 || slices.Contains(approved.Spec.AllowedNamespaces, notebook.Namespace) {
+			return nil
+		}
+	}
+	return fmt.Errorf("image %s is not an approved notebook image of namespace %s", image, notebook.Namespace)
+}
+
+// isImageRestricted reads the setting from the cache since the webhook server doesn't have the settings provider
+func (v *validator) isImageRestricted() (bool, error) {
+	value := settings.RestrictNotebookImages.Default
+	setting, err := v.settingCache.Get(settings.RestrictNotebookImagesSettingName)
+	if err != nil && !errors.IsNotFound(err) {
+		return false, err
+	}
+	if setting != nil {
+		if setting.Value != "" {
+			value = setting.Value
+		} else if setting.Default != "" {
+			value = setting.Default
+		}
+	}
+	return strconv.ParseBool(value)
+}
+
+// validateRayClusterRef only allows connecting the RayClusters of the notebook namespace or the public namespace,
+// since the network policies are added to the namespace of the RayCluster
+func validateRayClusterRef(notebook *mlv1.Notebook) error {
+	ref := notebook.Spec.RayClusterRef
+	if ref == nil {
+		return nil
+	}
+	if ref.Name == "" {
+		return fmt.Errorf("name of the rayClusterRef is required")
+	}
+	if ref.Namespace != "" && ref.Namespace != notebook.Namespace && ref.Namespace != constant.PublicNamespaceName {
+		return fmt.Errorf("RayCluster %s/%s is not allowed, only the RayClusters of namespace %s or %s can be referenced",
+			ref.Namespace, ref.Name, notebook.Namespace, constant.PublicNamespaceName)
+	}
+	return nil
+}
+
+// validateGitSource checks the target dir of the git repository is inside the working dir of the notebook
+func validateGitSource(notebook *mlv1.Notebook) error {
+	git := notebook.Spec.Git
+	if git == nil {
+		return nil
+	}
+	if git.Repository == "" {
+		return fmt.Errorf("repository of the git source is required")
+	}
+	if git.TargetDir != "" {
+		targetDir := path.Clean(git.TargetDir)
+		if path.IsAbs(targetDir) || targetDir == "." || targetDir == ".." || strings.HasPrefix(targetDir, "../") {
+			return fmt.Errorf("invalid targetDir %s of the git source, it must be a sub directory of the working dir", git.TargetDir)
+		}
+	}
+	return nil
+}
+
+// validateSchedule checks the cron expressions and the timezone of the start and stop schedules
+func validateSchedule(notebook *mlv1.Notebook) error {
+	schedule := notebook.Spec.Schedule
+	if schedule == nil {
+		return nil
+	}
+	_, err := utils.ParseScheduleWindow(*schedule)
+	return err
+}
+
+func getNotebookImage(notebook *mlv1.Notebook) string {
+	containers := notebook.Spec.Template.Spec.Containers
+	if len(containers) == 0 {
+		return ""
+	}
+	return containers[0].Image
 }
 
 func validateVolumeClaimTemplatesAnnotation(cluster *mlv1.Notebook) error {
@@ -0,0 +1,73 @@
+package notebookimage
+
+import (
+	"fmt"
+
+	"github.com/oneblock-ai/webhook/pkg/server/admission"
+	admissionregv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+	ctlmlv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ml.oneblock.ai/v1"
+	"github.com/oneblock-ai/oneblock/pkg/webhook/config"
+)
+
+type validator struct {
+	admission.DefaultValidator
+	notebookImageCache ctlmlv1.NotebookImageCache
+}
+
+var _ admission.Validator = &validator{}
+
+func NewValidator(mgmt *config.Management) admission.Validator {
+	return &validator{
+		notebookImageCache: mgmt.OneBlockMLFactory.Ml().V1().NotebookImage().Cache(),
+	}
+}
+
+func (v *validator) Create(_ *admission.Request, newObj runtime.Object) error {
+	return v.validateDefault(newObj.(*mlv1.NotebookImage))
+}
+
+func (v *validator) Update(_ *admission.Request, oldObj runtime.Object, newObj runtime.Object) error {
+	oldImage := oldObj.(*mlv1.NotebookImage)
+	newImage := newObj.(*mlv1.NotebookImage)
+	if oldImage.Spec.Default == newImage.Spec.Default && oldImage.Spec.Type == newImage.Spec.Type {
+		return nil
+	}
+	return v.validateDefault(newImage)
+}
+
+// validateDefault makes sure there is only one default image of each notebook type
+func (v *validator) validateDefault(image *mlv1.NotebookImage) error {
+	if !image.Spec.Default {
+		return nil
+	}
+
+	images, err := v.notebookImageCache.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, existing := range images {
+		if existing.Name != image.Name && existing.Spec.Default && existing.Spec.Type == image.Spec.Type {
+			return fmt.Errorf("notebook image %s is already the default image of type %s, unset it first",
+				existing.Name, image.Spec.Type)
+		}
+	}
+	return nil
+}
+
+func (v *validator) Resource() admission.Resource {
+	return admission.Resource{
+		Names:      []string{"notebookimages"},
+		Scope:      admissionregv1.ClusterScope,
+		APIGroup:   mlv1.SchemeGroupVersion.Group,
+		APIVersion: mlv1.SchemeGroupVersion.Version,
+		ObjectType: &mlv1.NotebookImage{},
+		OperationTypes: []admissionregv1.OperationType{
+			admissionregv1.Create,
+			admissionregv1.Update,
+		},
+	}
+}
@@ -0,0 +1,65 @@
+package notebookprofile
+
+import (
+	"fmt"
+
+	"github.com/oneblock-ai/webhook/pkg/server/admission"
+	admissionregv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+)
+
+type validator struct {
+	admission.DefaultValidator
+}
+
+var _ admission.Validator = &validator{}
+
+func NewValidator() admission.Validator {
+	return &validator{}
+}
+
+func (v *validator) Create(_ *admission.Request, newObj runtime.Object) error {
+	profile := newObj.(*mlv1.NotebookProfile)
+	return validateProfile(profile)
+}
+
+func (v *validator) Update(_ *admission.Request, _, newObj runtime.Object) error {
+	profile := newObj.(*mlv1.NotebookProfile)
+	return validateProfile(profile)
+}
+
+func validateProfile(profile *mlv1.NotebookProfile) error {
+	spec := profile.Spec
+	if spec.GPU != nil && utils.GetAcceleratorResourceName(spec.GPU.Type) == "" {
+		return fmt.Errorf("unknown GPU type %s", spec.GPU.Type)
+	}
+
+	// each of the volume mounts must refer to a volume of the profile
+	volumes := make(map[string]bool, len(spec.Volumes))
+	for _, volume := range spec.Volumes {
+		volumes[volume.Name] = true
+	}
+	for _, mount := range spec.VolumeMounts {
+		if !volumes[mount.Name] {
+			return fmt.Errorf("volume %s of mount path %s is not found in the profile volumes", mount.Name, mount.MountPath)
+		}
+	}
+	return nil
+}
+
+func (v *validator) Resource() admission.Resource {
+	return admission.Resource{
+		Names:      []string{"notebookprofiles"},
+		Scope:      admissionregv1.ClusterScope,
+		APIGroup:   mlv1.SchemeGroupVersion.Group,
+		APIVersion: mlv1.SchemeGroupVersion.Version,
+		ObjectType: &mlv1.NotebookProfile{},
+		OperationTypes: []admissionregv1.OperationType{
+			admissionregv1.Create,
+			admissionregv1.Update,
+		},
+	}
+}
@@ -0,0 +1,91 @@
+package queue
+
+import (
+	"fmt"
+
+	"github.com/oneblock-ai/webhook/pkg/server/admission"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	ctlschedulv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/scheduling.volcano.sh/v1beta1"
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+	"github.com/oneblock-ai/oneblock/pkg/webhook/config"
+)
+
+// Binding assigns the workloads to the queues bound to their namespaces and validates the queues of the workloads,
+// it's shared by the RayCluster, RayService, RayJob and Notebook webhooks
+type Binding struct {
+	queueCache ctlschedulv1.QueueCache
+}
+
+func NewBinding(mgmt *config.Management) *Binding {
+	return &Binding{
+		queueCache: mgmt.SchedulingFactory.Scheduling().V1beta1().Queue().Cache(),
+	}
+}
+
+// PatchQueueLabels returns the patch of the labels assigning the workload to the queue bound to its namespace if no
+// queue is set, the KubeRay scheduler name label is also set for the Ray workloads
+func (b *Binding) PatchQueueLabels(obj metav1.Object, rayWorkload bool) ([]admission.PatchOp, error) {
+	objLabels := obj.GetLabels()
+	setScheduler := rayWorkload && objLabels[constant.LabelRaySchedulerName] == ""
+	if objLabels[constant.LabelVolcanoQueueName] != "" && !setScheduler {
+		return nil, nil
+	}
+
+	newLabels := make(map[string]string, len(objLabels)+2)
+	for k, v := range objLabels {
+		newLabels[k] = v
+	}
+	if setScheduler {
+		newLabels[constant.LabelRaySchedulerName] = constant.VolcanoSchedulerName
+	}
+	if newLabels[constant.LabelVolcanoQueueName] == "" {
+		queues, err := b.queueCache.List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		newLabels[constant.LabelVolcanoQueueName] = utils.GetNamespaceQueue(queues, obj.GetNamespace())
+	}
+
+	return []admission.PatchOp{
+		{
+			Op:    admission.PatchOpAdd,
+			Path:  "/metadata/labels",
+			Value: newLabels,
+		},
+	}, nil
+}
+
+// ValidateQueue rejects the workload targeting a queue which doesn't exist or isn't allowed for its namespace
+func (b *Binding) ValidateQueue(obj metav1.Object) error {
+	queueName := obj.GetLabels()[constant.LabelVolcanoQueueName]
+	if queueName == "" {
+		return nil
+	}
+
+	fldPath := field.NewPath("metadata", "labels").Key(constant.LabelVolcanoQueueName)
+	queue, err := b.queueCache.Get(queueName)
+	if errors.IsNotFound(err) {
+		return field.NotFound(fldPath, queueName)
+	} else if err != nil {
+		return fmt.Errorf("failed to get queue %s: %w", queueName, err)
+	}
+
+	if !utils.IsQueueNamespaceAllowed(queue, obj.GetNamespace()) {
+		return field.Forbidden(fldPath, fmt.Sprintf("queue %s is not allowed for namespace %s", queueName, obj.GetNamespace()))
+	}
+	return nil
+}
+
+// ValidateQueueUpdate validates the queue of the updated workload if it's changed, the workloads already in the queue
+// are kept updatable even if the supported namespaces of the queue are changed later
+func (b *Binding) ValidateQueueUpdate(oldObj, newObj metav1.Object) error {
+	if oldObj.GetLabels()[constant.LabelVolcanoQueueName] == newObj.GetLabels()[constant.LabelVolcanoQueueName] {
+		return nil
+	}
+	return b.ValidateQueue(newObj)
+}
@@ -13,20 +13,24 @@ import (
 	"k8s.io/utils/pointer"
 
 	clusterctl "github.com/oneblock-ai/oneblock/pkg/controller/raycluster"
+	"github.com/oneblock-ai/oneblock/pkg/utils"
 	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
 	"github.com/oneblock-ai/oneblock/pkg/webhook/config"
+	"github.com/oneblock-ai/oneblock/pkg/webhook/resources/queue"
 )
 
 type mutator struct {
 	admission.DefaultMutator
-	releaseName string
+	releaseName  string
+	queueBinding *queue.Binding
 }
 
 var _ admission.Mutator = &mutator{}
 
 func NewMutator(mgmt *config.Management) admission.Mutator {
 	return &mutator{
-		releaseName: mgmt.ReleaseName,
+		releaseName:  mgmt.ReleaseName,
+		queueBinding: queue.NewBinding(mgmt),
 	}
 }
 
@@ -34,13 +38,21 @@ func (m *mutator) Create(_ *admission.Request, newObj runtime.Object) (admission
 	cluster := newObj.(*rayv1.RayCluster)
 	logrus.Debugf("[webhook mutating]raycluster %s is created", cluster.Name)
 
-	// skip updating the object if it is originated from RayService
+	// skip updating the object if it is originated from RayService, the defaults are applied by the RayService mutator
 	if isOwnedByRayService(cluster) {
 		logrus.Debugln("cluster is originated from rayService, skip mutating")
 		return nil, nil
 	}
 
-	patchOps := make([]admission.PatchOp, 0)
+	// the cluster without containers is rejected by the validator
+	if !HasContainers(&cluster.Spec) {
+		return nil, nil
+	}
+
+	patchOps, err := m.queueBinding.PatchQueueLabels(cluster, true)
+	if err != nil {
+		return nil, err
+	}
 
 	var gcsEnabled = false
 
@@ -67,12 +79,17 @@ func (m *mutator) Update(_ *admission.Request, oldObj runtime.Object, newObj run
 	cluster := newObj.(*rayv1.RayCluster)
 	logrus.Debugf("[webhook mutating]raycluster %s is updated", cluster.Name)
 
-	// skip updating the object if it is originated from RayService
+	// skip updating the object if it is originated from RayService, the defaults are applied by the RayService mutator
 	if isOwnedByRayService(cluster) {
 		logrus.Debugln("cluster is originated from rayService, skip mutating")
 		return nil, nil
 	}
 
+	// the cluster without containers is rejected by the validator
+	if !HasContainers(&cluster.Spec) {
+		return nil, nil
+	}
+
 	val, ok := cluster.Annotations[constant.AnnotationRayClusterEnableGCS]
 	// turn off GCS config is not allowed
 	if valOld, okOld := oldCluster.Annotations[constant.AnnotationRayClusterEnableGCS]; okOld && valOld == "true" {
@@ -81,7 +98,10 @@ func (m *mutator) Update(_ *admission.Request, oldObj runtime.Object, newObj run
 		}
 	}
 
-	patchOps := make([]admission.PatchOp, 0)
+	patchOps, err := m.queueBinding.PatchQueueLabels(cluster, true)
+	if err != nil {
+		return nil, err
+	}
 	var gcsEnabled = false
 
 	// patch fault-tolerant annotation if GCS is enabled
@@ -104,24 +124,49 @@ func (m *mutator) Update(_ *admission.Request, oldObj runtime.Object, newObj run
 }
 
 func patchHeadGroupSpec(cluster *rayv1.RayCluster, gcsEnabled bool, releaseName string) admission.PatchOp {
-	headGroupSpec := cluster.Spec.HeadGroupSpec
-	headGroupSpec.RayStartParams = patchHeadGroupStartParams(cluster, gcsEnabled)
-	headGroupSpec.Template.Spec.Containers[0].Ports = patchHeadGroupPorts(cluster)
-	headGroupSpec.Template.Spec.Containers[0].Resources.Limits = patchResourceLimits(headGroupSpec.Template)
-	headGroupSpec.Template.Spec.Containers[0].Lifecycle = patchContainerLifecycle(headGroupSpec.Template)
-	if gcsEnabled {
-		headGroupSpec.Template.Spec.Containers[0].Env = getHeadGroupEnvPath(cluster, releaseName)
-	}
-
 	return admission.PatchOp{
 		Op:    admission.PatchOpReplace,
 		Path:  "/spec/headGroupSpec",
-		Value: headGroupSpec,
+		Value: getHeadGroupSpec(&cluster.Spec, cluster.Namespace, gcsEnabled, releaseName),
+	}
+}
+
+// SetClusterSpecDefaults applies the head and worker group defaults of the RayCluster to the cluster spec of the
+// RayJob and RayService, their clusters are created by KubeRay from the spec
+func SetClusterSpecDefaults(spec *rayv1.RayClusterSpec, namespace string, gcsEnabled bool, releaseName string) {
+	spec.HeadGroupSpec = getHeadGroupSpec(spec, namespace, gcsEnabled, releaseName)
+	if len(spec.WorkerGroupSpecs) > 0 {
+		spec.WorkerGroupSpecs = patchWorkerGroupStartParams(spec)
 	}
 }
 
-func patchHeadGroupStartParams(cluster *rayv1.RayCluster, gcsEnabled bool) map[string]string {
-	rayStartParams := cluster.Spec.HeadGroupSpec.RayStartParams
+// HasContainers checks all the groups have containers, the invalid spec is rejected by the validator
+func HasContainers(spec *rayv1.RayClusterSpec) bool {
+	if len(spec.HeadGroupSpec.Template.Spec.Containers) == 0 {
+		return false
+	}
+	for _, group := range spec.WorkerGroupSpecs {
+		if len(group.Template.Spec.Containers) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func getHeadGroupSpec(spec *rayv1.RayClusterSpec, namespace string, gcsEnabled bool, releaseName string) rayv1.HeadGroupSpec {
+	headGroupSpec := spec.HeadGroupSpec
+	headGroupSpec.RayStartParams = patchHeadGroupStartParams(spec, gcsEnabled)
+	headGroupSpec.Template.Spec.Containers[0].Ports = patchHeadGroupPorts(spec)
+	headGroupSpec.Template.Spec.Containers[0].Resources.Limits = patchResourceLimits(headGroupSpec.Template)
+	headGroupSpec.Template.Spec.Containers[0].Lifecycle = patchContainerLifecycle(headGroupSpec.Template)
+	if gcsEnabled {
+		headGroupSpec.Template.Spec.Containers[0].Env = getHeadGroupEnvPath(spec, namespace, releaseName)
+	}
+	return headGroupSpec
+}
+
+func patchHeadGroupStartParams(spec *rayv1.RayClusterSpec, gcsEnabled bool) map[string]string {
+	rayStartParams := spec.HeadGroupSpec.RayStartParams
 	if rayStartParams == nil {
 		rayStartParams = map[string]string{
 			"dashboard-host": "0.0.0.0",
@@ -139,10 +184,10 @@ func patchHeadGroupStartParams(cluster *rayv1.RayCluster, gcsEnabled bool) map[s
 	return rayStartParams
 }
 
-func patchHeadGroupPorts(cluster *rayv1.RayCluster) []corev1.ContainerPort {
-	ports := cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Ports
+func patchHeadGroupPorts(spec *rayv1.RayClusterSpec) []corev1.ContainerPort {
+	ports := spec.HeadGroupSpec.Template.Spec.Containers[0].Ports
 	if len(ports) > 0 {
-		return cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Ports
+		return ports
 	}
 	return []corev1.ContainerPort{
 		{
@@ -207,9 +252,9 @@ func getRayFTAnnotationPatch(cluster *rayv1.RayCluster) admission.PatchOp {
 	}
 }
 
-func getHeadGroupEnvPath(cluster *rayv1.RayCluster, releaseName string) []corev1.EnvVar {
-	headGroupEnv := cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Env
-	redisEnvConfig := clusterctl.GetHeadNodeRedisEnvConfig(releaseName, cluster.Namespace)
+func getHeadGroupEnvPath(spec *rayv1.RayClusterSpec, namespace, releaseName string) []corev1.EnvVar {
+	headGroupEnv := spec.HeadGroupSpec.Template.Spec.Containers[0].Env
+	redisEnvConfig := clusterctl.GetHeadNodeRedisEnvConfig(releaseName, namespace)
 	if headGroupEnv == nil || len(headGroupEnv) == 0 {
 		headGroupEnv = redisEnvConfig
 	} else {
@@ -231,7 +276,7 @@ func getHeadGroupEnvPath(cluster *rayv1.RayCluster, releaseName string) []corev1
 }
 
 func patchWorkerGroupSpecs(cluster *rayv1.RayCluster) admission.PatchOp {
-	workerGroupSpecs := patchWorkerGroupStartParams(cluster)
+	workerGroupSpecs := patchWorkerGroupStartParams(&cluster.Spec)
 	return admission.PatchOp{
 		Op:    admission.PatchOpReplace,
 		Path:  "/spec/workerGroupSpecs",
@@ -239,8 +284,8 @@ func patchWorkerGroupSpecs(cluster *rayv1.RayCluster) admission.PatchOp {
 	}
 }
 
-func patchWorkerGroupStartParams(cluster *rayv1.RayCluster) []rayv1.WorkerGroupSpec {
-	workerGroupSpecs := cluster.Spec.WorkerGroupSpecs
+func patchWorkerGroupStartParams(spec *rayv1.RayClusterSpec) []rayv1.WorkerGroupSpec {
+	workerGroupSpecs := spec.WorkerGroupSpecs
 	for i, workerGroupSpec := range workerGroupSpecs {
 		rayStartParams := workerGroupSpec.RayStartParams
 		if rayStartParams == nil {
@@ -254,10 +299,26 @@ func patchWorkerGroupStartParams(cluster *rayv1.RayCluster) []rayv1.WorkerGroupS
 		workerGroupSpecs[i].Template.Spec.Containers[0].Resources.Limits = patchResourceLimits(workerGroupSpec.Template)
 		workerGroupSpecs[i].Template.Spec.Containers[0].Env = pathWorkerNodeEnvConf(workerGroupSpec)
 		workerGroupSpecs[i].Template.Spec.Containers[0].Lifecycle = patchContainerLifecycle(workerGroupSpec.Template)
+		workerGroupSpecs[i].Template.Spec.NodeSelector = patchAcceleratorNodeSelector(workerGroupSpec.Template)
 	}
 	return workerGroupSpecs
 }
 
+// patchAcceleratorNodeSelector selects the nodes of the accelerator type requested by the accelerator-type label of
+// the worker template, the nodes are labeled with their types by the accelerator discovery, and the nodes already
+// selected by the users are respected
+func patchAcceleratorNodeSelector(spec corev1.PodTemplateSpec) map[string]string {
+	nodeSelector := spec.Spec.NodeSelector
+	acceleratorType, ok := spec.Labels[constant.LabelAcceleratorType]
+	if !ok || acceleratorType == "" {
+		return nodeSelector
+	}
+	if _, ok = nodeSelector[constant.LabelAcceleratorType]; ok {
+		return nodeSelector
+	}
+	return utils.MergeNodeSelector(nodeSelector, utils.GetAcceleratorNodeSelector(acceleratorType))
+}
+
 func patchEnableInTreeAutoscaling() admission.PatchOp {
 	return admission.PatchOp{
 		Op:    admission.PatchOpReplace,
@@ -2,22 +2,29 @@ package raycluster
 
 import (
 	"fmt"
+	"slices"
+	"sort"
 
 	"github.com/oneblock-ai/webhook/pkg/server/admission"
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 	"github.com/sirupsen/logrus"
 	admissionregv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	ctlrayv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ray.io/v1"
 	"github.com/oneblock-ai/oneblock/pkg/utils"
 	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
 	"github.com/oneblock-ai/oneblock/pkg/webhook/config"
+	"github.com/oneblock-ai/oneblock/pkg/webhook/resources/queue"
 )
 
 type validator struct {
 	admission.DefaultValidator
 	rayClusterCache ctlrayv1.RayClusterCache
+	queueBinding    *queue.Binding
 }
 
 var _ admission.Validator = &validator{}
@@ -25,6 +32,7 @@ var _ admission.Validator = &validator{}
 func NewValidator(mgmt *config.Management) admission.Validator {
 	return &validator{
 		rayClusterCache: mgmt.KubeRayFactory.Ray().V1().RayCluster().Cache(),
+		queueBinding:    queue.NewBinding(mgmt),
 	}
 }
 
@@ -33,35 +41,216 @@ func (v *validator) Create(_ *admission.Request, newObj runtime.Object) error {
 
 	logrus.Debugf("[webhook validating]raycluster %s is created", cluster.Name)
 
-	if err := validateAutoScalingWithWorkerGroupSpecs(cluster); err != nil {
+	if err := v.queueBinding.ValidateQueue(cluster); err != nil {
+		return err
+	}
+	if err := ValidateClusterSpec(&cluster.Spec, field.NewPath("spec")); err != nil {
 		return err
 	}
 
 	return validateVolumeClaimTemplatesAnnotation(cluster)
 }
 
-func (v *validator) Update(_ *admission.Request, _, newObj runtime.Object) error {
+func (v *validator) Update(_ *admission.Request, oldObj, newObj runtime.Object) error {
+	oldCluster := oldObj.(*rayv1.RayCluster)
 	cluster := newObj.(*rayv1.RayCluster)
 
 	logrus.Debugf("[webhook validating]raycluster %s is updated", cluster.Name)
 
-	if err := validateAutoScalingWithWorkerGroupSpecs(cluster); err != nil {
+	if err := v.queueBinding.ValidateQueueUpdate(oldCluster, cluster); err != nil {
+		return err
+	}
+	if err := ValidateClusterSpecUpdate(&oldCluster.Spec, &cluster.Spec, field.NewPath("spec")); err != nil {
 		return err
 	}
 
 	return validateVolumeClaimTemplatesAnnotation(cluster)
 }
 
+// ValidateClusterSpec validates the cluster spec of the RayCluster, it's also used by the RayJob and RayService
+// whose clusters are created by KubeRay from the spec, the errors are reported with the field paths under fldPath
+func ValidateClusterSpec(spec *rayv1.RayClusterSpec, fldPath *field.Path) error {
+	var errs field.ErrorList
+	headPath := fldPath.Child("headGroupSpec", "template", "spec")
+	errs = append(errs, validatePodSpec(&spec.HeadGroupSpec.Template.Spec, headPath)...)
+
+	workersPath := fldPath.Child("workerGroupSpecs")
+	for i := range spec.WorkerGroupSpecs {
+		errs = append(errs, validateWorkerGroupSpec(&spec.WorkerGroupSpecs[i], workersPath.Index(i))...)
+	}
+
+	errs = append(errs, validateAutoScalingWithWorkerGroupSpecs(spec, fldPath)...)
+	errs = append(errs, validateRayVersion(spec, fldPath)...)
+	return errs.ToAggregate()
+}
+
+// ValidateClusterSpecUpdate validates the changed parts of the cluster spec, so that the updates of KubeRay and the
+// autoscaler to the existing clusters, e.g., scaling the worker replicas, aren't rejected by the checks
+func ValidateClusterSpecUpdate(oldSpec, spec *rayv1.RayClusterSpec, fldPath *field.Path) error {
+	var errs field.ErrorList
+	headPath := fldPath.Child("headGroupSpec", "template", "spec")
+	templateChanged := !equality.Semantic.DeepEqual(oldSpec.HeadGroupSpec.Template.Spec, spec.HeadGroupSpec.Template.Spec)
+	if templateChanged {
+		errs = append(errs, validatePodSpec(&spec.HeadGroupSpec.Template.Spec, headPath)...)
+	}
+
+	oldGroups := make(map[string]*rayv1.WorkerGroupSpec, len(oldSpec.WorkerGroupSpecs))
+	for i := range oldSpec.WorkerGroupSpecs {
+		oldGroups[oldSpec.WorkerGroupSpecs[i].GroupName] = &oldSpec.WorkerGroupSpecs[i]
+	}
+	workersPath := fldPath.Child("workerGroupSpecs")
+	for i := range spec.WorkerGroupSpecs {
+		group := &spec.WorkerGroupSpecs[i]
+		oldGroup, ok := oldGroups[group.GroupName]
+		if !ok {
+			templateChanged = true
+			errs = append(errs, validateWorkerGroupSpec(group, workersPath.Index(i))...)
+			continue
+		}
+		if !equality.Semantic.DeepEqual(oldGroup.Template.Spec, group.Template.Spec) {
+			templateChanged = true
+			errs = append(errs, validatePodSpec(&group.Template.Spec, workersPath.Index(i).Child("template", "spec"))...)
+		}
+		// the replicas scaled by the autoscaler are only validated if the bounds are changed
+		if !equality.Semantic.DeepEqual(oldGroup.MinReplicas, group.MinReplicas) ||
+			!equality.Semantic.DeepEqual(oldGroup.MaxReplicas, group.MaxReplicas) {
+			errs = append(errs, validateWorkerGroupReplicas(group, workersPath.Index(i))...)
+		}
+	}
+
+	errs = append(errs, validateAutoScalingWithWorkerGroupSpecs(spec, fldPath)...)
+	if templateChanged {
+		errs = append(errs, validateRayVersion(spec, fldPath)...)
+	}
+	return errs.ToAggregate()
+}
+
+func validateWorkerGroupSpec(group *rayv1.WorkerGroupSpec, fldPath *field.Path) field.ErrorList {
+	errs := validatePodSpec(&group.Template.Spec, fldPath.Child("template", "spec"))
+	return append(errs, validateWorkerGroupReplicas(group, fldPath)...)
+}
+
+func validateWorkerGroupReplicas(group *rayv1.WorkerGroupSpec, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	minReplicas, maxReplicas := group.MinReplicas, group.MaxReplicas
+	if minReplicas != nil && *minReplicas < 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("minReplicas"), *minReplicas, "must be greater than or equal to 0"))
+	}
+	if minReplicas != nil && maxReplicas != nil && *minReplicas > *maxReplicas {
+		errs = append(errs, field.Invalid(fldPath.Child("minReplicas"), *minReplicas,
+			fmt.Sprintf("must be less than or equal to maxReplicas %d", *maxReplicas)))
+	}
+	if replicas := group.Replicas; replicas != nil {
+		if minReplicas != nil && *replicas < *minReplicas {
+			errs = append(errs, field.Invalid(fldPath.Child("replicas"), *replicas,
+				fmt.Sprintf("must be greater than or equal to minReplicas %d", *minReplicas)))
+		}
+		if maxReplicas != nil && *replicas > *maxReplicas {
+			errs = append(errs, field.Invalid(fldPath.Child("replicas"), *replicas,
+				fmt.Sprintf("must be less than or equal to maxReplicas %d", *maxReplicas)))
+		}
+	}
+	return errs
+}
+
+// validatePodSpec checks the pod has containers since the first one is taken as the Ray container, and its
+// accelerator requests match the accelerator nodes selected by the nodeSelector
+func validatePodSpec(podSpec *corev1.PodSpec, fldPath *field.Path) field.ErrorList {
+	if len(podSpec.Containers) == 0 {
+		return field.ErrorList{field.Required(fldPath.Child("containers"), "the Ray container is required")}
+	}
+
+	var errs field.ErrorList
+	selectorPath := fldPath.Child("nodeSelector")
+	expected, selectorKey := getSelectedAcceleratorResource(podSpec.NodeSelector)
+	if selectorKey != "" && expected == "" {
+		return append(errs, field.NotSupported(selectorPath.Key(selectorKey), podSpec.NodeSelector[selectorKey],
+			utils.GetAcceleratorTypes()))
+	}
+
+	requested := false
+	for i, container := range podSpec.Containers {
+		resourcesPath := fldPath.Child("containers").Index(i).Child("resources")
+		for _, name := range getAcceleratorResources(container.Resources) {
+			requested = true
+			if expected != "" && name != expected {
+				errs = append(errs, field.Invalid(resourcesPath, name,
+					fmt.Sprintf("the nodes selected by %s=%s provide %s", selectorKey, podSpec.NodeSelector[selectorKey], expected)))
+			}
+		}
+	}
+	if expected != "" && !requested {
+		errs = append(errs, field.Invalid(selectorPath.Key(selectorKey), podSpec.NodeSelector[selectorKey],
+			fmt.Sprintf("accelerator nodes are selected, but none of the containers requests %s", expected)))
+	}
+	return errs
+}
+
+// getSelectedAcceleratorResource returns the accelerator resource of the nodes selected by the node selector and
+// the label key of the selector, the resource is empty if the accelerator type is unknown
+func getSelectedAcceleratorResource(nodeSelector map[string]string) (corev1.ResourceName, string) {
+	if acceleratorType, ok := nodeSelector[constant.LabelAcceleratorType]; ok {
+		return utils.GetAcceleratorResourceName(acceleratorType), constant.LabelAcceleratorType
+	}
+	if _, ok := nodeSelector[constant.LabelNvidiaGPUProduct]; ok {
+		return utils.ResourceNvidiaGPU, constant.LabelNvidiaGPUProduct
+	}
+	return "", ""
+}
+
+// getAcceleratorResources returns the accelerator resources requested by the container
+func getAcceleratorResources(resources corev1.ResourceRequirements) []corev1.ResourceName {
+	names := make([]corev1.ResourceName, 0)
+	for _, list := range []corev1.ResourceList{resources.Limits, resources.Requests} {
+		for name, quantity := range list {
+			if !utils.IsAcceleratorResource(name) || quantity.IsZero() || slices.Contains(names, name) {
+				continue
+			}
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
+
 // validateAutoScalingWithWorkerGroupSpecs checks if enableInTreeAutoscaling is true, workerGroupSpecs should be defined
-func validateAutoScalingWithWorkerGroupSpecs(cluster *rayv1.RayCluster) error {
-	if cluster.Spec.EnableInTreeAutoscaling != nil && *cluster.Spec.EnableInTreeAutoscaling == true {
-		if cluster.Spec.WorkerGroupSpecs == nil || len(cluster.Spec.WorkerGroupSpecs) == 0 {
-			return fmt.Errorf("enableInTreeAutoscaling is true, but workerGroupSpecs is not defined")
+func validateAutoScalingWithWorkerGroupSpecs(spec *rayv1.RayClusterSpec, fldPath *field.Path) field.ErrorList {
+	if spec.EnableInTreeAutoscaling != nil && *spec.EnableInTreeAutoscaling == true {
+		if spec.WorkerGroupSpecs == nil || len(spec.WorkerGroupSpecs) == 0 {
+			return field.ErrorList{field.Required(fldPath.Child("workerGroupSpecs"),
+				"enableInTreeAutoscaling is true, but workerGroupSpecs is not defined")}
 		}
 	}
 	return nil
 }
 
+// validateRayVersion checks the Ray images of the workers are the same version as the head, since the nodes of
+// different Ray versions can't join the same cluster
+func validateRayVersion(spec *rayv1.RayClusterSpec, fldPath *field.Path) field.ErrorList {
+	headContainers := spec.HeadGroupSpec.Template.Spec.Containers
+	if len(headContainers) == 0 {
+		return nil
+	}
+	headVersion := utils.GetRayVersion(headContainers[0].Image)
+	if headVersion == "" {
+		return nil
+	}
+
+	var errs field.ErrorList
+	for i, group := range spec.WorkerGroupSpecs {
+		if len(group.Template.Spec.Containers) == 0 {
+			continue
+		}
+		image := group.Template.Spec.Containers[0].Image
+		if version := utils.GetRayVersion(image); version != "" && version != headVersion {
+			imagePath := fldPath.Child("workerGroupSpecs").Index(i).Child("template", "spec", "containers").Index(0).Child("image")
+			errs = append(errs, field.Invalid(imagePath, image,
+				fmt.Sprintf("Ray version %s mismatches the Ray version %s of the head", version, headVersion)))
+		}
+	}
+	return errs
+}
+
 func validateVolumeClaimTemplatesAnnotation(cluster *rayv1.RayCluster) error {
 	volumeClaimTemplates, ok := cluster.Annotations[constant.AnnotationVolumeClaimTemplates]
 	if !ok || volumeClaimTemplates == "" {
@@ -0,0 +1,81 @@
+package rayjob
+
+import (
+	"github.com/oneblock-ai/webhook/pkg/server/admission"
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/sirupsen/logrus"
+	admissionregv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/oneblock-ai/oneblock/pkg/webhook/config"
+	"github.com/oneblock-ai/oneblock/pkg/webhook/resources/queue"
+	"github.com/oneblock-ai/oneblock/pkg/webhook/resources/raycluster"
+)
+
+type mutator struct {
+	admission.DefaultMutator
+	releaseName  string
+	queueBinding *queue.Binding
+}
+
+var _ admission.Mutator = &mutator{}
+
+func NewMutator(mgmt *config.Management) admission.Mutator {
+	return &mutator{
+		releaseName:  mgmt.ReleaseName,
+		queueBinding: queue.NewBinding(mgmt),
+	}
+}
+
+func (m *mutator) Create(_ *admission.Request, newObj runtime.Object) (admission.Patch, error) {
+	job := newObj.(*rayv1.RayJob)
+	logrus.Debugf("[webhook mutating]rayjob %s/%s is created", job.Namespace, job.Name)
+
+	return m.patchRayJob(job)
+}
+
+func (m *mutator) Update(_ *admission.Request, _ runtime.Object, newObj runtime.Object) (admission.Patch, error) {
+	job := newObj.(*rayv1.RayJob)
+	logrus.Debugf("[webhook mutating]rayjob %s/%s is updated", job.Namespace, job.Name)
+
+	return m.patchRayJob(job)
+}
+
+// patchRayJob applies the RayCluster defaults to the cluster spec of the job, the jobs submitted to the existing
+// clusters by the clusterSelector are not patched
+func (m *mutator) patchRayJob(job *rayv1.RayJob) (admission.Patch, error) {
+	patchOps := make([]admission.PatchOp, 0)
+	if job.Spec.RayClusterSpec == nil || !raycluster.HasContainers(job.Spec.RayClusterSpec) {
+		return patchOps, nil
+	}
+
+	// the labels and annotations of the job are copied to its cluster by KubeRay
+	queueOps, err := m.queueBinding.PatchQueueLabels(job, true)
+	if err != nil {
+		return nil, err
+	}
+	patchOps = append(patchOps, queueOps...)
+
+	spec := job.Spec.RayClusterSpec.DeepCopy()
+	raycluster.SetClusterSpecDefaults(spec, job.Namespace, false, m.releaseName)
+	patchOps = append(patchOps, admission.PatchOp{
+		Op:    admission.PatchOpReplace,
+		Path:  "/spec/rayClusterSpec",
+		Value: spec,
+	})
+	return patchOps, nil
+}
+
+func (m *mutator) Resource() admission.Resource {
+	return admission.Resource{
+		Names:      []string{"rayjobs"},
+		Scope:      admissionregv1.NamespacedScope,
+		APIGroup:   rayv1.SchemeGroupVersion.Group,
+		APIVersion: rayv1.SchemeGroupVersion.Version,
+		ObjectType: &rayv1.RayJob{},
+		OperationTypes: []admissionregv1.OperationType{
+			admissionregv1.Create,
+			admissionregv1.Update,
+		},
+	}
+}
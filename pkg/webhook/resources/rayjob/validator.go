@@ -0,0 +1,103 @@
+package rayjob
+
+import (
+	"fmt"
+
+	"github.com/oneblock-ai/webhook/pkg/server/admission"
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/sirupsen/logrus"
+	admissionregv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+	"github.com/oneblock-ai/oneblock/pkg/webhook/config"
+	"github.com/oneblock-ai/oneblock/pkg/webhook/resources/queue"
+	"github.com/oneblock-ai/oneblock/pkg/webhook/resources/raycluster"
+)
+
+type validator struct {
+	admission.DefaultValidator
+	queueBinding *queue.Binding
+}
+
+var _ admission.Validator = &validator{}
+
+func NewValidator(mgmt *config.Management) admission.Validator {
+	return &validator{
+		queueBinding: queue.NewBinding(mgmt),
+	}
+}
+
+func (v *validator) Create(_ *admission.Request, newObj runtime.Object) error {
+	job := newObj.(*rayv1.RayJob)
+	logrus.Debugf("[webhook validating]rayjob %s/%s is created", job.Namespace, job.Name)
+
+	if err := v.queueBinding.ValidateQueue(job); err != nil {
+		return err
+	}
+	return validateRayJob(nil, job)
+}
+
+func (v *validator) Update(_ *admission.Request, oldObj, newObj runtime.Object) error {
+	oldJob := oldObj.(*rayv1.RayJob)
+	job := newObj.(*rayv1.RayJob)
+	logrus.Debugf("[webhook validating]rayjob %s/%s is updated", job.Namespace, job.Name)
+
+	if err := v.queueBinding.ValidateQueueUpdate(oldJob, job); err != nil {
+		return err
+	}
+	return validateRayJob(oldJob, job)
+}
+
+// validateRayJob validates the job, only the changed cluster spec is validated if the old job is given
+func validateRayJob(oldJob, job *rayv1.RayJob) error {
+	if err := validateClusterSpec(oldJob, job); err != nil {
+		return err
+	}
+	if job.Spec.RuntimeEnv != "" && job.Spec.RuntimeEnvYAML != "" {
+		return fmt.Errorf("runtimeEnv and runtimeEnvYAML can't be set at the same time, runtimeEnv is deprecated")
+	}
+
+	volumeClaimTemplates, ok := job.Annotations[constant.AnnotationVolumeClaimTemplates]
+	if !ok || volumeClaimTemplates == "" {
+		return nil
+	}
+	return utils.ValidateVolumeClaimTemplatesAnnotation(volumeClaimTemplates)
+}
+
+// validateClusterSpec checks the job either creates a new cluster by the rayClusterSpec or runs on an existing
+// cluster selected by the clusterSelector
+func validateClusterSpec(oldJob, job *rayv1.RayJob) error {
+	spec := job.Spec.RayClusterSpec
+	if spec == nil {
+		if len(job.Spec.ClusterSelector) == 0 {
+			return fmt.Errorf("either rayClusterSpec or clusterSelector is required")
+		}
+		return nil
+	}
+	if len(job.Spec.ClusterSelector) > 0 {
+		return fmt.Errorf("rayClusterSpec and clusterSelector can't be set at the same time")
+	}
+
+	fldPath := field.NewPath("spec", "rayClusterSpec")
+	if oldJob != nil && oldJob.Spec.RayClusterSpec != nil {
+		return raycluster.ValidateClusterSpecUpdate(oldJob.Spec.RayClusterSpec, spec, fldPath)
+	}
+	return raycluster.ValidateClusterSpec(spec, fldPath)
+}
+
+func (v *validator) Resource() admission.Resource {
+	return admission.Resource{
+		Names:      []string{"rayjobs"},
+		Scope:      admissionregv1.NamespacedScope,
+		APIGroup:   rayv1.SchemeGroupVersion.Group,
+		APIVersion: rayv1.SchemeGroupVersion.Version,
+		ObjectType: &rayv1.RayJob{},
+		OperationTypes: []admissionregv1.OperationType{
+			admissionregv1.Create,
+			admissionregv1.Update,
+		},
+	}
+}
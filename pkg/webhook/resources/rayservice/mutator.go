@@ -0,0 +1,115 @@
+package rayservice
+
+import (
+	"github.com/oneblock-ai/webhook/pkg/server/admission"
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/sirupsen/logrus"
+	admissionregv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+	"github.com/oneblock-ai/oneblock/pkg/webhook/config"
+	"github.com/oneblock-ai/oneblock/pkg/webhook/resources/queue"
+	"github.com/oneblock-ai/oneblock/pkg/webhook/resources/raycluster"
+)
+
+type mutator struct {
+	admission.DefaultMutator
+	releaseName  string
+	queueBinding *queue.Binding
+}
+
+var _ admission.Mutator = &mutator{}
+
+func NewMutator(mgmt *config.Management) admission.Mutator {
+	return &mutator{
+		releaseName:  mgmt.ReleaseName,
+		queueBinding: queue.NewBinding(mgmt),
+	}
+}
+
+func (m *mutator) Create(_ *admission.Request, newObj runtime.Object) (admission.Patch, error) {
+	rayService := newObj.(*rayv1.RayService)
+	logrus.Debugf("[webhook mutating]rayservice %s/%s is created", rayService.Namespace, rayService.Name)
+
+	return m.patchRayService(rayService)
+}
+
+func (m *mutator) Update(_ *admission.Request, _ runtime.Object, newObj runtime.Object) (admission.Patch, error) {
+	rayService := newObj.(*rayv1.RayService)
+	logrus.Debugf("[webhook mutating]rayservice %s/%s is updated", rayService.Namespace, rayService.Name)
+
+	return m.patchRayService(rayService)
+}
+
+// patchRayService applies the RayCluster defaults to the cluster spec of the RayService, since the clusters owned by
+// the RayService are skipped by the RayCluster mutator, the RayServices compiled from the MLServices and
+// ServeApplications are skipped as they are configured by the controller, while all the RayServices are assigned to
+// the queues bound to their namespaces
+func (m *mutator) patchRayService(rayService *rayv1.RayService) (admission.Patch, error) {
+	// the labels of the RayService are copied to its clusters by KubeRay
+	patchOps, err := m.queueBinding.PatchQueueLabels(rayService, true)
+	if err != nil {
+		return nil, err
+	}
+	if isOwnedByMLResource(rayService) || !raycluster.HasContainers(&rayService.Spec.RayClusterSpec) {
+		return patchOps, nil
+	}
+
+	spec := rayService.Spec.RayClusterSpec.DeepCopy()
+	gcsEnabled := rayService.Annotations[constant.AnnotationRayClusterEnableGCS] == "true"
+	if gcsEnabled {
+		// the annotations of the RayService are copied to its clusters by KubeRay
+		if rayService.Annotations[constant.AnnotationRayFTEnabledKey] != "true" {
+			annotations := make(map[string]string, len(rayService.Annotations)+1)
+			for k, v := range rayService.Annotations {
+				annotations[k] = v
+			}
+			annotations[constant.AnnotationRayFTEnabledKey] = "true"
+			patchOps = append(patchOps, admission.PatchOp{
+				Op:    admission.PatchOpReplace,
+				Path:  "/metadata/annotations",
+				Value: annotations,
+			})
+		}
+		// enable in-tree autoscaling if gcs is enabled
+		if spec.EnableInTreeAutoscaling == nil || *spec.EnableInTreeAutoscaling {
+			spec.EnableInTreeAutoscaling = pointer.Bool(true)
+		}
+	}
+
+	raycluster.SetClusterSpecDefaults(spec, rayService.Namespace, gcsEnabled, m.releaseName)
+	patchOps = append(patchOps, admission.PatchOp{
+		Op:    admission.PatchOpReplace,
+		Path:  "/spec/rayClusterConfig",
+		Value: spec,
+	})
+	return patchOps, nil
+}
+
+func isOwnedByMLResource(rayService *rayv1.RayService) bool {
+	for _, owner := range rayService.OwnerReferences {
+		gv, err := schema.ParseGroupVersion(owner.APIVersion)
+		if err == nil && gv.Group == mlv1.SchemeGroupVersion.Group {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *mutator) Resource() admission.Resource {
+	return admission.Resource{
+		Names:      []string{"rayservices"},
+		Scope:      admissionregv1.NamespacedScope,
+		APIGroup:   rayv1.SchemeGroupVersion.Group,
+		APIVersion: rayv1.SchemeGroupVersion.Version,
+		ObjectType: &rayv1.RayService{},
+		OperationTypes: []admissionregv1.OperationType{
+			admissionregv1.Create,
+			admissionregv1.Update,
+		},
+	}
+}
@@ -0,0 +1,92 @@
+package rayservice
+
+import (
+	"fmt"
+
+	"github.com/oneblock-ai/webhook/pkg/server/admission"
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/sirupsen/logrus"
+	admissionregv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+
+	"github.com/oneblock-ai/oneblock/pkg/utils/constant"
+	"github.com/oneblock-ai/oneblock/pkg/webhook/config"
+	"github.com/oneblock-ai/oneblock/pkg/webhook/resources/queue"
+	"github.com/oneblock-ai/oneblock/pkg/webhook/resources/raycluster"
+)
+
+type validator struct {
+	admission.DefaultValidator
+	queueBinding *queue.Binding
+}
+
+var _ admission.Validator = &validator{}
+
+func NewValidator(mgmt *config.Management) admission.Validator {
+	return &validator{
+		queueBinding: queue.NewBinding(mgmt),
+	}
+}
+
+func (v *validator) Create(_ *admission.Request, newObj runtime.Object) error {
+	rayService := newObj.(*rayv1.RayService)
+	logrus.Debugf("[webhook validating]rayservice %s/%s is created", rayService.Namespace, rayService.Name)
+
+	if err := v.queueBinding.ValidateQueue(rayService); err != nil {
+		return err
+	}
+	return validateRayService(nil, rayService)
+}
+
+func (v *validator) Update(_ *admission.Request, oldObj, newObj runtime.Object) error {
+	oldRayService := oldObj.(*rayv1.RayService)
+	rayService := newObj.(*rayv1.RayService)
+	logrus.Debugf("[webhook validating]rayservice %s/%s is updated", rayService.Namespace, rayService.Name)
+
+	// turn off GCS config is not allowed
+	if oldRayService.Annotations[constant.AnnotationRayClusterEnableGCS] == "true" &&
+		rayService.Annotations[constant.AnnotationRayClusterEnableGCS] != "true" {
+		return fmt.Errorf("GCS is not allowed to be disabled once enabled")
+	}
+	if err := v.queueBinding.ValidateQueueUpdate(oldRayService, rayService); err != nil {
+		return err
+	}
+
+	return validateRayService(oldRayService, rayService)
+}
+
+// validateRayService validates the RayService, only the changed cluster spec is validated if the old one is given
+func validateRayService(oldRayService, rayService *rayv1.RayService) error {
+	fldPath := field.NewPath("spec", "rayClusterConfig")
+	if oldRayService != nil {
+		if err := raycluster.ValidateClusterSpecUpdate(&oldRayService.Spec.RayClusterSpec, &rayService.Spec.RayClusterSpec, fldPath); err != nil {
+			return err
+		}
+	} else if err := raycluster.ValidateClusterSpec(&rayService.Spec.RayClusterSpec, fldPath); err != nil {
+		return err
+	}
+
+	if rayService.Spec.ServeConfigV2 != "" {
+		serveConfig := make(map[string]interface{})
+		if err := yaml.Unmarshal([]byte(rayService.Spec.ServeConfigV2), &serveConfig); err != nil {
+			return fmt.Errorf("invalid serveConfigV2: %w", err)
+		}
+	}
+	return nil
+}
+
+func (v *validator) Resource() admission.Resource {
+	return admission.Resource{
+		Names:      []string{"rayservices"},
+		Scope:      admissionregv1.NamespacedScope,
+		APIGroup:   rayv1.SchemeGroupVersion.Group,
+		APIVersion: rayv1.SchemeGroupVersion.Version,
+		ObjectType: &rayv1.RayService{},
+		OperationTypes: []admissionregv1.OperationType{
+			admissionregv1.Create,
+			admissionregv1.Update,
+		},
+	}
+}
@@ -0,0 +1,62 @@
+package serveapplication
+
+import (
+	"fmt"
+
+	"github.com/oneblock-ai/webhook/pkg/server/admission"
+	admissionregv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	mlv1 "github.com/oneblock-ai/oneblock/pkg/apis/ml.oneblock.ai/v1"
+	ctlmlv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ml.oneblock.ai/v1"
+	ctlrayv1 "github.com/oneblock-ai/oneblock/pkg/generated/controllers/ray.io/v1"
+	"github.com/oneblock-ai/oneblock/pkg/webhook/config"
+)
+
+type validator struct {
+	admission.DefaultValidator
+	mlServiceCache  ctlmlv1.MLServiceCache
+	rayServiceCache ctlrayv1.RayServiceCache
+}
+
+var _ admission.Validator = &validator{}
+
+func NewValidator(mgmt *config.Management) admission.Validator {
+	return &validator{
+		mlServiceCache:  mgmt.OneBlockMLFactory.Ml().V1().MLService().Cache(),
+		rayServiceCache: mgmt.KubeRayFactory.Ray().V1().RayService().Cache(),
+	}
+}
+
+// Create rejects the application whose name is taken by an MLService or an existing RayService, the application is
+// compiled into a RayService of the same name and its PVCs are named by the RayService
+func (v *validator) Create(_ *admission.Request, newObj runtime.Object) error {
+	serveApp := newObj.(*mlv1.ServeApplication)
+
+	if _, err := v.mlServiceCache.Get(serveApp.Namespace, serveApp.Name); err == nil {
+		return fmt.Errorf("MLService %s/%s of the same name already exists", serveApp.Namespace, serveApp.Name)
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	if _, err := v.rayServiceCache.Get(serveApp.Namespace, serveApp.Name); err == nil {
+		return fmt.Errorf("RayService %s/%s of the same name already exists", serveApp.Namespace, serveApp.Name)
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (v *validator) Resource() admission.Resource {
+	return admission.Resource{
+		Names:      []string{"serveapplications"},
+		Scope:      admissionregv1.NamespacedScope,
+		APIGroup:   mlv1.SchemeGroupVersion.Group,
+		APIVersion: mlv1.SchemeGroupVersion.Version,
+		ObjectType: &mlv1.ServeApplication{},
+		OperationTypes: []admissionregv1.OperationType{
+			admissionregv1.Create,
+		},
+	}
+}
@@ -0,0 +1,61 @@
+package setting
+
+import (
+	"fmt"
+
+	"github.com/oneblock-ai/webhook/pkg/server/admission"
+	admissionregv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	mgmtv1 "github.com/oneblock-ai/oneblock/pkg/apis/management.oneblock.ai/v1"
+	"github.com/oneblock-ai/oneblock/pkg/settings"
+	"github.com/oneblock-ai/oneblock/pkg/utils"
+)
+
+type validator struct {
+	admission.DefaultValidator
+}
+
+var _ admission.Validator = &validator{}
+
+func NewValidator() admission.Validator {
+	return &validator{}
+}
+
+func (v *validator) Create(_ *admission.Request, newObj runtime.Object) error {
+	return validateSetting(newObj.(*mgmtv1.Setting))
+}
+
+func (v *validator) Update(_ *admission.Request, _ runtime.Object, newObj runtime.Object) error {
+	return validateSetting(newObj.(*mgmtv1.Setting))
+}
+
+// validateSetting rejects the invalid values of the settings parsed by the server, the empty value falls back
+// to the default
+func validateSetting(setting *mgmtv1.Setting) error {
+	if setting.Value == "" {
+		return nil
+	}
+
+	switch setting.Name {
+	case settings.AcceleratorTypesSettingName:
+		if _, err := utils.ParseAcceleratorTypes(setting.Value); err != nil {
+			return fmt.Errorf("invalid value of setting %s: %w", setting.Name, err)
+		}
+	}
+	return nil
+}
+
+func (v *validator) Resource() admission.Resource {
+	return admission.Resource{
+		Names:      []string{"settings"},
+		Scope:      admissionregv1.ClusterScope,
+		APIGroup:   mgmtv1.SchemeGroupVersion.Group,
+		APIVersion: mgmtv1.SchemeGroupVersion.Version,
+		ObjectType: &mgmtv1.Setting{},
+		OperationTypes: []admissionregv1.OperationType{
+			admissionregv1.Create,
+			admissionregv1.Update,
+		},
+	}
+}